@@ -0,0 +1,8 @@
+// Package goever is Go binding of Ever SDK, Ever joins use cases of all modules of the core library over one
+// client gateway.
+//
+// Exported functions of the binding don't panic on malformed output of the core library. Responses which can't be
+// parsed are returned as errors, subscriptions of Net report them on their error channel, and failures of app
+// objects, including panics of application handlers, are resolved as errors and passed to the hook of
+// domain.WithAppObjectErrorHook.
+package goever
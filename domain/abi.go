@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -341,21 +342,37 @@ type (
 
 	AbiUseCase interface {
 		EncodeMessageBody(*ParamsOfEncodeMessageBody) (*ResultOfEncodeMessageBody, error)
+		EncodeMessageBodyContext(context.Context, *ParamsOfEncodeMessageBody) (*ResultOfEncodeMessageBody, error)
 		AttachSignatureToMessageBody(*ParamsOfAttachSignatureToMessageBody) (*ResultOfAttachSignatureToMessageBody, error)
+		AttachSignatureToMessageBodyContext(context.Context, *ParamsOfAttachSignatureToMessageBody) (*ResultOfAttachSignatureToMessageBody, error)
 		EncodeMessage(*ParamsOfEncodeMessage) (*ResultOfEncodeMessage, error)
+		EncodeMessageContext(context.Context, *ParamsOfEncodeMessage) (*ResultOfEncodeMessage, error)
 		EncodeInternalMessage(*ParamsOfEncodeInternalMessage) (*ResultOfEncodeInternalMessage, error)
+		EncodeInternalMessageContext(context.Context, *ParamsOfEncodeInternalMessage) (*ResultOfEncodeInternalMessage, error)
 		AttachSignature(*ParamsOfAttachSignature) (*ResultOfAttachSignature, error)
+		AttachSignatureContext(context.Context, *ParamsOfAttachSignature) (*ResultOfAttachSignature, error)
 		DecodeMessage(*ParamsOfDecodeMessage) (*DecodedMessageBody, error)
+		DecodeMessageContext(context.Context, *ParamsOfDecodeMessage) (*DecodedMessageBody, error)
 		DecodeMessageBody(*ParamsOfDecodeMessageBody) (*DecodedMessageBody, error)
+		DecodeMessageBodyContext(context.Context, *ParamsOfDecodeMessageBody) (*DecodedMessageBody, error)
 		EncodeAccount(*ParamsOfEncodeAccount) (*ResultOfEncodeAccount, error)
+		EncodeAccountContext(context.Context, *ParamsOfEncodeAccount) (*ResultOfEncodeAccount, error)
 		DecodeAccountData(*ParamsOfDecodeAccountData) (*ResultOfDecodeData, error)
+		DecodeAccountDataContext(context.Context, *ParamsOfDecodeAccountData) (*ResultOfDecodeData, error)
 		UpdateInitialData(*ParamsOfUpdateInitialData) (*ResultOfUpdateInitialData, error)
+		UpdateInitialDataContext(context.Context, *ParamsOfUpdateInitialData) (*ResultOfUpdateInitialData, error)
 		EncodeInitialData(*ParamsOfEncodeInitialData) (*ResultOfEncodeInitialData, error)
+		EncodeInitialDataContext(context.Context, *ParamsOfEncodeInitialData) (*ResultOfEncodeInitialData, error)
 		DecodeInitialData(*ParamsOfDecodeInitialData) (*ResultOfDecodeInitialData, error)
+		DecodeInitialDataContext(context.Context, *ParamsOfDecodeInitialData) (*ResultOfDecodeInitialData, error)
 		DecodeBoc(*ParamsOfDecodeBoc) (*ResultOfDecodeBoc, error)
+		DecodeBocContext(context.Context, *ParamsOfDecodeBoc) (*ResultOfDecodeBoc, error)
 		EncodeBoc(*ParamsOfAbiEncodeBoc) (*ResultOfAbiEncodeBoc, error)
+		EncodeBocContext(context.Context, *ParamsOfAbiEncodeBoc) (*ResultOfAbiEncodeBoc, error)
 		CalcFunctionID(*ParamsOfCalcFunctionId) (*ResultOfCalcFunctionId, error)
+		CalcFunctionIDContext(context.Context, *ParamsOfCalcFunctionId) (*ResultOfCalcFunctionId, error)
 		GetSignatureData(*ParamsOfGetSignatureData) (*ResultOfGetSignatureData, error)
+		GetSignatureDataContext(context.Context, *ParamsOfGetSignatureData) (*ResultOfGetSignatureData, error)
 	}
 )
 
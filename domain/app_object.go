@@ -0,0 +1,220 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// ErrAppRequestTimeout - handler of app request didn't return in time, the request is resolved with error.
+var ErrAppRequestTimeout = errors.New("app request timeout")
+
+type (
+	// AppHandler handles request or notification of app object, params is the decoded value of the params enum,
+	// e.g. ParamsOfAppSigningBoxSign. The result of notification is ignored. ctx is done at the timeout of
+	// WithAppRequestTimeout or when serving stops, handler has to return then: the request is resolved already and
+	// a handler which doesn't return keeps its goroutine.
+	AppHandler func(ctx context.Context, params interface{}) (interface{}, error)
+
+	// AppObjectHandlers - typed handler table of app object.
+	AppObjectHandlers struct {
+		// Name of app object for errors, e.g. AppSigningBox.
+		Name string
+		// Decode returns value of the params enum of request data or notification.
+		Decode func(data json.RawMessage) (interface{}, error)
+		// Encode marshals value returned by handler of request to the result enum.
+		Encode func(value interface{}) (json.RawMessage, error)
+		// Requests - handlers of requests by type of params, e.g. "Sign".
+		Requests map[string]AppHandler
+		// Notifications - handlers of notifications by type of params, e.g. "Log".
+		Notifications map[string]AppHandler
+	}
+
+	// AppObjectError - failure of app object: request or notification which couldn't be handled or resolved.
+	AppObjectError struct {
+		Name         string
+		Type         string
+		AppRequestID int
+		IsNotify     bool
+		Err          error
+	}
+
+	// AppObjectRegistry dispatches requests and notifications of app objects to their handler tables.
+	AppObjectRegistry struct {
+		client  ClientGateway
+		timeout time.Duration
+		onError func(*AppObjectError)
+	}
+
+	// AppObjectOption - option of AppObjectRegistry.
+	AppObjectOption func(*AppObjectRegistry)
+)
+
+func (e *AppObjectError) Error() string {
+	if e.IsNotify {
+		return fmt.Sprintf("%s: notification %s: %v", e.Name, e.Type, e.Err)
+	}
+
+	return fmt.Sprintf("%s: request %d %s: %v", e.Name, e.AppRequestID, e.Type, e.Err)
+}
+
+func (e *AppObjectError) Unwrap() error {
+	return e.Err
+}
+
+// WithAppRequestTimeout limits time of handling of one app request, zero means no limit.
+func WithAppRequestTimeout(timeout time.Duration) AppObjectOption {
+	return func(r *AppObjectRegistry) {
+		r.timeout = timeout
+	}
+}
+
+// WithAppObjectErrorHook sets hook which gets failures of app objects, they are logged by default.
+func WithAppObjectErrorHook(onError func(*AppObjectError)) AppObjectOption {
+	return func(r *AppObjectRegistry) {
+		r.onError = onError
+	}
+}
+
+// NewAppObjectRegistry returns registry which resolves app requests through client.
+func NewAppObjectRegistry(client ClientGateway, opts ...AppObjectOption) *AppObjectRegistry {
+	r := &AppObjectRegistry{
+		client: client,
+		onError: func(err *AppObjectError) {
+			log.Printf("ever-client-go: %v", err)
+		},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Serve handles requests and notifications of stream in order until the stream ends or ctx is done.
+// Every request is resolved: with the result of handler or with error when it fails, panics or times out.
+func (r *AppObjectRegistry) Serve(ctx context.Context, stream *Stream, handlers *AppObjectHandlers) {
+	for {
+		response, err := stream.Next(ctx)
+		if err != nil {
+			return
+		}
+
+		switch response.Code {
+		case ResponseAppRequest:
+			r.request(ctx, response.Data, handlers)
+		case ResponseAppNotify:
+			r.notify(ctx, response.Data, handlers)
+		}
+	}
+}
+
+func (r *AppObjectRegistry) request(ctx context.Context, data json.RawMessage, handlers *AppObjectHandlers) {
+	appRequest := &ParamsOfAppRequest{}
+	if err := json.Unmarshal(data, appRequest); err != nil {
+		// There is no id to resolve the request with.
+		r.fail(&AppObjectError{Name: handlers.Name, Err: err})
+		return
+	}
+
+	requestType := enumType(appRequest.RequestData)
+	var result json.RawMessage
+	value, err := r.handle(ctx, handlers, handlers.Requests, appRequest.RequestData)
+	if err == nil {
+		result, err = r.encode(handlers, value)
+	}
+
+	appRequestResult := NewAppRequestResult(AppRequestResultOk{Result: result})
+	if err != nil {
+		r.fail(&AppObjectError{Name: handlers.Name, Type: requestType, AppRequestID: appRequest.AppRequestID, Err: err})
+		appRequestResult = NewAppRequestResult(AppRequestResultError{Text: err.Error()})
+	}
+
+	err = r.client.ResolveAppRequest(&ParamsOfResolveAppRequest{
+		AppRequestID: appRequest.AppRequestID,
+		Result:       appRequestResult,
+	})
+	if err != nil && !errors.Is(err, ErrClientClosed) {
+		r.fail(&AppObjectError{Name: handlers.Name, Type: requestType, AppRequestID: appRequest.AppRequestID, Err: err})
+	}
+}
+
+func (r *AppObjectRegistry) notify(ctx context.Context, data json.RawMessage, handlers *AppObjectHandlers) {
+	if _, err := r.handle(ctx, handlers, handlers.Notifications, data); err != nil {
+		r.fail(&AppObjectError{Name: handlers.Name, Type: enumType(data), IsNotify: true, Err: err})
+	}
+}
+
+// handle decodes params and calls their handler, the handler runs with panic recovery and the timeout of registry.
+// ctx of the handler is cancelled when handle returns.
+func (r *AppObjectRegistry) handle(ctx context.Context, handlers *AppObjectHandlers, table map[string]AppHandler,
+	data json.RawMessage) (interface{}, error) {
+	params, err := handlers.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	handler, isFound := table[enumType(data)]
+	if !isFound {
+		return nil, fmt.Errorf("unsupported type %q", enumType(data))
+	}
+
+	var cancel context.CancelFunc
+	if r.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	type handled struct {
+		value interface{}
+		err   error
+	}
+	done := make(chan handled, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- handled{err: fmt.Errorf("handler panicked: %v", p)}
+			}
+		}()
+		value, err := handler(ctx, params)
+		done <- handled{value: value, err: err}
+	}()
+
+	select {
+	case h := <-done:
+		return h.value, h.err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, ErrAppRequestTimeout
+		}
+		return nil, ctx.Err()
+	}
+}
+
+func (r *AppObjectRegistry) encode(handlers *AppObjectHandlers, value interface{}) (result json.RawMessage, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("encode panicked: %v", p)
+		}
+	}()
+
+	return handlers.Encode(value)
+}
+
+func (r *AppObjectRegistry) fail(err *AppObjectError) {
+	if r.onError != nil {
+		r.onError(err)
+	}
+}
+
+// enumType returns type of enum value in data, it is empty when data isn't an enum.
+func enumType(data json.RawMessage) string {
+	var value EnumType
+	_ = json.Unmarshal(data, &value)
+
+	return value.Type
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -219,25 +220,45 @@ type (
 
 	BocUseCase interface {
 		DecodeTvc(*ParamsOfDecodeTvc) (*ResultOfDecodeTvc, error)
+		DecodeTvcContext(context.Context, *ParamsOfDecodeTvc) (*ResultOfDecodeTvc, error)
 		ParseMessage(*ParamsOfParse) (*ResultOfParse, error)
+		ParseMessageContext(context.Context, *ParamsOfParse) (*ResultOfParse, error)
 		ParseTransaction(*ParamsOfParse) (*ResultOfParse, error)
+		ParseTransactionContext(context.Context, *ParamsOfParse) (*ResultOfParse, error)
 		ParseAccount(*ParamsOfParse) (*ResultOfParse, error)
+		ParseAccountContext(context.Context, *ParamsOfParse) (*ResultOfParse, error)
 		ParseBlock(*ParamsOfParse) (*ResultOfParse, error)
+		ParseBlockContext(context.Context, *ParamsOfParse) (*ResultOfParse, error)
 		ParseShardstate(*ParamsOfParseShardstate) (*ResultOfParse, error)
+		ParseShardstateContext(context.Context, *ParamsOfParseShardstate) (*ResultOfParse, error)
 		GetBlockhainConfig(*ParamsOfGetBlockchainConfig) (*ResultOfGetBlockchainConfig, error)
+		GetBlockhainConfigContext(context.Context, *ParamsOfGetBlockchainConfig) (*ResultOfGetBlockchainConfig, error)
 		GetBocHash(*ParamsOfGetBocHash) (*ResultOfGetBocHash, error)
+		GetBocHashContext(context.Context, *ParamsOfGetBocHash) (*ResultOfGetBocHash, error)
 		GetBocDepth(*ParamsOfGetBocDepth) (*ResultOfGetBocDepth, error)
+		GetBocDepthContext(context.Context, *ParamsOfGetBocDepth) (*ResultOfGetBocDepth, error)
 		GetCodeFromTvc(*ParamsOfGetCodeFromTvc) (*ResultOfGetCodeFromTvc, error)
+		GetCodeFromTvcContext(context.Context, *ParamsOfGetCodeFromTvc) (*ResultOfGetCodeFromTvc, error)
 		CacheGet(*ParamsOfBocCacheGet) (*ResultOfBocCacheGet, error)
+		CacheGetContext(context.Context, *ParamsOfBocCacheGet) (*ResultOfBocCacheGet, error)
 		CacheSet(*ParamsOfBocCacheSet) (*ResultOfBocCacheSet, error)
+		CacheSetContext(context.Context, *ParamsOfBocCacheSet) (*ResultOfBocCacheSet, error)
 		CacheUnpin(*ParamsOfBocCacheUnpin) error
+		CacheUnpinContext(context.Context, *ParamsOfBocCacheUnpin) error
 		EncodeBoc(*ParamsOfEncodeBoc) (*ResultOfEncodeBoc, error)
+		EncodeBocContext(context.Context, *ParamsOfEncodeBoc) (*ResultOfEncodeBoc, error)
 		GetCodeSalt(*ParamsOfGetCodeSalt) (*ResultOfGetCodeSalt, error)
+		GetCodeSaltContext(context.Context, *ParamsOfGetCodeSalt) (*ResultOfGetCodeSalt, error)
 		SetCodeSalt(*ParamsOfSetCodeSalt) (*ResultOfSetCodeSalt, error)
+		SetCodeSaltContext(context.Context, *ParamsOfSetCodeSalt) (*ResultOfSetCodeSalt, error)
 		DecodeStateInit(*ParamsOfDecodeStateInit) (*ResultOfDecodeStateInit, error)
+		DecodeStateInitContext(context.Context, *ParamsOfDecodeStateInit) (*ResultOfDecodeStateInit, error)
 		EncodeStateInit(*ParamsOfEncodeStateInit) (*ResultOfEncodeStateInit, error)
+		EncodeStateInitContext(context.Context, *ParamsOfEncodeStateInit) (*ResultOfEncodeStateInit, error)
 		EncodeExternalInMessage(*ParamsOfEncodeExternalInMessage) (*ResultOfEncodeExternalInMessage, error)
+		EncodeExternalInMessageContext(context.Context, *ParamsOfEncodeExternalInMessage) (*ResultOfEncodeExternalInMessage, error)
 		GetCompilerVersion(version *ParamsOfGetCompilerVersion) (*ResultOfGetCompilerVersion, error)
+		GetCompilerVersionContext(context.Context, *ParamsOfGetCompilerVersion) (*ResultOfGetCompilerVersion, error)
 	}
 )
 
@@ -1,8 +1,13 @@
 package domain
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"time"
+
 	"github.com/markgenuine/ever-client-go/util"
 )
 
@@ -17,6 +22,13 @@ const (
 
 var ClientErrorCode map[string]int
 
+// ErrClientClosed - the client gateway is closed or destroyed, the request isn't sent or its responses are dropped.
+var ErrClientClosed = errors.New("client is closed")
+
+// ErrRequestTimeout - the core library didn't finish the request in time set by the gateway, later responses of
+// it are dropped.
+var ErrRequestTimeout = errors.New("request timeout")
+
 type (
 	ClientError struct {
 		Code    int             `json:"code"`
@@ -30,6 +42,38 @@ type (
 		Error error
 	}
 
+	// OpenHandle - SDK handle which has to be released: subscription, iterator, box, debot, BOC pin or monitor queue.
+	OpenHandle struct {
+		Kind     string
+		Method   string          // function which opened the handle
+		Handle   json.RawMessage // handle, pin or name of queue
+		CallSite string          // file:line of the caller, it is empty without handle tracking
+		Opened   time.Time
+	}
+
+	// HandleTracker is implemented by client gateways which know handles opened through them.
+	HandleTracker interface {
+		OpenHandles() []*OpenHandle
+	}
+
+	// RequestStats - requests of client gateway: Live are waiting for the core library, the oldest of them is
+	// Oldest old, Expired were ended by their timeout.
+	RequestStats struct {
+		Live    int
+		Oldest  time.Duration
+		Expired uint64
+	}
+
+	// RequestTracker is implemented by client gateways which know requests waiting for the core library.
+	RequestTracker interface {
+		RequestStats() RequestStats
+	}
+
+	// DropTracker is implemented by client gateways which count responses dropped by backpressure of streams.
+	DropTracker interface {
+		DroppedResponses() map[string]uint64
+	}
+
 	AppRequestResult struct {
 		ValueEnumType interface{}
 	}
@@ -106,14 +150,19 @@ type (
 
 	ClientGateway interface {
 		Destroy()
+		Close(context.Context) error
 		GetResult(string, interface{}, interface{}) error
-		Request(string, interface{}) (<-chan *ClientResponse, error)
+		Request(string, interface{}) (*Stream, error)
 		GetResponse(string, interface{}) ([]byte, error)
+		GetResultContext(context.Context, string, interface{}, interface{}) error
+		RequestContext(context.Context, string, interface{}) (*Stream, error)
+		GetResponseContext(context.Context, string, interface{}) ([]byte, error)
 		GetAPIReference() (*ResultOfGetAPIReference, error)
 		Version() (*ResultOfVersion, error)
 		Config() (*ClientConfig, error)
 		GetBuildInfo() (*ResultOfBuildInfo, error)
 		ResolveAppRequest(*ParamsOfResolveAppRequest) error
+		AppObjects() *AppObjectRegistry
 	}
 
 	AppPasswordProvider interface {
@@ -131,6 +180,35 @@ type (
 		Decrypt(ParamsOfAppEncryptionBoxDecrypt) (ResultOfAppEncryptionBoxDecrypt, error)
 	}
 
+	// AppPasswordProviderContext is implemented by password providers which stop when ctx of the request is done,
+	// e.g. at the timeout of WithAppRequestTimeout. It is used instead of AppPasswordProvider.
+	AppPasswordProviderContext interface {
+		GetPasswordContext(context.Context, ParamsOfAppPasswordProviderGetPassword) (ResultOfAppPasswordProviderGetPassword, error)
+	}
+
+	// AppSigningBoxContext - AppSigningBox which stops when ctx of the request is done, see AppPasswordProviderContext.
+	AppSigningBoxContext interface {
+		GetPublicKeyContext(context.Context) (ResultOfAppSigningBoxGetPublicKey, error)
+		SignContext(context.Context, ParamsOfAppSigningBoxSign) (ResultOfAppSigningBoxSign, error)
+	}
+
+	// AppEncryptionBoxContext - AppEncryptionBox which stops when ctx of the request is done, see
+	// AppPasswordProviderContext.
+	AppEncryptionBoxContext interface {
+		GetInfoContext(context.Context) (ResultOfAppEncryptionBoxGetInfo, error)
+		EncryptContext(context.Context, ParamsOfAppEncryptionBoxEncrypt) (ResultOfAppEncryptionBoxEncrypt, error)
+		DecryptContext(context.Context, ParamsOfAppEncryptionBoxDecrypt) (ResultOfAppEncryptionBoxDecrypt, error)
+	}
+
+	// AppDebotBrowserContext - requests of AppDebotBrowser which stop when ctx of the request is done, see
+	// AppPasswordProviderContext.
+	AppDebotBrowserContext interface {
+		InputContext(context.Context, ParamsOfAppDebotBrowserInput) (ResultOfAppDebotBrowserInput, error)
+		GetSigningBoxContext(context.Context, ParamsOfAppDebotBrowserGetSigningBox) (ResultOfAppDebotBrowserGetSigningBox, error)
+		InvokeDebotContext(context.Context, ParamsOfAppDebotBrowserInvokeDebot) (ResultOfAppDebotBrowserInvokeDebot, error)
+		ApproveContext(context.Context, ParamsOfAppDebotBrowserApprove) (ResultOfAppDebotBrowserApprove, error)
+	}
+
 	AppDebotBrowser interface {
 		Log(ParamsOfAppDebotBrowserLog) error
 		Switch(ParamsOfAppDebotBrowserSwitch) error
@@ -149,63 +227,65 @@ type (
 	NetworkQueriesProtocol string
 
 	ClientConfig struct {
-		Binding          *BindingConfig `json:"binding,omitempty"`
-		Network          *NetworkConfig `json:"network,omitempty"`
-		Crypto           *CryptoConfig  `json:"crypto,omitempty"`
-		Abi              *AbiConfig     `json:"abi,omitempty"`
-		Boc              *BocConfig     `json:"boc,omitempty"`
-		ProofsConfig     *ProofsConfig  `json:"proofs,omitempty"`
-		LocalStoragePath string         `json:"local_storage_path,omitempty"`
+		Binding          *BindingConfig `toml:"binding" yaml:"binding" json:"binding,omitempty"`
+		Network          *NetworkConfig `toml:"network" yaml:"network" json:"network,omitempty"`
+		Crypto           *CryptoConfig  `toml:"crypto" yaml:"crypto" json:"crypto,omitempty"`
+		Abi              *AbiConfig     `toml:"abi" yaml:"abi" json:"abi,omitempty"`
+		Boc              *BocConfig     `toml:"boc" yaml:"boc" json:"boc,omitempty"`
+		ProofsConfig     *ProofsConfig  `toml:"proofs" yaml:"proofs" json:"proofs,omitempty"`
+		LocalStoragePath string         `toml:"local_storage_path" yaml:"local_storage_path" json:"local_storage_path,omitempty"`
+		// LibraryPath - path of libton_client loaded by the binding, it isn't passed to the core library.
+		LibraryPath string `toml:"-" yaml:"-" json:"-"`
 	}
 
 	// Binding config for information about Binding
 	BindingConfig struct {
-		Library string `json:"library,omitempty"`
-		Version string `json:"version,omitempty"`
+		Library string `toml:"library" yaml:"library" json:"library,omitempty"`
+		Version string `toml:"version" yaml:"version" json:"version,omitempty"`
 	}
 
 	NetworkConfig struct {
-		ServerAddress            string                 `json:"server_address,omitempty"`
-		Endpoints                []string               `json:"endpoints,omitempty"`
-		NetworkRetriesCount      *int                   `json:"network_retries_count,omitempty"`
-		MaxReconnectTimeOut      *int                   `json:"max_reconnect_timeout,omitempty"`
-		ReconnectTimeout         *int                   `json:"reconnect_timeout,omitempty"`
-		MessageRetriesCount      *int                   `json:"message_retries_count,omitempty"`
-		MessageProcessingTimeout *int                   `json:"message_processing_timeout,omitempty"`
-		WaitForTimeout           *int                   `json:"wait_for_timeout,omitempty"`
-		OutOfSyncThreshold       *int                   `json:"out_of_sync_threshold,omitempty"` //DEPRECATED
-		SendingEndpointCount     *int                   `json:"sending_endpoint_count,omitempty"`
-		LatencyDetectionInterval *int                   `json:"latency_detection_interval,omitempty"`
-		MaxLatency               *int                   `json:"max_latency,omitempty"`
-		QueryTimeout             *int                   `json:"query_timeout,omitempty"`
-		QueriesProtocol          NetworkQueriesProtocol `json:"queries_protocol,omitempty"`
-		FirstRempStatusTimeout   *int                   `json:"first_remp_status_timeout,omitempty"`
-		NextRempStatusTimeout    *int                   `json:"next_remp_status_timeout,omitempty"`
-		SignatureID              *int                   `json:"signature_id,omitempty"`
-		AccessKey                string                 `json:"access_key,omitempty"`
+		ServerAddress            string                 `toml:"server_address" yaml:"server_address" json:"server_address,omitempty"`
+		Endpoints                []string               `toml:"endpoints" yaml:"endpoints" json:"endpoints,omitempty"`
+		NetworkRetriesCount      *int                   `toml:"network_retries_count" yaml:"network_retries_count" json:"network_retries_count,omitempty"`
+		MaxReconnectTimeOut      *int                   `toml:"max_reconnect_timeout" yaml:"max_reconnect_timeout" json:"max_reconnect_timeout,omitempty"`
+		ReconnectTimeout         *int                   `toml:"reconnect_timeout" yaml:"reconnect_timeout" json:"reconnect_timeout,omitempty"`
+		MessageRetriesCount      *int                   `toml:"message_retries_count" yaml:"message_retries_count" json:"message_retries_count,omitempty"`
+		MessageProcessingTimeout *int                   `toml:"message_processing_timeout" yaml:"message_processing_timeout" json:"message_processing_timeout,omitempty"`
+		WaitForTimeout           *int                   `toml:"wait_for_timeout" yaml:"wait_for_timeout" json:"wait_for_timeout,omitempty"`
+		OutOfSyncThreshold       *int                   `toml:"out_of_sync_threshold" yaml:"out_of_sync_threshold" json:"out_of_sync_threshold,omitempty"` //DEPRECATED
+		SendingEndpointCount     *int                   `toml:"sending_endpoint_count" yaml:"sending_endpoint_count" json:"sending_endpoint_count,omitempty"`
+		LatencyDetectionInterval *int                   `toml:"latency_detection_interval" yaml:"latency_detection_interval" json:"latency_detection_interval,omitempty"`
+		MaxLatency               *int                   `toml:"max_latency" yaml:"max_latency" json:"max_latency,omitempty"`
+		QueryTimeout             *int                   `toml:"query_timeout" yaml:"query_timeout" json:"query_timeout,omitempty"`
+		QueriesProtocol          NetworkQueriesProtocol `toml:"queries_protocol" yaml:"queries_protocol" json:"queries_protocol,omitempty"`
+		FirstRempStatusTimeout   *int                   `toml:"first_remp_status_timeout" yaml:"first_remp_status_timeout" json:"first_remp_status_timeout,omitempty"`
+		NextRempStatusTimeout    *int                   `toml:"next_remp_status_timeout" yaml:"next_remp_status_timeout" json:"next_remp_status_timeout,omitempty"`
+		SignatureID              *int                   `toml:"signature_id" yaml:"signature_id" json:"signature_id,omitempty"`
+		AccessKey                string                 `toml:"access_key" yaml:"access_key" json:"access_key,omitempty"`
 	}
 
 	CryptoConfig struct {
-		MnemonicDictionary  *MnemonicDictionary `toml:"mnemonic_dictionary" json:"mnemonic_dictionary,omitempty"`
-		MnemonicWordCount   *int                `toml:"mnemonic_word_count" json:"mnemonic_word_count,omitempty"`
-		HdKeyDerivationPath string              `toml:"hdkey_derivation_path" json:"hdkey_derivation_path,omitempty"`
+		MnemonicDictionary  *MnemonicDictionary `toml:"mnemonic_dictionary" yaml:"mnemonic_dictionary" json:"mnemonic_dictionary,omitempty"`
+		MnemonicWordCount   *int                `toml:"mnemonic_word_count" yaml:"mnemonic_word_count" json:"mnemonic_word_count,omitempty"`
+		HdKeyDerivationPath string              `toml:"hdkey_derivation_path" yaml:"hdkey_derivation_path" json:"hdkey_derivation_path,omitempty"`
 	}
 
 	// AbiConfig ...
 	AbiConfig struct {
-		WorkChain                          *int     `toml:"workchain" json:"workchain,omitempty"`
-		MessageExpirationTimeout           *int     `toml:"message_expiration_timeout" json:"message_expiration_timeout,omitempty"`
-		MessageExpirationTimeoutGrowFactor *float32 `toml:"message_expiration_timeout_grow_factor" json:"message_expiration_timeout_grow_factor,omitempty"`
+		WorkChain                          *int     `toml:"workchain" yaml:"workchain" json:"workchain,omitempty"`
+		MessageExpirationTimeout           *int     `toml:"message_expiration_timeout" yaml:"message_expiration_timeout" json:"message_expiration_timeout,omitempty"`
+		MessageExpirationTimeoutGrowFactor *float32 `toml:"message_expiration_timeout_grow_factor" yaml:"message_expiration_timeout_grow_factor" json:"message_expiration_timeout_grow_factor,omitempty"`
 	}
 
 	// BocConfig ...
 	BocConfig struct {
-		CacheMaxSize *int `toml:"cache_max_size" json:"cache_max_size,omitempty"`
+		CacheMaxSize *int `toml:"cache_max_size" yaml:"cache_max_size" json:"cache_max_size,omitempty"`
 	}
 
 	// ProofsConfig ...
 	ProofsConfig struct {
-		CacheInLocalStorage *bool `toml:"cache_in_local_storage"  json:"cache_in_local_storage,omitempty"`
+		CacheInLocalStorage *bool `toml:"cache_in_local_storage" yaml:"cache_in_local_storage" json:"cache_in_local_storage,omitempty"`
 	}
 )
 
@@ -303,37 +383,108 @@ func init() {
 	}
 }
 
+// NewClientError create ClientError with code from codes table, used for sentinel errors of modules.
+func NewClientError(codes map[string]int, name string) *ClientError {
+	return &ClientError{Code: codes[name], Message: name}
+}
+
+// Error - implements error interface.
+func (ce *ClientError) Error() string {
+	return fmt.Sprintf("%s (code: %d)", ce.Message, ce.Code)
+}
+
+// Is - errors with equal codes are the same error, so errors.Is(err, net.ErrWaitForTimeout) works
+// for the error returned by SDK.
+func (ce *ClientError) Is(target error) bool {
+	targetErr, ok := target.(*ClientError)
+	return ok && targetErr.Code == ce.Code
+}
+
+// ErrorCode returns code of error of SDK in the chain of err, 0 for other errors, e.g. cancelled calls.
+func ErrorCode(err error) int {
+	var clientErr *ClientError
+	if errors.As(err, &clientErr) {
+		return clientErr.Code
+	}
+
+	return 0
+}
+
+// DynBufferForResponses returns responses of in buffered without limit, see BufferResponses.
+//
+// Deprecated: use Stream.Buffer, it keeps backpressure of the stream.
 func DynBufferForResponses(in <-chan *ClientResponse) <-chan *ClientResponse {
-	out := make(chan *ClientResponse, 1)
-	var storage []*ClientResponse
+	return BufferResponses(in, Backpressure{}, nil)
+}
+
+// BufferResponses reads responses of in without waiting for the reader of the returned channel, they are
+// buffered up to b.Size. When the buffer is full an event or notification is dropped by b.Policy and passed to
+// onDrop, onDrop may be nil. With OverflowFail the buffered responses are followed by error response with
+// ErrBufferOverflow, the channel is closed and later responses of in are dropped.
+func BufferResponses(in <-chan *ClientResponse, b Backpressure, onDrop func(*ClientResponse)) <-chan *ClientResponse {
+	out := make(chan *ClientResponse)
+	drop := func(r *ClientResponse) {
+		if onDrop != nil {
+			onDrop(r)
+		}
+	}
 	go func() {
 		defer close(out)
-		for {
-			if len(storage) == 0 {
-				item, ok := <-in
-				if !ok {
-					return
-				}
-				storage = append(storage, item)
-				continue
+		var storage []*ClientResponse
+		for in != nil || len(storage) > 0 {
+			var (
+				receive = in
+				send    chan<- *ClientResponse
+				next    *ClientResponse
+			)
+			if len(storage) > 0 {
+				send, next = out, storage[0]
+			}
+			if b.Policy == OverflowBlock && b.isFull(len(storage)) {
+				receive = nil
 			}
 
 			select {
-			case item, ok := <-in:
-				if ok {
-					storage = append(storage, item)
-				} else {
-					for _, item := range storage {
-						out <- item
-					}
-					return
+			case r, ok := <-receive:
+				if !ok {
+					in = nil
+					continue
 				}
-			case out <- storage[0]:
-				if len(storage) == 1 {
-					storage = nil
-				} else {
-					storage = storage[1:]
+				if !b.isFull(len(storage)) || !isDroppable(r) {
+					storage = append(storage, r)
+					continue
+				}
+				switch b.Policy {
+				case OverflowDropOldest:
+					oldest := -1
+					for i, old := range storage {
+						if isDroppable(old) {
+							oldest = i
+							break
+						}
+					}
+					if oldest < 0 {
+						// The buffer is full of results and app requests, the new event is the oldest droppable one.
+						drop(r)
+						continue
+					}
+					drop(storage[oldest])
+					storage = append(storage[:oldest:oldest], storage[oldest+1:]...)
+					storage = append(storage, r)
+				case OverflowDropNewest:
+					drop(r)
+				case OverflowFail:
+					drop(r)
+					storage = append(storage, &ClientResponse{Code: ResponseError, Error: ErrBufferOverflow})
+					go func(in <-chan *ClientResponse) {
+						for r := range in {
+							drop(r)
+						}
+					}(in)
+					in = nil
 				}
+			case send <- next:
+				storage = storage[1:]
 			}
 		}
 	}()
@@ -341,29 +492,43 @@ func DynBufferForResponses(in <-chan *ClientResponse) <-chan *ClientResponse {
 	return out
 }
 
-func HandleEvents(responses <-chan *ClientResponse, callback EventCallback, result interface{}) error {
-	for r := range responses {
+// isDroppable reports whether response may be dropped by backpressure: events and notifications of app objects.
+func isDroppable(r *ClientResponse) bool {
+	return r.Code == ResponseCustom || r.Code == ResponseAppNotify
+}
+
+// HandleEvents passes events of processing to callback and unmarshals the result of stream to result.
+// Events which can't be parsed and unknown responses end the call with error.
+func HandleEvents(stream *Stream, callback EventCallback, result interface{}) error {
+	return HandleEventsContext(context.Background(), stream, callback, result)
+}
+
+// HandleEventsContext - HandleEvents which returns ctx.Err() when ctx is done before the final response.
+func HandleEventsContext(ctx context.Context, stream *Stream, callback EventCallback, result interface{}) error {
+	for {
+		r, err := stream.Next(ctx)
+		if err == io.EOF {
+			return ErrNoResult
+		}
+		if err != nil {
+			return err
+		}
+
 		switch r.Code {
-		case 100:
+		case ResponseCustom:
 			event := &ProcessingEvent{}
 			if err := json.Unmarshal(r.Data, event); err != nil {
-				panic(err)
+				return fmt.Errorf("processing event %s: %w", r.Data, err)
 			}
 			callback(event)
-		case 1:
+		case ResponseError:
 			return r.Error
-		case 0:
-			if err := json.Unmarshal(r.Data, result); err != nil {
-				panic(err)
-			}
-
-			return nil
+		case ResponseSuccess:
+			return json.Unmarshal(r.Data, result)
 		default:
-			panic(fmt.Errorf("unknown response type code %v", r.Code))
+			return fmt.Errorf("unknown response type code %v", r.Code)
 		}
 	}
-
-	return nil
 }
 
 func (aRR *AppRequestResult) MarshalJSON() ([]byte, error) {
@@ -411,3 +576,8 @@ func (aRR *AppRequestResult) UnmarshalJSON(b []byte) error {
 func NewAppRequestResult(value interface{}) *AppRequestResult {
 	return &AppRequestResult{ValueEnumType: value}
 }
+
+// Age - time since the handle is opened.
+func (h *OpenHandle) Age() time.Duration {
+	return time.Since(h.Opened)
+}
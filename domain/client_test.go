@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientError(t *testing.T) {
+	t.Run("Is", func(t *testing.T) {
+		err := fmt.Errorf("net.query: %w", &ClientError{Code: NetErrorCode["QueryFailed"], Message: "query failed"})
+		assert.True(t, errors.Is(err, NewClientError(NetErrorCode, "QueryFailed")))
+		assert.False(t, errors.Is(err, NewClientError(NetErrorCode, "WaitForTimeout")))
+	})
+
+	t.Run("ErrorCode", func(t *testing.T) {
+		assert.Equal(t, 0, ErrorCode(context.Canceled))
+		assert.Equal(t, 0, ErrorCode(nil))
+		assert.Equal(t, 507, ErrorCode(&ClientError{Code: 507}))
+		assert.Equal(t, 507, ErrorCode(fmt.Errorf("net.query: %w", &ClientError{Code: 507})))
+	})
+}
@@ -0,0 +1,228 @@
+package domain
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/markgenuine/ever-client-go/util"
+)
+
+type (
+	// ConfigError - invalid value of the config field, Field is the path in the config file, e.g. network.query_timeout.
+	ConfigError struct {
+		Field   string
+		Message string
+	}
+
+	// ConfigErrors - all invalid fields of the config.
+	ConfigErrors []*ConfigError
+
+	// configEnv - environment variable which overrides the config field.
+	configEnv struct {
+		name  string
+		field string
+		set   func(config *ClientConfig, value string) error
+	}
+)
+
+func (e *ConfigError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+func (e ConfigErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return "invalid config: " + strings.Join(messages, "; ")
+}
+
+// configEnvs - environment overrides applied by LoadConfig on top of the file.
+var configEnvs = []configEnv{
+	{"EVER_NETWORK_ENDPOINTS", "network.endpoints", func(c *ClientConfig, v string) error {
+		c.Network.Endpoints = splitList(v)
+		return nil
+	}},
+	{"EVER_SERVER_ADDRESS", "network.server_address", func(c *ClientConfig, v string) error {
+		c.Network.ServerAddress = v
+		return nil
+	}},
+	{"EVER_ACCESS_KEY", "network.access_key", func(c *ClientConfig, v string) error {
+		c.Network.AccessKey = v
+		return nil
+	}},
+	{"EVER_QUERIES_PROTOCOL", "network.queries_protocol", func(c *ClientConfig, v string) error {
+		c.Network.QueriesProtocol = NetworkQueriesProtocol(strings.ToUpper(v))
+		return nil
+	}},
+	{"EVER_MESSAGE_PROCESSING_TIMEOUT", "network.message_processing_timeout", func(c *ClientConfig, v string) error {
+		return setInt(&c.Network.MessageProcessingTimeout, v)
+	}},
+	{"EVER_WAIT_FOR_TIMEOUT", "network.wait_for_timeout", func(c *ClientConfig, v string) error {
+		return setInt(&c.Network.WaitForTimeout, v)
+	}},
+	{"EVER_QUERY_TIMEOUT", "network.query_timeout", func(c *ClientConfig, v string) error {
+		return setInt(&c.Network.QueryTimeout, v)
+	}},
+	{"EVER_MESSAGE_RETRIES_COUNT", "network.message_retries_count", func(c *ClientConfig, v string) error {
+		return setInt(&c.Network.MessageRetriesCount, v)
+	}},
+	{"EVER_MNEMONIC_WORD_COUNT", "crypto.mnemonic_word_count", func(c *ClientConfig, v string) error {
+		return setInt(&c.Crypto.MnemonicWordCount, v)
+	}},
+	{"EVER_LOCAL_STORAGE_PATH", "local_storage_path", func(c *ClientConfig, v string) error {
+		c.LocalStoragePath = v
+		return nil
+	}},
+}
+
+// LoadConfig returns NewDefaultConfig with values of the TOML (.toml) or YAML (.yaml, .yml) file at path and
+// EVER_* environment variables on top, e.g. EVER_NETWORK_ENDPOINTS (comma separated) or EVER_ACCESS_KEY.
+// Empty path means defaults and environment only. The result is validated, invalid fields are returned as ConfigErrors.
+func LoadConfig(path string) (ClientConfig, error) {
+	return loadConfig(path, os.LookupEnv)
+}
+
+func loadConfig(path string, lookupEnv func(string) (string, bool)) (ClientConfig, error) {
+	config := NewDefaultConfig("", nil, "")
+	if path != "" {
+		if err := decodeFile(path, &config); err != nil {
+			return ClientConfig{}, err
+		}
+	}
+	// Sections can be emptied by the file, overrides and validation need them.
+	if config.Network == nil {
+		config.Network = &NetworkConfig{}
+	}
+	if config.Crypto == nil {
+		config.Crypto = &CryptoConfig{}
+	}
+
+	var errs ConfigErrors
+	for _, env := range configEnvs {
+		value, isFound := lookupEnv(env.name)
+		if !isFound {
+			continue
+		}
+		if err := env.set(&config, value); err != nil {
+			errs = append(errs, &ConfigError{Field: env.field, Message: fmt.Sprintf("%s: %v", env.name, err)})
+		}
+	}
+	if len(errs) > 0 {
+		return ClientConfig{}, errs
+	}
+
+	if err := config.Validate(); err != nil {
+		return ClientConfig{}, err
+	}
+
+	return config, nil
+}
+
+// decodeFile reads TOML or YAML file into v, format is chosen by extension.
+func decodeFile(path string, v interface{}) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		if _, err := toml.Decode(string(data), v); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, v); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	default:
+		return fmt.Errorf("%s: unknown format, use .toml, .yaml or .yml", path)
+	}
+
+	return nil
+}
+
+// Validate checks values which the core library would reject or misuse, every invalid field is reported.
+func (c *ClientConfig) Validate() error {
+	var errs ConfigErrors
+	fail := func(field, format string, args ...interface{}) {
+		errs = append(errs, &ConfigError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+	positive := func(field string, value *int) {
+		if value != nil && *value <= 0 {
+			fail(field, "must be positive, got %d", *value)
+		}
+	}
+
+	if network := c.Network; network == nil {
+		fail("network", "is required")
+	} else {
+		if len(network.Endpoints) == 0 && network.ServerAddress == "" {
+			fail("network.endpoints", "at least one endpoint is required")
+		}
+		for i, endpoint := range network.Endpoints {
+			if strings.TrimSpace(endpoint) == "" {
+				fail(fmt.Sprintf("network.endpoints[%d]", i), "is empty")
+			}
+		}
+		switch network.QueriesProtocol {
+		case "", NetworkQueriesProtocolHTTP, NetworkQueriesProtocolWS:
+		default:
+			fail("network.queries_protocol", "unknown protocol %q, use %s or %s",
+				network.QueriesProtocol, NetworkQueriesProtocolHTTP, NetworkQueriesProtocolWS)
+		}
+		positive("network.max_reconnect_timeout", network.MaxReconnectTimeOut)
+		positive("network.reconnect_timeout", network.ReconnectTimeout)
+		positive("network.message_processing_timeout", network.MessageProcessingTimeout)
+		positive("network.wait_for_timeout", network.WaitForTimeout)
+		positive("network.latency_detection_interval", network.LatencyDetectionInterval)
+		positive("network.max_latency", network.MaxLatency)
+		positive("network.query_timeout", network.QueryTimeout)
+		positive("network.first_remp_status_timeout", network.FirstRempStatusTimeout)
+		positive("network.next_remp_status_timeout", network.NextRempStatusTimeout)
+	}
+
+	if c.Crypto != nil && c.Crypto.MnemonicWordCount != nil {
+		if _, isFound := WordCountList()[*c.Crypto.MnemonicWordCount]; !isFound {
+			fail("crypto.mnemonic_word_count", "must be one of 12, 15, 18, 21, 24, got %d", *c.Crypto.MnemonicWordCount)
+		}
+	}
+	if c.Abi != nil {
+		positive("abi.message_expiration_timeout", c.Abi.MessageExpirationTimeout)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func splitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+func setInt(field **int, value string) error {
+	number, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return err
+	}
+	*field = util.IntToPointerInt(number)
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ever-config")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	write := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, ioutil.WriteFile(path, []byte(data), 0o644))
+		return path
+	}
+	noEnv := func(string) (string, bool) { return "", false }
+	env := func(vars map[string]string) func(string) (string, bool) {
+		return func(name string) (string, bool) {
+			value, isFound := vars[name]
+			return value, isFound
+		}
+	}
+	fields := func(err error) []string {
+		var errs ConfigErrors
+		if !errors.As(err, &errs) {
+			return nil
+		}
+		result := make([]string, len(errs))
+		for i, e := range errs {
+			result[i] = e.Field
+		}
+		return result
+	}
+
+	t.Run("TOML", func(t *testing.T) {
+		path := write("client.toml", `
+local_storage_path = "/var/lib/ever"
+
+[network]
+endpoints = ["https://devnet.evercloud.dev/graphql"]
+queries_protocol = "WS"
+query_timeout = 30000
+
+[crypto]
+mnemonic_word_count = 24
+`)
+		config, err := loadConfig(path, noEnv)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"https://devnet.evercloud.dev/graphql"}, config.Network.Endpoints)
+		assert.Equal(t, NetworkQueriesProtocolWS, config.Network.QueriesProtocol)
+		assert.Equal(t, 30000, *config.Network.QueryTimeout)
+		assert.Equal(t, 40000, *config.Network.WaitForTimeout)
+		assert.Equal(t, 24, *config.Crypto.MnemonicWordCount)
+		assert.Equal(t, EnglishMnemonicDictionary, *config.Crypto.MnemonicDictionary)
+		assert.Equal(t, "/var/lib/ever", config.LocalStoragePath)
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		path := write("client.yaml", `
+network:
+  endpoints:
+    - https://mainnet.evercloud.dev/graphql
+  access_key: secret
+abi:
+  message_expiration_timeout: 60000
+`)
+		config, err := loadConfig(path, noEnv)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"https://mainnet.evercloud.dev/graphql"}, config.Network.Endpoints)
+		assert.Equal(t, "secret", config.Network.AccessKey)
+		assert.Equal(t, 60000, *config.Abi.MessageExpirationTimeout)
+		assert.Equal(t, 12, *config.Crypto.MnemonicWordCount)
+	})
+
+	t.Run("Env", func(t *testing.T) {
+		path := write("env.yml", "network:\n  endpoints: [https://a/graphql]\n")
+		config, err := loadConfig(path, env(map[string]string{
+			"EVER_NETWORK_ENDPOINTS": "https://b/graphql, https://c/graphql",
+			"EVER_ACCESS_KEY":        "key",
+			"EVER_QUERIES_PROTOCOL":  "http",
+			"EVER_QUERY_TIMEOUT":     "1000",
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"https://b/graphql", "https://c/graphql"}, config.Network.Endpoints)
+		assert.Equal(t, "key", config.Network.AccessKey)
+		assert.Equal(t, NetworkQueriesProtocolHTTP, config.Network.QueriesProtocol)
+		assert.Equal(t, 1000, *config.Network.QueryTimeout)
+
+		config, err = loadConfig("", env(map[string]string{"EVER_NETWORK_ENDPOINTS": "https://d/graphql"}))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"https://d/graphql"}, config.Network.Endpoints)
+
+		_, err = loadConfig("", env(map[string]string{"EVER_NETWORK_ENDPOINTS": "https://d/graphql", "EVER_QUERY_TIMEOUT": "1m"}))
+		assert.Equal(t, []string{"network.query_timeout"}, fields(err))
+		assert.Contains(t, err.Error(), "EVER_QUERY_TIMEOUT")
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		path := write("invalid.toml", `
+[network]
+endpoints = ["https://a/graphql", " "]
+queries_protocol = "UDP"
+wait_for_timeout = 0
+message_processing_timeout = -1
+
+[crypto]
+mnemonic_word_count = 13
+`)
+		_, err := loadConfig(path, noEnv)
+		assert.Equal(t, []string{
+			"network.endpoints[1]",
+			"network.queries_protocol",
+			"network.message_processing_timeout",
+			"network.wait_for_timeout",
+			"crypto.mnemonic_word_count",
+		}, fields(err))
+
+		_, err = loadConfig("", noEnv)
+		assert.Equal(t, []string{"network.endpoints"}, fields(err))
+	})
+
+	t.Run("Format", func(t *testing.T) {
+		_, err := loadConfig(write("client.json", "{}"), noEnv)
+		assert.Error(t, err)
+		_, err = loadConfig(write("broken.toml", "[network"), noEnv)
+		assert.Error(t, err)
+		_, err = loadConfig(filepath.Join(dir, "missing.toml"), noEnv)
+		assert.True(t, os.IsNotExist(err))
+	})
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/markgenuine/ever-client-go/util"
@@ -602,56 +603,104 @@ type (
 
 	CryptoUseCase interface {
 		Factorize(*ParamsOfFactorize) (*ResultOfFactorize, error)
+		FactorizeContext(context.Context, *ParamsOfFactorize) (*ResultOfFactorize, error)
 		ModularPower(*ParamsOfModularPower) (*ResultOfModularPower, error)
+		ModularPowerContext(context.Context, *ParamsOfModularPower) (*ResultOfModularPower, error)
 		TonCrc16(*ParamsOfTonCrc16) (*ResultOfTonCrc16, error)
+		TonCrc16Context(context.Context, *ParamsOfTonCrc16) (*ResultOfTonCrc16, error)
 		GenerateRandomBytes(*ParamsOfGenerateRandomBytes) (*ResultOfGenerateRandomBytes, error)
+		GenerateRandomBytesContext(context.Context, *ParamsOfGenerateRandomBytes) (*ResultOfGenerateRandomBytes, error)
 		ConvertPublicKeyString(*ParamsOfConvertPublicKeyToTonSafeFormat) (*ResultOfConvertPublicKeyToTonSafeFormat, error)
+		ConvertPublicKeyStringContext(context.Context, *ParamsOfConvertPublicKeyToTonSafeFormat) (*ResultOfConvertPublicKeyToTonSafeFormat, error)
 		GenerateRandomSignKeys() (*KeyPair, error)
+		GenerateRandomSignKeysContext(context.Context) (*KeyPair, error)
 		Sign(*ParamsOfSign) (*ResultOfSign, error)
+		SignContext(context.Context, *ParamsOfSign) (*ResultOfSign, error)
 		VerifySignature(*ParamsOfVerifySignature) (*ResultOfVerifySignature, error)
+		VerifySignatureContext(context.Context, *ParamsOfVerifySignature) (*ResultOfVerifySignature, error)
 		Sha256(*ParamsOfHash) (*ResultOfHash, error)
+		Sha256Context(context.Context, *ParamsOfHash) (*ResultOfHash, error)
 		Sha512(*ParamsOfHash) (*ResultOfHash, error)
+		Sha512Context(context.Context, *ParamsOfHash) (*ResultOfHash, error)
 		Scrypt(*ParamsOfScrypt) (*ResultOfScrypt, error)
+		ScryptContext(context.Context, *ParamsOfScrypt) (*ResultOfScrypt, error)
 		NaclSignKeypairFromSecretKey(*ParamsOfNaclSignKeyPairFromSecret) (*KeyPair, error)
+		NaclSignKeypairFromSecretKeyContext(context.Context, *ParamsOfNaclSignKeyPairFromSecret) (*KeyPair, error)
 		NaclSign(*ParamsOfNaclSign) (*ResultOfNaclSign, error)
+		NaclSignContext(context.Context, *ParamsOfNaclSign) (*ResultOfNaclSign, error)
 		NaclSignOpen(*ParamsOfNaclSignOpen) (*ResultOfNaclSignOpen, error)
+		NaclSignOpenContext(context.Context, *ParamsOfNaclSignOpen) (*ResultOfNaclSignOpen, error)
 		NaclSignDetached(*ParamsOfNaclSign) (*ResultOfNaclSignDetached, error)
+		NaclSignDetachedContext(context.Context, *ParamsOfNaclSign) (*ResultOfNaclSignDetached, error)
 		NaclSignDetachedVerify(*ParamsOfNaclSignDetachedVerify) (*ResultOfNaclSignDetachedVerify, error)
+		NaclSignDetachedVerifyContext(context.Context, *ParamsOfNaclSignDetachedVerify) (*ResultOfNaclSignDetachedVerify, error)
 		NaclBoxKeypair() (*KeyPair, error)
+		NaclBoxKeypairContext(context.Context) (*KeyPair, error)
 		NaclBoxKeypairFromSecretKey(*ParamsOfNaclBoxKeyPairFromSecret) (*KeyPair, error)
+		NaclBoxKeypairFromSecretKeyContext(context.Context, *ParamsOfNaclBoxKeyPairFromSecret) (*KeyPair, error)
 		NaclBox(*ParamsOfNaclBox) (*ResultOfNaclBox, error)
+		NaclBoxContext(context.Context, *ParamsOfNaclBox) (*ResultOfNaclBox, error)
 		NaclBoxOpen(*ParamsOfNaclBoxOpen) (*ResultOfNaclBoxOpen, error)
+		NaclBoxOpenContext(context.Context, *ParamsOfNaclBoxOpen) (*ResultOfNaclBoxOpen, error)
 		NaclSecretBox(*ParamsOfNaclSecretBox) (*ResultOfNaclBox, error)
+		NaclSecretBoxContext(context.Context, *ParamsOfNaclSecretBox) (*ResultOfNaclBox, error)
 		NaclSecretBoxOpen(*ParamsOfNaclSecretBoxOpen) (*ResultOfNaclBoxOpen, error)
+		NaclSecretBoxOpenContext(context.Context, *ParamsOfNaclSecretBoxOpen) (*ResultOfNaclBoxOpen, error)
 		MnemonicWords(*ParamsOfMnemonicWords) (*ResultOfMnemonicWords, error)
+		MnemonicWordsContext(context.Context, *ParamsOfMnemonicWords) (*ResultOfMnemonicWords, error)
 		MnemonicFromRandom(*ParamsOfMnemonicFromRandom) (*ResultOfMnemonicFromRandom, error)
+		MnemonicFromRandomContext(context.Context, *ParamsOfMnemonicFromRandom) (*ResultOfMnemonicFromRandom, error)
 		MnemonicFromEntropy(*ParamsOfMnemonicFromEntropy) (*ResultOfMnemonicFromEntropy, error)
+		MnemonicFromEntropyContext(context.Context, *ParamsOfMnemonicFromEntropy) (*ResultOfMnemonicFromEntropy, error)
 		MnemonicVerify(*ParamsOfMnemonicVerify) (*ResultOfMnemonicVerify, error)
+		MnemonicVerifyContext(context.Context, *ParamsOfMnemonicVerify) (*ResultOfMnemonicVerify, error)
 		MnemonicDeriveSignKeys(*ParamsOfMnemonicDeriveSignKeys) (*KeyPair, error)
+		MnemonicDeriveSignKeysContext(context.Context, *ParamsOfMnemonicDeriveSignKeys) (*KeyPair, error)
 		HDKeyXprvFromMnemonic(*ParamsOfHDKeyXPrvFromMnemonic) (*ResultOfHDKeyXPrvFromMnemonic, error)
+		HDKeyXprvFromMnemonicContext(context.Context, *ParamsOfHDKeyXPrvFromMnemonic) (*ResultOfHDKeyXPrvFromMnemonic, error)
 		HDKeyDeriveFromXprv(*ParamsOfHDKeyDeriveFromXPrv) (*ResultOfHDKeyDeriveFromXPrv, error)
+		HDKeyDeriveFromXprvContext(context.Context, *ParamsOfHDKeyDeriveFromXPrv) (*ResultOfHDKeyDeriveFromXPrv, error)
 		HDKeyDeriveFromXprvPath(*ParamsOfHDKeyDeriveFromXPrvPath) (*ResultOfHDKeyDeriveFromXPrvPath, error)
+		HDKeyDeriveFromXprvPathContext(context.Context, *ParamsOfHDKeyDeriveFromXPrvPath) (*ResultOfHDKeyDeriveFromXPrvPath, error)
 		HDKeySecretFromXprv(*ParamsOfHDKeySecretFromXPrv) (*ResultOfHDKeySecretFromXPrv, error)
+		HDKeySecretFromXprvContext(context.Context, *ParamsOfHDKeySecretFromXPrv) (*ResultOfHDKeySecretFromXPrv, error)
 		HDKeyPublicFromXprv(*ParamsOfHDKeyPublicFromXPrv) (*ResultOfHDKeyPublicFromXPrv, error)
+		HDKeyPublicFromXprvContext(context.Context, *ParamsOfHDKeyPublicFromXPrv) (*ResultOfHDKeyPublicFromXPrv, error)
 		Chacha20(*ParamsOfChaCha20) (*ResultOfChaCha20, error)
+		Chacha20Context(context.Context, *ParamsOfChaCha20) (*ResultOfChaCha20, error)
 		CreateCryptoBox(*ParamsOfCreateCryptoBox, AppPasswordProvider) (*RegisteredCryptoBox, error)
 		RemoveCryptoBox(*RegisteredCryptoBox) error
+		RemoveCryptoBoxContext(context.Context, *RegisteredCryptoBox) error
 		GetCryptoBoxInfo(*RegisteredCryptoBox) (*ResultOfGetCryptoBoxInfo, error)
+		GetCryptoBoxInfoContext(context.Context, *RegisteredCryptoBox) (*ResultOfGetCryptoBoxInfo, error)
 		GetCryptoBoxSeedPhrase(*RegisteredCryptoBox) (*ResultOfGetCryptoBoxSeedPhrase, error)
+		GetCryptoBoxSeedPhraseContext(context.Context, *RegisteredCryptoBox) (*ResultOfGetCryptoBoxSeedPhrase, error)
 		GetSigningBoxFromCryptoBox(*ParamsOfGetSigningBoxFromCryptoBox) (*RegisteredSigningBox, error)
+		GetSigningBoxFromCryptoBoxContext(context.Context, *ParamsOfGetSigningBoxFromCryptoBox) (*RegisteredSigningBox, error)
 		GetEncryptionBoxFromCryptoBox(box *ParamsOfGetEncryptionBoxFromCryptoBox) (*RegisteredEncryptionBox, error)
+		GetEncryptionBoxFromCryptoBoxContext(context.Context, *ParamsOfGetEncryptionBoxFromCryptoBox) (*RegisteredEncryptionBox, error)
 		ClearCryptoBoxSecretCache(*RegisteredCryptoBox) error
+		ClearCryptoBoxSecretCacheContext(context.Context, *RegisteredCryptoBox) error
 		RegisterSigningBox(AppSigningBox) (*RegisteredSigningBox, error)
 		GetSigningBox(*KeyPair) (*RegisteredSigningBox, error)
+		GetSigningBoxContext(context.Context, *KeyPair) (*RegisteredSigningBox, error)
 		SigningBoxGetPublicKey(*RegisteredSigningBox) (*ResultOfSigningBoxGetPublicKey, error)
+		SigningBoxGetPublicKeyContext(context.Context, *RegisteredSigningBox) (*ResultOfSigningBoxGetPublicKey, error)
 		SigningBoxSign(*ParamsOfSigningBoxSign) (*ResultOfSigningBoxSign, error)
+		SigningBoxSignContext(context.Context, *ParamsOfSigningBoxSign) (*ResultOfSigningBoxSign, error)
 		RemoveSigningBox(*RegisteredSigningBox) error
+		RemoveSigningBoxContext(context.Context, *RegisteredSigningBox) error
 		RegisterEncryptionBox(AppEncryptionBox) (*RegisteredEncryptionBox, error)
 		RemoveEncryptionBox(*RegisteredEncryptionBox) error
+		RemoveEncryptionBoxContext(context.Context, *RegisteredEncryptionBox) error
 		EncryptionBoxGetInfo(*ParamsOfEncryptionBoxGetInfo) (*ResultOfEncryptionBoxGetInfo, error)
+		EncryptionBoxGetInfoContext(context.Context, *ParamsOfEncryptionBoxGetInfo) (*ResultOfEncryptionBoxGetInfo, error)
 		EncryptionBoxEncrypt(*ParamsOfEncryptionBoxEncrypt) (*ResultOfEncryptionBoxEncrypt, error)
+		EncryptionBoxEncryptContext(context.Context, *ParamsOfEncryptionBoxEncrypt) (*ResultOfEncryptionBoxEncrypt, error)
 		EncryptionBoxDecrypt(*ParamsOfEncryptionBoxDecrypt) (*ResultOfEncryptionBoxDecrypt, error)
+		EncryptionBoxDecryptContext(context.Context, *ParamsOfEncryptionBoxDecrypt) (*ResultOfEncryptionBoxDecrypt, error)
 		CreateEncryptionBox(*ParamsOfCreateEncryptionBox) (*RegisteredEncryptionBox, error)
+		CreateEncryptionBoxContext(context.Context, *ParamsOfCreateEncryptionBox) (*RegisteredEncryptionBox, error)
 	}
 )
 
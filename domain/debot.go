@@ -1,6 +1,11 @@
 package domain
 
-import "math/big"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
 
 var DebotErrorCode map[string]int
 
@@ -156,10 +161,15 @@ type (
 	DebotUseCase interface {
 		Init(*ParamsOfInit, AppDebotBrowser) (*RegisteredDebot, error)
 		Start(*ParamsOfStart) error
+		StartContext(context.Context, *ParamsOfStart) error
 		Fetch(*ParamsOfFetch) (*ResultOfFetch, error)
+		FetchContext(context.Context, *ParamsOfFetch) (*ResultOfFetch, error)
 		Execute(*ParamsOfExecute) error
+		ExecuteContext(context.Context, *ParamsOfExecute) error
 		Send(*ParamsOfSend) error
+		SendContext(context.Context, *ParamsOfSend) error
 		Remove(*ParamsOfRemove) error
+		RemoveContext(context.Context, *ParamsOfRemove) error
 	}
 )
 
@@ -180,3 +190,194 @@ func init() {
 		"DebotNoCode":                813,
 	}
 }
+
+func (pOADB *ParamsOfAppDebotBrowser) MarshalJSON() ([]byte, error) {
+	switch value := (pOADB.ValueEnumType).(type) {
+	case ParamsOfAppDebotBrowserLog:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			ParamsOfAppDebotBrowserLog
+		}{"Log", value})
+	case ParamsOfAppDebotBrowserSwitch:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			ParamsOfAppDebotBrowserSwitch
+		}{"Switch", value})
+	case ParamsOfAppDebotBrowserSwitchCompleted:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			ParamsOfAppDebotBrowserSwitchCompleted
+		}{"SwitchCompleted", value})
+	case ParamsOfAppDebotBrowserShowAction:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			ParamsOfAppDebotBrowserShowAction
+		}{"ShowAction", value})
+	case ParamsOfAppDebotBrowserInput:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			ParamsOfAppDebotBrowserInput
+		}{"Input", value})
+	case ParamsOfAppDebotBrowserGetSigningBox:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			ParamsOfAppDebotBrowserGetSigningBox
+		}{"GetSigningBox", value})
+	case ParamsOfAppDebotBrowserInvokeDebot:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			ParamsOfAppDebotBrowserInvokeDebot
+		}{"InvokeDebot", value})
+	case ParamsOfAppDebotBrowserSend:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			ParamsOfAppDebotBrowserSend
+		}{"Send", value})
+	case ParamsOfAppDebotBrowserApprove:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			ParamsOfAppDebotBrowserApprove
+		}{"Approve", value})
+	default:
+		return nil, fmt.Errorf("unsupported type for ParamsOfAppDebotBrowser %v", pOADB.ValueEnumType)
+	}
+}
+
+func (pOADB *ParamsOfAppDebotBrowser) UnmarshalJSON(b []byte) error {
+	var typeD EnumType
+	if err := json.Unmarshal(b, &typeD); err != nil {
+		return err
+	}
+
+	switch typeD.Type {
+	case "Log":
+		var valueEnum ParamsOfAppDebotBrowserLog
+		if err := json.Unmarshal(b, &valueEnum); err != nil {
+			return err
+		}
+		pOADB.ValueEnumType = valueEnum
+	case "Switch":
+		var valueEnum ParamsOfAppDebotBrowserSwitch
+		if err := json.Unmarshal(b, &valueEnum); err != nil {
+			return err
+		}
+		pOADB.ValueEnumType = valueEnum
+	case "SwitchCompleted":
+		var valueEnum ParamsOfAppDebotBrowserSwitchCompleted
+		if err := json.Unmarshal(b, &valueEnum); err != nil {
+			return err
+		}
+		pOADB.ValueEnumType = valueEnum
+	case "ShowAction":
+		var valueEnum ParamsOfAppDebotBrowserShowAction
+		if err := json.Unmarshal(b, &valueEnum); err != nil {
+			return err
+		}
+		pOADB.ValueEnumType = valueEnum
+	case "Input":
+		var valueEnum ParamsOfAppDebotBrowserInput
+		if err := json.Unmarshal(b, &valueEnum); err != nil {
+			return err
+		}
+		pOADB.ValueEnumType = valueEnum
+	case "GetSigningBox":
+		var valueEnum ParamsOfAppDebotBrowserGetSigningBox
+		if err := json.Unmarshal(b, &valueEnum); err != nil {
+			return err
+		}
+		pOADB.ValueEnumType = valueEnum
+	case "InvokeDebot":
+		var valueEnum ParamsOfAppDebotBrowserInvokeDebot
+		if err := json.Unmarshal(b, &valueEnum); err != nil {
+			return err
+		}
+		pOADB.ValueEnumType = valueEnum
+	case "Send":
+		var valueEnum ParamsOfAppDebotBrowserSend
+		if err := json.Unmarshal(b, &valueEnum); err != nil {
+			return err
+		}
+		pOADB.ValueEnumType = valueEnum
+	case "Approve":
+		var valueEnum ParamsOfAppDebotBrowserApprove
+		if err := json.Unmarshal(b, &valueEnum); err != nil {
+			return err
+		}
+		pOADB.ValueEnumType = valueEnum
+	default:
+		return fmt.Errorf("unsupported type for ParamsOfAppDebotBrowser %v", typeD.Type)
+	}
+	return nil
+}
+
+func NewParamsOfAppDebotBrowser(value interface{}) *ParamsOfAppDebotBrowser {
+	return &ParamsOfAppDebotBrowser{ValueEnumType: value}
+}
+
+func (rOADB *ResultOfAppDebotBrowser) MarshalJSON() ([]byte, error) {
+	switch value := (rOADB.ValueEnumType).(type) {
+	case ResultOfAppDebotBrowserInput:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			ResultOfAppDebotBrowserInput
+		}{"Input", value})
+	case ResultOfAppDebotBrowserGetSigningBox:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			ResultOfAppDebotBrowserGetSigningBox
+		}{"GetSigningBox", value})
+	case ResultOfAppDebotBrowserInvokeDebot:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			ResultOfAppDebotBrowserInvokeDebot
+		}{"InvokeDebot", value})
+	case ResultOfAppDebotBrowserApprove:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			ResultOfAppDebotBrowserApprove
+		}{"Approve", value})
+	default:
+		return nil, fmt.Errorf("unsupported type for ResultOfAppDebotBrowser %v", rOADB.ValueEnumType)
+	}
+}
+
+func (rOADB *ResultOfAppDebotBrowser) UnmarshalJSON(b []byte) error {
+	var typeD EnumType
+	if err := json.Unmarshal(b, &typeD); err != nil {
+		return err
+	}
+
+	switch typeD.Type {
+	case "Input":
+		var valueEnum ResultOfAppDebotBrowserInput
+		if err := json.Unmarshal(b, &valueEnum); err != nil {
+			return err
+		}
+		rOADB.ValueEnumType = valueEnum
+	case "GetSigningBox":
+		var valueEnum ResultOfAppDebotBrowserGetSigningBox
+		if err := json.Unmarshal(b, &valueEnum); err != nil {
+			return err
+		}
+		rOADB.ValueEnumType = valueEnum
+	case "InvokeDebot":
+		var valueEnum ResultOfAppDebotBrowserInvokeDebot
+		if err := json.Unmarshal(b, &valueEnum); err != nil {
+			return err
+		}
+		rOADB.ValueEnumType = valueEnum
+	case "Approve":
+		var valueEnum ResultOfAppDebotBrowserApprove
+		if err := json.Unmarshal(b, &valueEnum); err != nil {
+			return err
+		}
+		rOADB.ValueEnumType = valueEnum
+	default:
+		return fmt.Errorf("unsupported type for ResultOfAppDebotBrowser %v", typeD.Type)
+	}
+	return nil
+}
+
+func NewResultOfAppDebotBrowser(value interface{}) *ResultOfAppDebotBrowser {
+	return &ResultOfAppDebotBrowser{ValueEnumType: value}
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -206,28 +207,51 @@ type (
 
 	NetUseCase interface {
 		Query(*ParamsOfQuery) (*ResultOfQuery, error)
+		QueryContext(context.Context, *ParamsOfQuery) (*ResultOfQuery, error)
 		BatchQuery(*ParamsOfBatchQuery) (*ResultOfBatchQuery, error)
+		BatchQueryContext(context.Context, *ParamsOfBatchQuery) (*ResultOfBatchQuery, error)
 		QueryCollection(*ParamsOfQueryCollection) (*ResultOfQueryCollection, error)
+		QueryCollectionContext(context.Context, *ParamsOfQueryCollection) (*ResultOfQueryCollection, error)
 		AggregateCollection(*ParamsOfAggregateCollection) (*ResultOfAggregateCollection, error)
+		AggregateCollectionContext(context.Context, *ParamsOfAggregateCollection) (*ResultOfAggregateCollection, error)
 		WaitForCollection(*ParamsOfWaitForCollection) (*ResultOfWaitForCollection, error)
+		WaitForCollectionContext(context.Context, *ParamsOfWaitForCollection) (*ResultOfWaitForCollection, error)
 		Unsubscribe(*ResultOfSubscribeCollection) error
-		SubscribeCollection(*ParamsOfSubscribeCollection) (<-chan json.RawMessage, *ResultOfSubscribeCollection, error)
-		Subscribe(*ParamsOfSubscribe) (<-chan json.RawMessage, *ResultOfSubscribeCollection, error)
+		UnsubscribeContext(context.Context, *ResultOfSubscribeCollection) error
+		SubscribeCollection(*ParamsOfSubscribeCollection) (<-chan json.RawMessage, <-chan error, *ResultOfSubscribeCollection, error)
+		SubscribeCollectionContext(context.Context, *ParamsOfSubscribeCollection) (<-chan json.RawMessage, <-chan error, *ResultOfSubscribeCollection, error)
+		Subscribe(*ParamsOfSubscribe) (<-chan json.RawMessage, <-chan error, *ResultOfSubscribeCollection, error)
+		SubscribeContext(context.Context, *ParamsOfSubscribe) (<-chan json.RawMessage, <-chan error, *ResultOfSubscribeCollection, error)
 		Suspend() error
+		SuspendContext(context.Context) error
 		Resume() error
+		ResumeContext(context.Context) error
 		FindLastShardBlock(*ParamsOfFindLastShardBlock) (*ResultOfFindLastShardBlock, error)
+		FindLastShardBlockContext(context.Context, *ParamsOfFindLastShardBlock) (*ResultOfFindLastShardBlock, error)
 		FetchEndpoints() (*EndpointsSet, error)
+		FetchEndpointsContext(context.Context) (*EndpointsSet, error)
 		SetEndpoints(*EndpointsSet) error
+		SetEndpointsContext(context.Context, *EndpointsSet) error
 		GetEndpoints() (*ResultOfGetEndpoints, error)
+		GetEndpointsContext(context.Context) (*ResultOfGetEndpoints, error)
 		QueryCounterparties(*ParamsOfQueryCounterparties) (*ResultOfQueryCollection, error)
+		QueryCounterpartiesContext(context.Context, *ParamsOfQueryCounterparties) (*ResultOfQueryCollection, error)
 		QueryTransactionTree(*ParamsOfQueryTransactionTree) (*ResultOfQueryTransactionTree, error)
+		QueryTransactionTreeContext(context.Context, *ParamsOfQueryTransactionTree) (*ResultOfQueryTransactionTree, error)
 		CreateBlockIterator(*ParamsOfCreateBlockIterator) (*RegisteredIterator, error)
+		CreateBlockIteratorContext(context.Context, *ParamsOfCreateBlockIterator) (*RegisteredIterator, error)
 		ResumeBlockIterator(*ParamsOfResumeBlockIterator) (*RegisteredIterator, error)
+		ResumeBlockIteratorContext(context.Context, *ParamsOfResumeBlockIterator) (*RegisteredIterator, error)
 		CreateTransactionIterator(*ParamsOfCreateTransactionIterator) (*RegisteredIterator, error)
+		CreateTransactionIteratorContext(context.Context, *ParamsOfCreateTransactionIterator) (*RegisteredIterator, error)
 		ResumeTransactionIterator(*ParamsOfResumeTransactionIterator) (*RegisteredIterator, error)
+		ResumeTransactionIteratorContext(context.Context, *ParamsOfResumeTransactionIterator) (*RegisteredIterator, error)
 		IteratorNext(*ParamsOfIteratorNext) (*ResultOfIteratorNext, error)
+		IteratorNextContext(context.Context, *ParamsOfIteratorNext) (*ResultOfIteratorNext, error)
 		RemoveIterator(*RegisteredIterator) error
+		RemoveIteratorContext(context.Context, *RegisteredIterator) error
 		GetSignatureID() (*ResultOfGetSignatureId, error)
+		GetSignatureIDContext(context.Context) (*ResultOfGetSignatureId, error)
 	}
 )
 
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -236,13 +237,21 @@ type (
 
 	ProcessingUseCase interface {
 		MonitorMessages(*ParamsOfMonitorMessages) error
+		MonitorMessagesContext(context.Context, *ParamsOfMonitorMessages) error
 		GetMonitorInfo(*ParamsOfGetMonitorInfo) (*MonitoringQueueInfo, error)
+		GetMonitorInfoContext(context.Context, *ParamsOfGetMonitorInfo) (*MonitoringQueueInfo, error)
 		FetchNextMonitorResults(*ParamsOfFetchNextMonitorResults) (*ResultOfFetchNextMonitorResults, error)
+		FetchNextMonitorResultsContext(context.Context, *ParamsOfFetchNextMonitorResults) (*ResultOfFetchNextMonitorResults, error)
 		CancelMonitor(monitor *ParamsOfCancelMonitor) error
+		CancelMonitorContext(context.Context, *ParamsOfCancelMonitor) error
 		SendMessages(messages *ParamsOfSendMessages) (*ResultOfSendMessages, error)
+		SendMessagesContext(context.Context, *ParamsOfSendMessages) (*ResultOfSendMessages, error)
 		SendMessage(*ParamsOfSendMessage, EventCallback) (*ResultOfSendMessage, error)
+		SendMessageContext(context.Context, *ParamsOfSendMessage, EventCallback) (*ResultOfSendMessage, error)
 		WaitForTransaction(*ParamsOfWaitForTransaction, EventCallback) (*ResultOfProcessMessage, error)
+		WaitForTransactionContext(context.Context, *ParamsOfWaitForTransaction, EventCallback) (*ResultOfProcessMessage, error)
 		ProcessMessage(*ParamsOfProcessMessage, EventCallback) (*ResultOfProcessMessage, error)
+		ProcessMessageContext(context.Context, *ParamsOfProcessMessage, EventCallback) (*ResultOfProcessMessage, error)
 	}
 )
 
@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/markgenuine/ever-client-go/util"
+)
+
+// Built-in network profiles.
+const (
+	ProfileEverscaleMainnet = "everscale-mainnet"
+	ProfileEverscaleDevnet  = "everscale-devnet"
+	ProfileVenomMainnet     = "venom-mainnet"
+	ProfileVenomTestnet     = "venom-testnet"
+	ProfileGosh             = "gosh"
+	ProfileLocal            = "local"
+)
+
+// ProjectIDPlaceholder - part of endpoints of profile which is replaced by project ID of the caller, see
+// NetworkProfile.ForProject.
+const ProjectIDPlaceholder = "{project_id}"
+
+var (
+	// ErrUnknownProfile - there is no network profile with the name.
+	ErrUnknownProfile = errors.New("unknown network profile")
+	// ErrProjectIDRequired - endpoints of network profile need project ID of the caller, e.g. of Evercloud.
+	ErrProjectIDRequired = errors.New("network profile requires project ID")
+)
+
+type (
+	// NetworkProfile - endpoints and settings of a network. Empty fields keep values of NewDefaultConfig,
+	// SignatureID is queried from the network by the core library when it isn't set.
+	NetworkProfile struct {
+		Name                     string                 `toml:"-" yaml:"-"`
+		Endpoints                []string               `toml:"endpoints" yaml:"endpoints"`
+		SignatureID              *int                   `toml:"signature_id" yaml:"signature_id"`
+		WorkChain                *int                   `toml:"workchain" yaml:"workchain"`
+		QueriesProtocol          NetworkQueriesProtocol `toml:"queries_protocol" yaml:"queries_protocol"`
+		MessageProcessingTimeout *int                   `toml:"message_processing_timeout" yaml:"message_processing_timeout"`
+		WaitForTimeout           *int                   `toml:"wait_for_timeout" yaml:"wait_for_timeout"`
+		QueryTimeout             *int                   `toml:"query_timeout" yaml:"query_timeout"`
+		MessageExpirationTimeout *int                   `toml:"message_expiration_timeout" yaml:"message_expiration_timeout"`
+	}
+
+	// profilesFile - file of user profiles, profiles are keyed by name.
+	profilesFile struct {
+		Profiles map[string]*NetworkProfile `toml:"profiles" yaml:"profiles"`
+	}
+)
+
+var profiles = struct {
+	sync.RWMutex
+	byName map[string]*NetworkProfile
+}{byName: make(map[string]*NetworkProfile)}
+
+func init() {
+	for _, profile := range []*NetworkProfile{
+		{Name: ProfileEverscaleMainnet, Endpoints: []string{"https://mainnet.evercloud.dev/" + ProjectIDPlaceholder + "/graphql"}},
+		{Name: ProfileEverscaleDevnet, Endpoints: []string{"https://devnet.evercloud.dev/" + ProjectIDPlaceholder + "/graphql"}},
+		{Name: ProfileVenomMainnet, Endpoints: []string{"https://gql.venom.foundation/graphql"}},
+		{Name: ProfileVenomTestnet, Endpoints: []string{"https://gql-testnet.venom.foundation/graphql"}},
+		{Name: ProfileGosh, Endpoints: []string{"https://network.gosh.sh"}},
+		// Evernode SE confirms messages at once, long waits only hide failures of tests.
+		{Name: ProfileLocal, Endpoints: []string{"http://localhost/graphql"},
+			MessageProcessingTimeout: util.IntToPointerInt(10000), WaitForTimeout: util.IntToPointerInt(10000)},
+	} {
+		profiles.byName[profile.Name] = profile
+	}
+}
+
+// RegisterProfile adds profile or replaces the profile with the same name.
+func RegisterProfile(profile NetworkProfile) error {
+	if err := profile.validate(); err != nil {
+		return err
+	}
+
+	profiles.Lock()
+	profiles.byName[profile.Name] = &profile
+	profiles.Unlock()
+
+	return nil
+}
+
+// LoadProfiles registers profiles of the TOML or YAML file, e.g. [profiles.my-net] endpoints = ["..."].
+// Nothing is registered when any of the profiles is invalid.
+func LoadProfiles(path string) error {
+	file := &profilesFile{}
+	if err := decodeFile(path, file); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(file.Profiles))
+	for name := range file.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	loaded := make([]*NetworkProfile, 0, len(names))
+	for _, name := range names {
+		profile := file.Profiles[name]
+		if profile == nil {
+			profile = &NetworkProfile{}
+		}
+		profile.Name = name
+		if err := profile.validate(); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		loaded = append(loaded, profile)
+	}
+
+	profiles.Lock()
+	for _, profile := range loaded {
+		profiles.byName[profile.Name] = profile
+	}
+	profiles.Unlock()
+
+	return nil
+}
+
+func (p *NetworkProfile) validate() error {
+	if p.Name == "" {
+		return errors.New("network profile without name")
+	}
+	if len(p.Endpoints) == 0 {
+		return fmt.Errorf("network profile %s: no endpoints", p.Name)
+	}
+
+	return nil
+}
+
+// Profile returns copy of the registered profile.
+func Profile(name string) (NetworkProfile, error) {
+	profiles.RLock()
+	defer profiles.RUnlock()
+
+	profile, isFound := profiles.byName[name]
+	if !isFound {
+		return NetworkProfile{}, fmt.Errorf("%q: %w", name, ErrUnknownProfile)
+	}
+
+	return *profile, nil
+}
+
+// Profiles returns sorted names of the registered profiles.
+func Profiles() []string {
+	profiles.RLock()
+	defer profiles.RUnlock()
+
+	names := make([]string, 0, len(profiles.byName))
+	for name := range profiles.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// NeedsProject reports whether endpoints of profile contain ProjectIDPlaceholder.
+func (p NetworkProfile) NeedsProject() bool {
+	for _, endpoint := range p.Endpoints {
+		if strings.Contains(endpoint, ProjectIDPlaceholder) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ForProject returns copy of profile whose endpoints carry projectID instead of ProjectIDPlaceholder.
+func (p NetworkProfile) ForProject(projectID string) (NetworkProfile, error) {
+	endpoints := make([]string, len(p.Endpoints))
+	for i, endpoint := range p.Endpoints {
+		endpoints[i] = strings.ReplaceAll(endpoint, ProjectIDPlaceholder, projectID)
+	}
+	if projectID == "" && p.NeedsProject() {
+		return NetworkProfile{}, fmt.Errorf("%s: %w", p.Name, ErrProjectIDRequired)
+	}
+	p.Endpoints = endpoints
+
+	return p, nil
+}
+
+// Config returns complete config for the network of profile.
+func (p NetworkProfile) Config(accessKey string) ClientConfig {
+	config := NewDefaultConfig("", append([]string(nil), p.Endpoints...), accessKey)
+	network := config.Network
+	network.QueriesProtocol = p.QueriesProtocol
+	if p.SignatureID != nil {
+		network.SignatureID = util.IntToPointerInt(*p.SignatureID)
+	}
+	if p.MessageProcessingTimeout != nil {
+		network.MessageProcessingTimeout = util.IntToPointerInt(*p.MessageProcessingTimeout)
+	}
+	if p.WaitForTimeout != nil {
+		network.WaitForTimeout = util.IntToPointerInt(*p.WaitForTimeout)
+	}
+	if p.QueryTimeout != nil {
+		network.QueryTimeout = util.IntToPointerInt(*p.QueryTimeout)
+	}
+	if p.WorkChain != nil {
+		config.Abi.WorkChain = util.IntToPointerInt(*p.WorkChain)
+	}
+	if p.MessageExpirationTimeout != nil {
+		config.Abi.MessageExpirationTimeout = util.IntToPointerInt(*p.MessageExpirationTimeout)
+	}
+
+	return config
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfiles(t *testing.T) {
+	t.Run("BuiltIn", func(t *testing.T) {
+		for _, name := range []string{ProfileEverscaleMainnet, ProfileEverscaleDevnet, ProfileVenomMainnet,
+			ProfileVenomTestnet, ProfileGosh, ProfileLocal} {
+			profile, err := Profile(name)
+			assert.NoError(t, err)
+			profile, err = profile.ForProject("project")
+			assert.NoError(t, err)
+			config := profile.Config("key")
+			assert.NoError(t, config.Validate(), name)
+			assert.Equal(t, "key", config.Network.AccessKey)
+		}
+		assert.Subset(t, Profiles(), []string{ProfileVenomTestnet, ProfileLocal})
+
+		_, err := Profile("unknown")
+		assert.True(t, errors.Is(err, ErrUnknownProfile))
+	})
+
+	t.Run("ForProject", func(t *testing.T) {
+		profile, err := Profile(ProfileEverscaleMainnet)
+		assert.NoError(t, err)
+		assert.True(t, profile.NeedsProject())
+		_, err = profile.ForProject("")
+		assert.True(t, errors.Is(err, ErrProjectIDRequired))
+
+		project, err := profile.ForProject("abc")
+		assert.NoError(t, err)
+		assert.False(t, project.NeedsProject())
+		assert.Equal(t, []string{"https://mainnet.evercloud.dev/abc/graphql"}, project.Endpoints)
+		assert.Equal(t, "https://mainnet.evercloud.dev/"+ProjectIDPlaceholder+"/graphql", profile.Endpoints[0])
+
+		venom, err := Profile(ProfileVenomMainnet)
+		assert.NoError(t, err)
+		venom, err = venom.ForProject("")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"https://gql.venom.foundation/graphql"}, venom.Endpoints)
+	})
+
+	t.Run("Config", func(t *testing.T) {
+		signatureID, workChain, timeout := 42, -1, 5000
+		assert.NoError(t, RegisterProfile(NetworkProfile{
+			Name:            "test-net",
+			Endpoints:       []string{"https://test/graphql"},
+			SignatureID:     &signatureID,
+			WorkChain:       &workChain,
+			QueriesProtocol: NetworkQueriesProtocolWS,
+			QueryTimeout:    &timeout,
+		}))
+		profile, err := Profile("test-net")
+		assert.NoError(t, err)
+
+		config := profile.Config("")
+		assert.Equal(t, []string{"https://test/graphql"}, config.Network.Endpoints)
+		assert.Equal(t, 42, *config.Network.SignatureID)
+		assert.Equal(t, -1, *config.Abi.WorkChain)
+		assert.Equal(t, NetworkQueriesProtocolWS, config.Network.QueriesProtocol)
+		assert.Equal(t, 5000, *config.Network.QueryTimeout)
+		assert.Equal(t, 40000, *config.Network.WaitForTimeout)
+
+		*config.Network.SignatureID = 0
+		config.Network.Endpoints[0] = "changed"
+		profile, _ = Profile("test-net")
+		assert.Equal(t, 42, *profile.Config("").Network.SignatureID)
+		assert.Equal(t, "https://test/graphql", profile.Config("").Network.Endpoints[0])
+
+		assert.Error(t, RegisterProfile(NetworkProfile{Name: "empty"}))
+		assert.Error(t, RegisterProfile(NetworkProfile{Endpoints: []string{"https://test/graphql"}}))
+	})
+
+	t.Run("LoadProfiles", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "ever-profiles")
+		assert.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "profiles.toml")
+		assert.NoError(t, ioutil.WriteFile(path, []byte(`
+[profiles.file-net]
+endpoints = ["https://file/graphql"]
+signature_id = 7
+`), 0o644))
+		assert.NoError(t, LoadProfiles(path))
+		profile, err := Profile("file-net")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"https://file/graphql"}, profile.Endpoints)
+		assert.Equal(t, 7, *profile.SignatureID)
+
+		path = filepath.Join(dir, "profiles.yaml")
+		assert.NoError(t, ioutil.WriteFile(path, []byte("profiles:\n  a-net:\n    endpoints: [\"https://a/graphql\"]\n  broken-net: {}\n"), 0o644))
+		assert.Error(t, LoadProfiles(path))
+		_, err = Profile("broken-net")
+		assert.True(t, errors.Is(err, ErrUnknownProfile))
+		_, err = Profile("a-net")
+		assert.True(t, errors.Is(err, ErrUnknownProfile))
+	})
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+)
 
 var ProofsErrorCode map[string]int
 
@@ -19,13 +22,16 @@ type (
 
 	ProofsUseCase interface {
 		ProofBlockData(*ParamsOfProofBlockData) error
+		ProofBlockDataContext(context.Context, *ParamsOfProofBlockData) error
 		ProofTransactionData(*ParamsOfProofTransactionData) error
+		ProofTransactionDataContext(context.Context, *ParamsOfProofTransactionData) error
 		ParamsMessageData(*ParamsOfProofMessageData) error
+		ParamsMessageDataContext(context.Context, *ParamsOfProofMessageData) error
 	}
 )
 
 func init() {
-	AbiErrorCode = map[string]int{
+	ProofsErrorCode = map[string]int{
 		"InvalidData":           901,
 		"ProofCheckFailed":      902,
 		"InternalError":         903,
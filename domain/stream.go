@@ -0,0 +1,433 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// Types of responses of the core library, tc_response_types of client_method.h.
+const (
+	ResponseSuccess    uint32 = 0
+	ResponseError      uint32 = 1
+	ResponseNop        uint32 = 2
+	ResponseAppRequest uint32 = 3
+	ResponseAppNotify  uint32 = 4
+	ResponseCustom     uint32 = 100 // events of functions, e.g. ProcessingEvent or data of subscription
+)
+
+// Policies of Backpressure, what happens to a response when the buffer of stream is full.
+const (
+	// OverflowBlock stops reading responses, so the core library waits for the reader.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest drops the oldest event in the buffer.
+	OverflowDropOldest
+	// OverflowDropNewest drops the new event.
+	OverflowDropNewest
+	// OverflowFail ends the stream with ErrBufferOverflow.
+	OverflowFail
+)
+
+// ErrNoResult - the stream is finished without success or error response.
+var ErrNoResult = errors.New("stream is finished without result")
+
+// ErrBufferOverflow - the reader of stream with OverflowFail is too slow, events after it are dropped.
+var ErrBufferOverflow = errors.New("buffer of stream overflowed")
+
+type (
+	// OverflowPolicy - how a full buffer of stream is handled, see Backpressure.
+	OverflowPolicy int
+
+	// Backpressure - bound of responses buffered for a slow reader of stream, zero Size means no bound. Only
+	// events and notifications of app objects are dropped, results and app requests are always kept.
+	Backpressure struct {
+		Size   int
+		Policy OverflowPolicy
+		// OnDrop is called for every dropped response from goroutines of the stream, it may be nil.
+		OnDrop func(*ClientResponse)
+	}
+)
+
+// Stream - responses of one request of the core library.
+// Responses are read one by one with Next, or are split by type with Result, Events, AppRequests and
+// AppNotifications. Next must not be called after Events, AppRequests or AppNotifications.
+type Stream struct {
+	ctx          context.Context
+	responses    <-chan *ClientResponse
+	backpressure Backpressure
+	buffered     bool
+	dropped      *uint64
+
+	mu         sync.Mutex
+	unread     []*ClientResponse
+	result     *ClientResponse
+	resultDone chan struct{}
+	resultOnce sync.Once
+	err        error
+
+	splitOnce        sync.Once
+	events           chan json.RawMessage
+	appRequests      chan *ParamsOfAppRequest
+	appNotifications chan json.RawMessage
+}
+
+// NewStream returns stream of responses, ctx is the context of request: the stream ends when it is done.
+func NewStream(ctx context.Context, responses <-chan *ClientResponse) *Stream {
+	return &Stream{
+		ctx:        ctx,
+		responses:  responses,
+		dropped:    new(uint64),
+		resultDone: make(chan struct{}),
+	}
+}
+
+// Buffer returns stream which receives responses without waiting for the reader, so a slow reader doesn't
+// stop the core library. The buffer is bounded by backpressure of s, it has no bound by default. Stream which
+// is buffered already, e.g. by backpressure of the gateway, is returned as it is. It is called before responses
+// are read.
+func (s *Stream) Buffer() *Stream {
+	if s.buffered {
+		return s
+	}
+
+	return s.WithBackpressure(s.backpressure)
+}
+
+// WithBackpressure returns stream which buffers responses of s up to b.Size and handles the overflow by b.Policy.
+// Events of Events are bounded by it too. It is called before responses are read.
+func (s *Stream) WithBackpressure(b Backpressure) *Stream {
+	stream := s.Derive(nil)
+	stream.backpressure = b
+	stream.buffered = true
+	stream.responses = BufferResponses(s.responses, b, stream.drop)
+
+	return stream
+}
+
+// Derive returns stream of responses which are forwarded from s, it keeps backpressure, buffer and count of
+// dropped responses of s.
+func (s *Stream) Derive(responses <-chan *ClientResponse) *Stream {
+	stream := NewStream(s.ctx, responses)
+	stream.backpressure = s.backpressure
+	stream.buffered = s.buffered
+	stream.dropped = s.dropped
+
+	return stream
+}
+
+// Dropped returns count of responses dropped by backpressure of the stream and the streams it is derived from.
+func (s *Stream) Dropped() uint64 {
+	return atomic.LoadUint64(s.dropped)
+}
+
+func (s *Stream) drop(r *ClientResponse) {
+	atomic.AddUint64(s.dropped, 1)
+	if s.backpressure.OnDrop != nil {
+		s.backpressure.OnDrop(r)
+	}
+}
+
+// isFull reports whether a buffer of size responses is full.
+func (b Backpressure) isFull(size int) bool {
+	return b.Size > 0 && size >= b.Size
+}
+
+// Next returns the next response. It returns io.EOF when the stream is finished and ctx.Err() when ctx or
+// context of request is done.
+func (s *Stream) Next(ctx context.Context) (*ClientResponse, error) {
+	s.mu.Lock()
+	if len(s.unread) > 0 {
+		r := s.unread[0]
+		s.unread = s.unread[1:]
+		s.mu.Unlock()
+		return r, nil
+	}
+	s.mu.Unlock()
+
+	return s.receive(ctx)
+}
+
+func (s *Stream) receive(ctx context.Context) (*ClientResponse, error) {
+	select {
+	case r, ok := <-s.responses:
+		if !ok {
+			s.finishResult(nil)
+			if err := s.ctx.Err(); err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+		if r.Code == ResponseSuccess || r.Code == ResponseError {
+			s.finishResult(r)
+		}
+		return r, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// finishResult keeps the first success or error response, nil means the stream ended without it.
+func (s *Stream) finishResult(r *ClientResponse) {
+	s.resultOnce.Do(func() {
+		s.mu.Lock()
+		s.result = r
+		s.mu.Unlock()
+		close(s.resultDone)
+	})
+}
+
+// Result waits for the first success or error response and unmarshals its data to v, v may be nil.
+// Other responses which come before it remain available for Next or for the split streams.
+func (s *Stream) Result(v interface{}) error {
+	if !s.isSplit() {
+		if err := s.readResult(); err != nil {
+			return err
+		}
+	}
+
+	select {
+	case <-s.resultDone:
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+
+	s.mu.Lock()
+	r := s.result
+	s.mu.Unlock()
+	if r == nil {
+		if err := s.ctx.Err(); err != nil {
+			return err
+		}
+		return ErrNoResult
+	}
+	if r.Code == ResponseError {
+		return r.Error
+	}
+	if v == nil {
+		return nil
+	}
+
+	return json.Unmarshal(r.Data, v)
+}
+
+// readResult reads responses until the result, responses of other types are kept for Next.
+func (s *Stream) readResult() error {
+	for {
+		select {
+		case <-s.resultDone:
+			return nil
+		default:
+		}
+
+		r, err := s.receive(s.ctx)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if r.Code != ResponseSuccess && r.Code != ResponseError {
+			s.mu.Lock()
+			s.unread = append(s.unread, r)
+			s.mu.Unlock()
+		}
+	}
+}
+
+// Events returns payloads of ResponseCustom responses, the channel is closed at the end of stream.
+func (s *Stream) Events() <-chan json.RawMessage {
+	s.split()
+	return s.events
+}
+
+// AppRequests returns requests of app object, every one of them has to be resolved by client.resolve_app_request.
+// The channel is closed at the end of stream.
+func (s *Stream) AppRequests() <-chan *ParamsOfAppRequest {
+	s.split()
+	return s.appRequests
+}
+
+// AppNotifications returns payloads of notifications of app object, the channel is closed at the end of stream.
+func (s *Stream) AppNotifications() <-chan json.RawMessage {
+	s.split()
+	return s.appNotifications
+}
+
+// Err returns error of the split streams: broken app request or done context of request.
+func (s *Stream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+func (s *Stream) isSplit() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.events != nil
+}
+
+// split starts to sort responses by type. The split streams are buffered, so a type which isn't read doesn't
+// stop others. Events are bounded by backpressure of the stream, other types have no bound. When the stream is
+// buffered already its buffer handles the overflow, sorting only waits for the reader of full events.
+func (s *Stream) split() {
+	s.splitOnce.Do(func() {
+		s.mu.Lock()
+		s.events = make(chan json.RawMessage)
+		s.appRequests = make(chan *ParamsOfAppRequest)
+		s.appNotifications = make(chan json.RawMessage)
+		s.mu.Unlock()
+		go s.sort()
+	})
+}
+
+func (s *Stream) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+func (s *Stream) sort() {
+	var (
+		events        []json.RawMessage
+		appRequests   []*ParamsOfAppRequest
+		notifications []json.RawMessage
+		finished      bool
+		overflowed    bool
+		eventsOpen    = true
+		requestsOpen  = true
+		notifyOpen    = true
+	)
+	defer func() {
+		if eventsOpen {
+			close(s.events)
+		}
+		if requestsOpen {
+			close(s.appRequests)
+		}
+		if notifyOpen {
+			close(s.appNotifications)
+		}
+	}()
+
+	incoming := make(chan *ClientResponse)
+	go func() {
+		defer close(incoming)
+		for {
+			r, err := s.Next(s.ctx)
+			if err != nil {
+				if err != io.EOF {
+					s.setErr(err)
+				}
+				return
+			}
+			select {
+			case incoming <- r:
+			case <-s.ctx.Done():
+				s.setErr(s.ctx.Err())
+				return
+			}
+		}
+	}()
+
+	for {
+		// Every split stream is closed as soon as the stream is finished and its queue is read, events are also
+		// closed after the overflow with OverflowFail.
+		if (finished || overflowed) && eventsOpen && len(events) == 0 {
+			close(s.events)
+			eventsOpen = false
+		}
+		if finished && requestsOpen && len(appRequests) == 0 {
+			close(s.appRequests)
+			requestsOpen = false
+		}
+		if finished && notifyOpen && len(notifications) == 0 {
+			close(s.appNotifications)
+			notifyOpen = false
+		}
+		if !eventsOpen && !requestsOpen && !notifyOpen {
+			return
+		}
+
+		var (
+			eventsOut        chan json.RawMessage
+			nextEvent        json.RawMessage
+			appRequestsOut   chan *ParamsOfAppRequest
+			nextAppRequest   *ParamsOfAppRequest
+			notificationsOut chan json.RawMessage
+			nextNotification json.RawMessage
+		)
+		if len(events) > 0 {
+			eventsOut, nextEvent = s.events, events[0]
+		}
+		if len(appRequests) > 0 {
+			appRequestsOut, nextAppRequest = s.appRequests, appRequests[0]
+		}
+		if len(notifications) > 0 {
+			notificationsOut, nextNotification = s.appNotifications, notifications[0]
+		}
+
+		in := incoming
+		if finished || (s.buffered || s.backpressure.Policy == OverflowBlock) && s.backpressure.isFull(len(events)) {
+			in = nil
+		}
+		select {
+		case r, ok := <-in:
+			if !ok {
+				finished = true
+				continue
+			}
+			switch r.Code {
+			case ResponseCustom:
+				if overflowed {
+					s.drop(r)
+					continue
+				}
+				if s.backpressure.isFull(len(events)) {
+					switch s.backpressure.Policy {
+					case OverflowDropOldest:
+						s.drop(&ClientResponse{Code: ResponseCustom, Data: events[0]})
+						events = events[1:]
+					case OverflowDropNewest:
+						s.drop(r)
+						continue
+					case OverflowFail:
+						s.setErr(ErrBufferOverflow)
+						s.drop(r)
+						overflowed = true
+						continue
+					}
+				}
+				events = append(events, r.Data)
+			case ResponseError:
+				if errors.Is(r.Error, ErrBufferOverflow) {
+					s.setErr(r.Error)
+				}
+			case ResponseAppRequest:
+				appRequest := &ParamsOfAppRequest{}
+				if err := json.Unmarshal(r.Data, appRequest); err != nil {
+					s.setErr(err)
+					continue
+				}
+				appRequests = append(appRequests, appRequest)
+			case ResponseAppNotify:
+				notifications = append(notifications, r.Data)
+			}
+		case eventsOut <- nextEvent:
+			events = events[1:]
+		case appRequestsOut <- nextAppRequest:
+			appRequests = appRequests[1:]
+		case notificationsOut <- nextNotification:
+			notifications = notifications[1:]
+		case <-s.ctx.Done():
+			s.setErr(s.ctx.Err())
+			return
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// bufferedEvents returns closed channel with count events and the result after them.
+func bufferedEvents(count int) chan *ClientResponse {
+	in := make(chan *ClientResponse, count+1)
+	for i := 1; i <= count; i++ {
+		in <- &ClientResponse{Code: ResponseCustom, Data: []byte(fmt.Sprint(i))}
+	}
+	in <- &ClientResponse{Code: ResponseSuccess, Data: []byte(`{}`)}
+	close(in)
+
+	return in
+}
+
+// waitDropped waits until dropped reaches count.
+func waitDropped(dropped *uint64, count uint64) {
+	for i := 0; i < 200 && atomic.LoadUint64(dropped) < count; i++ {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func readAll(out <-chan *ClientResponse) []string {
+	var data []string
+	for r := range out {
+		if r.Error != nil {
+			data = append(data, r.Error.Error())
+			continue
+		}
+		data = append(data, string(r.Data))
+	}
+
+	return data
+}
+
+func TestBackpressure(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		policy  OverflowPolicy
+		dropped uint64
+		want    []string
+	}{
+		{name: "DropOldest", policy: OverflowDropOldest, dropped: 3, want: []string{"4", "5", "{}"}},
+		{name: "DropNewest", policy: OverflowDropNewest, dropped: 3, want: []string{"1", "2", "{}"}},
+		{name: "Fail", policy: OverflowFail, dropped: 4, want: []string{"1", "2", ErrBufferOverflow.Error()}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var dropped uint64
+			out := BufferResponses(bufferedEvents(5), Backpressure{Size: 2, Policy: tc.policy}, func(*ClientResponse) {
+				atomic.AddUint64(&dropped, 1)
+			})
+			waitDropped(&dropped, tc.dropped)
+
+			assert.Equal(t, tc.want, readAll(out))
+			waitDropped(&dropped, tc.dropped)
+			assert.Equal(t, tc.dropped, atomic.LoadUint64(&dropped))
+		})
+	}
+
+	t.Run("Block", func(t *testing.T) {
+		in := make(chan *ClientResponse)
+		out := BufferResponses(in, Backpressure{Size: 2, Policy: OverflowBlock}, nil)
+		in <- &ClientResponse{Code: ResponseCustom}
+		in <- &ClientResponse{Code: ResponseCustom}
+		select {
+		case in <- &ClientResponse{Code: ResponseCustom}:
+			t.Fatal("full buffer is read")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		<-out
+		in <- &ClientResponse{Code: ResponseCustom}
+		close(in)
+		assert.Len(t, readAll(out), 2)
+	})
+
+	t.Run("DropOldestOfAppRequests", func(t *testing.T) {
+		var dropped uint64
+		in := make(chan *ClientResponse, 4)
+		in <- &ClientResponse{Code: ResponseAppRequest, Data: []byte("1")}
+		in <- &ClientResponse{Code: ResponseAppRequest, Data: []byte("2")}
+		in <- &ClientResponse{Code: ResponseCustom, Data: []byte("3")}
+		in <- &ClientResponse{Code: ResponseAppRequest, Data: []byte("4")}
+		close(in)
+		out := BufferResponses(in, Backpressure{Size: 2, Policy: OverflowDropOldest}, func(*ClientResponse) {
+			atomic.AddUint64(&dropped, 1)
+		})
+		waitDropped(&dropped, 1)
+
+		assert.Equal(t, []string{"1", "2", "4"}, readAll(out))
+		assert.Equal(t, uint64(1), atomic.LoadUint64(&dropped))
+	})
+
+	t.Run("Unbounded", func(t *testing.T) {
+		assert.Equal(t, []string{"1", "2", "3", "{}"}, readAll(DynBufferForResponses(bufferedEvents(3))))
+	})
+
+	t.Run("BufferOnce", func(t *testing.T) {
+		in := make(chan *ClientResponse)
+		defer close(in)
+		stream := NewStream(context.Background(), in).WithBackpressure(Backpressure{Size: 2, Policy: OverflowDropOldest})
+		assert.Same(t, stream, stream.Buffer())
+		derived := stream.Derive(nil)
+		assert.Same(t, derived, derived.Buffer())
+
+		unbuffered := NewStream(context.Background(), in)
+		assert.NotSame(t, unbuffered, unbuffered.Buffer())
+	})
+
+	t.Run("Events", func(t *testing.T) {
+		var hooked uint64
+		in := make(chan *ClientResponse, 11)
+		in <- &ClientResponse{Code: ResponseSuccess, Data: []byte(`{}`)}
+		for i := 0; i < 10; i++ {
+			in <- &ClientResponse{Code: ResponseCustom, Data: []byte(fmt.Sprint(i))}
+		}
+		stream := NewStream(context.Background(), in).WithBackpressure(Backpressure{
+			Size:   2,
+			Policy: OverflowFail,
+			OnDrop: func(*ClientResponse) { atomic.AddUint64(&hooked, 1) },
+		})
+		assert.NoError(t, stream.Result(nil))
+		for len(in) > 0 {
+			time.Sleep(time.Millisecond)
+		}
+
+		received := 0
+		for range stream.Events() {
+			received++
+		}
+		assert.True(t, errors.Is(stream.Err(), ErrBufferOverflow))
+		assert.True(t, received <= 4)
+		waitDropped(&hooked, uint64(10-received))
+		assert.Equal(t, uint64(10-received), stream.Derive(nil).Dropped())
+		assert.Equal(t, stream.Dropped(), atomic.LoadUint64(&hooked))
+		close(in)
+	})
+}
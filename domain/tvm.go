@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -90,28 +91,31 @@ type (
 
 	TvmUseCase interface {
 		RunExecutor(*ParamsOfRunExecutor) (*ResultOfRunExecuteMessage, error)
+		RunExecutorContext(context.Context, *ParamsOfRunExecutor) (*ResultOfRunExecuteMessage, error)
 		RunTvm(*ParamsOfRunTvm) (*ResultOfRunTvm, error)
+		RunTvmContext(context.Context, *ParamsOfRunTvm) (*ResultOfRunTvm, error)
 		RunGet(*ParamsOfRunGet) (*ResultOfRunGet, error)
+		RunGetContext(context.Context, *ParamsOfRunGet) (*ResultOfRunGet, error)
 	}
 )
 
 func init() {
 	TVMErrorCode = map[string]int{
-		"CanNotReadTransaction      ": 401,
-		"CanNotReadBlockchainConfig ": 402,
-		"TransactionAborted         ": 403,
-		"InternalError              ": 404,
-		"ActionPhaseFailed          ": 405,
-		"AccountCodeMissing         ": 406,
-		"LowBalance                 ": 407,
-		"AccountFrozenOrDeleted     ": 408,
-		"AccountMissing             ": 409,
-		"UnknownExecutionError      ": 410,
-		"InvalidInputStack          ": 411,
-		"InvalidAccountBoc          ": 412,
-		"InvalidMessageType         ": 413,
-		"ContractExecutionError     ": 414,
-		"AccountIsSuspended			":       415,
+		"CanNotReadTransaction":      401,
+		"CanNotReadBlockchainConfig": 402,
+		"TransactionAborted":         403,
+		"InternalError":              404,
+		"ActionPhaseFailed":          405,
+		"AccountCodeMissing":         406,
+		"LowBalance":                 407,
+		"AccountFrozenOrDeleted":     408,
+		"AccountMissing":             409,
+		"UnknownExecutionError":      410,
+		"InvalidInputStack":          411,
+		"InvalidAccountBoc":          412,
+		"InvalidMessageType":         413,
+		"ContractExecutionError":     414,
+		"AccountIsSuspended":         415,
 	}
 }
 
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -73,10 +74,15 @@ type (
 
 	UtilsUseCase interface {
 		ConvertAddress(*ParamsOfConvertAddress) (*ResultOfConvertAddress, error)
+		ConvertAddressContext(context.Context, *ParamsOfConvertAddress) (*ResultOfConvertAddress, error)
 		GetAddressType(*ParamsOfGetAddressType) (*ResultOfGetAddressType, error)
+		GetAddressTypeContext(context.Context, *ParamsOfGetAddressType) (*ResultOfGetAddressType, error)
 		CalcStorageFee(pOCA *ParamsOfCalcStorageFee) (*ResultOfCalcStorageFee, error)
+		CalcStorageFeeContext(context.Context, *ParamsOfCalcStorageFee) (*ResultOfCalcStorageFee, error)
 		CompressZstd(pOCA *ParamsOfCompressZstd) (*ResultOfCompressZstd, error)
+		CompressZstdContext(context.Context, *ParamsOfCompressZstd) (*ResultOfCompressZstd, error)
 		DecompressZstd(pOCA *ParamsOfDecompressZstd) (*ResultOfDecompressZstd, error)
+		DecompressZstdContext(context.Context, *ParamsOfDecompressZstd) (*ResultOfDecompressZstd, error)
 	}
 )
 
@@ -1,8 +1,14 @@
 package goever
 
 import (
+	"context"
+	"errors"
+
 	"github.com/markgenuine/ever-client-go/domain"
 	clientgw "github.com/markgenuine/ever-client-go/gateway/client"
+	"github.com/markgenuine/ever-client-go/gateway/failover"
+	"github.com/markgenuine/ever-client-go/gateway/pool"
+	"github.com/markgenuine/ever-client-go/gateway/reload"
 	"github.com/markgenuine/ever-client-go/usecase/abi"
 	"github.com/markgenuine/ever-client-go/usecase/boc"
 	"github.com/markgenuine/ever-client-go/usecase/crypto"
@@ -26,15 +32,68 @@ type Ever struct {
 	Proofs     domain.ProofsUseCase
 	Tvm        domain.TvmUseCase
 	Utils      domain.UtilsUseCase
+
+	reload *reload.Gateway
 }
 
+// ErrNotReloadable - Reconfigure of Ever which isn't created by NewEverReloadable.
+var ErrNotReloadable = errors.New("ever is not reloadable, it is created without NewEverReloadable")
+
 // NewEverWithConfig ...
-func NewEverWithConfig(config domain.ClientConfig) (*Ever, error) {
-	client, err := clientgw.NewClientGateway(config)
+// opts configure the client gateway, e.g. clientgw.WithInterceptors.
+func NewEverWithConfig(config domain.ClientConfig, opts ...clientgw.Option) (*Ever, error) {
+	client, err := clientgw.NewClientGateway(config, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newEver(config, client), nil
+}
+
+// NewEverWithFailover creates Ever over contexts of several providers: net and processing go to the healthy
+// provider, see failover.Gateway. Switches of the provider are passed to the hook of failover.WithSwitchHook.
+func NewEverWithFailover(config domain.ClientConfig, providers []failover.Provider, opts ...failover.Option) (*Ever, error) {
+	client, err := failover.NewGateway(config, providers, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newEver(config, client), nil
+}
+
+// NewEverPool creates Ever over size contexts with config, calls go to the context with the least calls in flight
+// and calls with handles to the context which opened them, see pool.Gateway.
+func NewEverPool(config domain.ClientConfig, size int, opts ...pool.Option) (*Ever, error) {
+	client, err := pool.NewGateway(config, size, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newEver(config, client), nil
+}
+
+// NewEverReloadable creates Ever whose config is changed by Reconfigure, factory makes the gateway of every
+// config, e.g. with clientgw.NewClientGateway, failover.NewGateway or pool.NewGateway and their options:
+//
+//	ever, err := goever.NewEverReloadable(config, func(config domain.ClientConfig) (domain.ClientGateway, error) {
+//		return clientgw.NewClientGateway(config, opts...)
+//	})
+//
+// Handles of every gateway are replaced by virtual ones, so they stay unique across configs.
+func NewEverReloadable(config domain.ClientConfig, factory reload.Factory, opts ...reload.Option) (*Ever, error) {
+	client, err := reload.NewGateway(config, factory, opts...)
 	if err != nil {
 		return nil, err
 	}
-	ever := &Ever{
+	ever := newEver(config, client)
+	ever.reload = client
+
+	return ever, nil
+}
+
+// newEver creates use cases over client.
+func newEver(config domain.ClientConfig, client domain.ClientGateway) *Ever {
+	return &Ever{
 		Abi:        abi.NewAbi(config, client),
 		Boc:        boc.NewBoc(config, client),
 		Client:     client,
@@ -46,7 +105,6 @@ func NewEverWithConfig(config domain.ClientConfig) (*Ever, error) {
 		Tvm:        tvm.NewTvm(config, client),
 		Utils:      utils.NewUtils(config, client),
 	}
-	return ever, nil
 }
 
 // NewEver ...
@@ -54,3 +112,74 @@ func NewEver(address string, endPoints []string, accessKey string) (*Ever, error
 	conf := domain.NewDefaultConfig(address, endPoints, accessKey)
 	return NewEverWithConfig(conf)
 }
+
+// NewEverForProfile creates Ever for the network of the registered profile, e.g. domain.ProfileVenomTestnet,
+// see domain.RegisterProfile and domain.LoadProfiles for user profiles. Profiles of Evercloud need project ID,
+// they are used with NewEverForProject.
+func NewEverForProfile(name string, accessKey string, opts ...clientgw.Option) (*Ever, error) {
+	return NewEverForProject(name, "", accessKey, opts...)
+}
+
+// NewEverForProject creates Ever for the network of the registered profile whose endpoints carry projectID,
+// e.g. domain.ProfileEverscaleMainnet with ID of the Evercloud project.
+func NewEverForProject(name string, projectID string, accessKey string, opts ...clientgw.Option) (*Ever, error) {
+	profile, err := domain.Profile(name)
+	if err != nil {
+		return nil, err
+	}
+	profile, err = profile.ForProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEverWithConfig(profile.Config(accessKey), opts...)
+}
+
+// Reconfigure creates a context with config and swaps it under the use cases of ever. New calls go to the new
+// context, subscriptions are made there again, calls with other handles of the previous context (iterators,
+// boxes, debots, ...) go there until the handles are released. The previous context is destroyed when it is
+// idle. Subscriptions which couldn't be made again are returned as error, they stay in the previous context.
+// Ever has to be created by NewEverReloadable, otherwise ErrNotReloadable is returned.
+func (e *Ever) Reconfigure(config domain.ClientConfig) error {
+	if e.reload == nil {
+		return ErrNotReloadable
+	}
+
+	return e.reload.Reconfigure(config)
+}
+
+// Close releases subscriptions, iterators, boxes, debots and monitor queues opened by ever, waits for requests
+// in progress until ctx is done and destroys the client context.
+func (e *Ever) Close(ctx context.Context) error {
+	return e.Client.Close(ctx)
+}
+
+// OpenHandles returns SDK handles opened by ever which aren't released yet. Call sites of handles are known
+// when ever is created with clientgw.WithHandleTracking.
+func (e *Ever) OpenHandles() []*domain.OpenHandle {
+	if tracker, ok := e.Client.(domain.HandleTracker); ok {
+		return tracker.OpenHandles()
+	}
+
+	return nil
+}
+
+// RequestStats returns requests of ever which wait for the core library and count of expired ones, see
+// clientgw.WithRequestTimeout.
+func (e *Ever) RequestStats() domain.RequestStats {
+	if tracker, ok := e.Client.(domain.RequestTracker); ok {
+		return tracker.RequestStats()
+	}
+
+	return domain.RequestStats{}
+}
+
+// DroppedResponses returns count of responses dropped by backpressure of streams of ever by functions, see
+// clientgw.WithBackpressure.
+func (e *Ever) DroppedResponses() map[string]uint64 {
+	if tracker, ok := e.Client.(domain.DropTracker); ok {
+		return tracker.DroppedResponses()
+	}
+
+	return map[string]uint64{}
+}
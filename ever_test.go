@@ -0,0 +1,119 @@
+package goever
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/markgenuine/ever-client-go/domain"
+	"github.com/markgenuine/ever-client-go/gateway/cassette"
+	clientgw "github.com/markgenuine/ever-client-go/gateway/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTB collects errors of CheckHandleLeaks instead of failing the test.
+type fakeTB struct {
+	cleanups []func()
+	errors   []string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Cleanup(cleanup func()) {
+	f.cleanups = append(f.cleanups, cleanup)
+}
+
+func (f *fakeTB) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTB) finish() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+}
+
+func newSubscriptionsEver(t *testing.T, onLeak func([]*domain.OpenHandle)) *Ever {
+	player := cassette.NewPlayer(&cassette.Cassette{Interactions: []*cassette.Interaction{
+		{Method: "net.subscribe_collection", Params: json.RawMessage(`{"collection":"blocks","result":"id"}`), Responses: []*cassette.Response{
+			{Type: 0, Payload: json.RawMessage(`{"handle":1}`)},
+			{Type: 2, Finished: true},
+		}},
+		{Method: "net.unsubscribe", Params: json.RawMessage(`{"handle":1}`), Responses: []*cassette.Response{
+			{Type: 0, Payload: json.RawMessage(`{}`), Finished: true},
+		}},
+	}})
+
+	ever, err := NewEverWithConfig(domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), ""),
+		clientgw.WithTransport(player), clientgw.WithHandleTracking(onLeak))
+	assert.Equal(t, nil, err)
+
+	return ever
+}
+
+func TestHandleLeaks(t *testing.T) {
+	t.Run("TestOpenHandles", func(t *testing.T) {
+		var leaks []*domain.OpenHandle
+		ever := newSubscriptionsEver(t, func(handles []*domain.OpenHandle) { leaks = handles })
+
+		_, _, result, err := ever.Net.SubscribeCollection(&domain.ParamsOfSubscribeCollection{Collection: "blocks", Result: "id"})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 1, result.Handle)
+
+		handles := ever.OpenHandles()
+		assert.Equal(t, 1, len(handles))
+		assert.Equal(t, clientgw.HandleSubscription, handles[0].Kind)
+		assert.Equal(t, "net.subscribe_collection", handles[0].Method)
+		assert.True(t, strings.Contains(handles[0].CallSite, "ever_test.go"), handles[0].CallSite)
+
+		ever.Client.Destroy()
+		assert.Equal(t, 1, len(leaks))
+	})
+
+	t.Run("TestCheckHandleLeaks", func(t *testing.T) {
+		tb := &fakeTB{}
+		ever := newSubscriptionsEver(t, func([]*domain.OpenHandle) {})
+		CheckHandleLeaks(tb, ever)
+		_, _, _, err := ever.Net.SubscribeCollection(&domain.ParamsOfSubscribeCollection{Collection: "blocks", Result: "id"})
+		assert.Equal(t, nil, err)
+		tb.finish()
+		assert.Equal(t, 1, len(tb.errors))
+		ever.Client.Destroy()
+
+		tb = &fakeTB{}
+		ever = newSubscriptionsEver(t, nil)
+		CheckHandleLeaks(tb, ever)
+		_, _, _, err = ever.Net.SubscribeCollection(&domain.ParamsOfSubscribeCollection{Collection: "blocks", Result: "id"})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, nil, ever.Close(context.Background()))
+		tb.finish()
+		assert.Equal(t, 0, len(tb.errors))
+	})
+}
+
+func TestReconfigure(t *testing.T) {
+	ever := newSubscriptionsEver(t, nil)
+	defer ever.Client.Destroy()
+	assert.True(t, errors.Is(ever.Reconfigure(domain.NewDefaultConfig("", nil, "")), ErrNotReloadable))
+
+	player := cassette.NewPlayer(&cassette.Cassette{Interactions: []*cassette.Interaction{
+		{Method: "net.subscribe_collection", Params: json.RawMessage(`{"collection":"blocks","result":"id"}`), Responses: []*cassette.Response{
+			{Type: 0, Payload: json.RawMessage(`{"handle":1}`)},
+		}},
+	}})
+	reloadable, err := NewEverReloadable(domain.NewDefaultConfig("", nil, ""), func(config domain.ClientConfig) (domain.ClientGateway, error) {
+		return clientgw.NewClientGateway(config, clientgw.WithTransport(player), clientgw.WithHandleTracking(func([]*domain.OpenHandle) {}))
+	})
+	assert.NoError(t, err)
+	defer reloadable.Client.Destroy()
+
+	_, _, result, err := reloadable.Net.SubscribeCollection(&domain.ParamsOfSubscribeCollection{Collection: "blocks", Result: "id"})
+	assert.NoError(t, err)
+	handles := reloadable.OpenHandles()
+	if assert.Len(t, handles, 1) {
+		assert.Equal(t, fmt.Sprint(result.Handle), string(handles[0].Handle))
+	}
+}
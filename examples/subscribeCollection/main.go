@@ -28,7 +28,7 @@ func main() {
 	}
 
 	// # Create generator
-	generator, handle, err := netUC.SubscribeCollection(queryParams)
+	generator, _, handle, err := netUC.SubscribeCollection(queryParams)
 	log.Println("generator: ", generator)
 	log.Println("handle: ", handle)
 	log.Println("err: ", err)
@@ -0,0 +1,330 @@
+// Package cassette records calls of the core library to a file and replays them without libton_client,
+// so use cases can be tested offline.
+package cassette
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/markgenuine/ever-client-go/domain"
+	"github.com/markgenuine/ever-client-go/gateway/client"
+)
+
+type (
+	// Response - one response of the core library, Payload is JSON or empty for nop responses.
+	Response struct {
+		Type     uint32          `json:"type"`
+		Payload  json.RawMessage `json:"payload,omitempty"`
+		Finished bool            `json:"finished,omitempty"`
+	}
+
+	// Interaction - request of method with params and the stream of its responses.
+	Interaction struct {
+		Method    string          `json:"method"`
+		Params    json.RawMessage `json:"params,omitempty"`
+		Responses []*Response     `json:"responses"`
+	}
+
+	// Cassette - recorded interactions in order of requests.
+	Cassette struct {
+		mu           sync.Mutex
+		Interactions []*Interaction `json:"interactions"`
+	}
+
+	// Recorder - transport which passes calls to another transport and records them to cassette.
+	Recorder struct {
+		transport client.Transport
+		cassette  *Cassette
+		path      string
+		errMu     sync.Mutex
+		err       error
+	}
+
+	// Player - transport which serves recorded interactions, requests are matched by method and normalized params.
+	Player struct {
+		cassette *Cassette
+		mu       sync.Mutex
+		played   map[*Interaction]bool
+	}
+
+	// RecordingGateway - client gateway which records its calls to a cassette file.
+	RecordingGateway struct {
+		domain.ClientGateway
+		*Recorder
+	}
+)
+
+// Load reads cassette from file.
+func Load(path string) (*Cassette, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cassette := &Cassette{}
+	if err := json.Unmarshal(data, cassette); err != nil {
+		return nil, fmt.Errorf("cassette %s: %w", path, err)
+	}
+
+	return cassette, nil
+}
+
+// Save writes cassette to file.
+func (c *Cassette) Save(path string) error {
+	c.mu.Lock()
+	data, err := json.MarshalIndent(c, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+func (c *Cassette) add(method string, params []byte) *Interaction {
+	interaction := &Interaction{Method: method, Params: payloadJSON(params)}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Interactions = append(c.Interactions, interaction)
+
+	return interaction
+}
+
+func (c *Cassette) addResponse(interaction *Interaction, payload []byte, responseType uint32, finished bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	interaction.Responses = append(interaction.Responses, &Response{
+		Type:     responseType,
+		Payload:  payloadJSON(payload),
+		Finished: finished,
+	})
+}
+
+// payloadJSON keeps payload as is when it is JSON, otherwise as JSON string.
+func payloadJSON(payload []byte) json.RawMessage {
+	if len(payload) == 0 {
+		return nil
+	}
+	if json.Valid(payload) {
+		return append(json.RawMessage(nil), payload...)
+	}
+	raw, _ := json.Marshal(string(payload))
+
+	return raw
+}
+
+// Normalize returns params in canonical form: JSON with sorted keys and without spaces.
+func Normalize(params []byte) string {
+	if len(params) == 0 {
+		return ""
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(params, &value); err != nil {
+		return string(params)
+	}
+	if value == nil {
+		return ""
+	}
+	normalized, err := json.Marshal(value)
+	if err != nil {
+		return string(params)
+	}
+
+	return string(normalized)
+}
+
+// NewRecorder returns transport which records calls of transport. When path isn't empty the cassette is
+// saved there after every finished request.
+func NewRecorder(transport client.Transport, path string) *Recorder {
+	return &Recorder{
+		transport: transport,
+		cassette:  &Cassette{},
+		path:      path,
+	}
+}
+
+// Cassette returns recorded interactions.
+func (r *Recorder) Cassette() *Cassette {
+	return r.cassette
+}
+
+// Save writes cassette to path of recorder.
+func (r *Recorder) Save() error {
+	err := r.cassette.Save(r.path)
+	r.errMu.Lock()
+	defer r.errMu.Unlock()
+	if err != nil && r.err == nil {
+		r.err = err
+	}
+
+	return err
+}
+
+// Err returns the first error of saving cassette.
+func (r *Recorder) Err() error {
+	r.errMu.Lock()
+	defer r.errMu.Unlock()
+
+	return r.err
+}
+
+func (r *Recorder) CreateContext(config []byte) ([]byte, error) {
+	return r.transport.CreateContext(config)
+}
+
+func (r *Recorder) DestroyContext(context uint32) {
+	r.transport.DestroyContext(context)
+}
+
+func (r *Recorder) Request(context uint32, method string, paramsJSON []byte, handler client.ResponseHandler) error {
+	interaction := r.cassette.add(method, paramsJSON)
+
+	return r.transport.Request(context, method, paramsJSON, func(payload []byte, responseType uint32, finished bool) {
+		r.cassette.addResponse(interaction, payload, responseType, finished)
+		if finished && r.path != "" {
+			_ = r.Save()
+		}
+		handler(payload, responseType, finished)
+	})
+}
+
+// NewPlayer returns transport which replays cassette. Equal requests get recorded interactions in order,
+// the last one is repeated when they are over.
+func NewPlayer(cassette *Cassette) *Player {
+	return &Player{
+		cassette: cassette,
+		played:   make(map[*Interaction]bool),
+	}
+}
+
+// Unplayed returns interactions which weren't requested yet.
+func (p *Player) Unplayed() []*Interaction {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var unplayed []*Interaction
+	for _, interaction := range p.cassette.Interactions {
+		if !p.played[interaction] {
+			unplayed = append(unplayed, interaction)
+		}
+	}
+
+	return unplayed
+}
+
+func (p *Player) next(method string, params []byte) *Interaction {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := Normalize(params)
+	var last *Interaction
+	for _, interaction := range p.cassette.Interactions {
+		if interaction.Method != method || Normalize(interaction.Params) != key {
+			continue
+		}
+		if !p.played[interaction] {
+			p.played[interaction] = true
+			return interaction
+		}
+		last = interaction
+	}
+
+	return last
+}
+
+func (p *Player) CreateContext([]byte) ([]byte, error) {
+	return []byte(`{"result":1}`), nil
+}
+
+func (p *Player) DestroyContext(uint32) {}
+
+func (p *Player) Request(_ uint32, method string, paramsJSON []byte, handler client.ResponseHandler) error {
+	interaction := p.next(method, paramsJSON)
+	if interaction == nil {
+		payload, _ := json.Marshal(&domain.ClientError{
+			Code:    domain.ClientErrorCode["NotImplemented"],
+			Message: fmt.Sprintf("cassette: no interaction for %s %s", method, Normalize(paramsJSON)),
+		})
+		go handler(payload, domain.ResponseError, true)
+		return nil
+	}
+
+	go func() {
+		for _, response := range interaction.Responses {
+			handler(response.Payload, response.Type, response.Finished)
+		}
+	}()
+
+	return nil
+}
+
+// NewRecordingGateway returns client gateway on the default transport which records its calls to the cassette
+// at path.
+func NewRecordingGateway(config domain.ClientConfig, path string, opts ...client.Option) (*RecordingGateway, error) {
+	transport, err := client.NewDefaultTransport(config)
+	if err != nil {
+		return nil, err
+	}
+
+	recorder := NewRecorder(transport, path)
+	gateway, err := client.NewClientGateway(config, append(opts, client.WithTransport(recorder))...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecordingGateway{ClientGateway: gateway, Recorder: recorder}, nil
+}
+
+// Request starts method in the client gateway, the recorder is only its transport.
+func (g *RecordingGateway) Request(method string, paramIn interface{}) (*domain.Stream, error) {
+	return g.ClientGateway.Request(method, paramIn)
+}
+
+// Destroy destroys client context and saves the cassette, see Err for the result of saving.
+func (g *RecordingGateway) Destroy() {
+	g.ClientGateway.Destroy()
+	_ = g.Save()
+}
+
+// Close closes client gateway gracefully and saves the cassette.
+func (g *RecordingGateway) Close(ctx context.Context) error {
+	err := g.ClientGateway.Close(ctx)
+	if saveErr := g.Save(); err == nil {
+		err = saveErr
+	}
+
+	return err
+}
+
+// RecordEnv - environment variable which makes NewGateway record cassettes instead of replaying them.
+const RecordEnv = "EVER_RECORD_CASSETTES"
+
+// NewGateway returns gateway of NewReplayGateway. When RecordEnv is set it returns gateway of NewRecordingGateway
+// instead, so tests of cassettes record them against libton_client and the network of config.
+func NewGateway(config domain.ClientConfig, path string, opts ...client.Option) (domain.ClientGateway, error) {
+	if os.Getenv(RecordEnv) == "" {
+		return NewReplayGateway(config, path, opts...)
+	}
+
+	gateway, err := NewRecordingGateway(config, path, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return gateway, nil
+}
+
+// NewReplayGateway returns client gateway which serves the cassette at path, libton_client isn't used.
+func NewReplayGateway(config domain.ClientConfig, path string, opts ...client.Option) (domain.ClientGateway, error) {
+	cassette, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.NewClientGateway(config, append(opts, client.WithTransport(NewPlayer(cassette)))...)
+}
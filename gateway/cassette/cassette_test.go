@@ -0,0 +1,101 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/markgenuine/ever-client-go/domain"
+	"github.com/markgenuine/ever-client-go/gateway/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// echoTransport answers every request with an event and the result which repeats params.
+type echoTransport struct{}
+
+func (echoTransport) CreateContext([]byte) ([]byte, error) {
+	return []byte(`{"result":1}`), nil
+}
+
+func (echoTransport) DestroyContext(uint32) {}
+
+func (echoTransport) Request(_ uint32, method string, paramsJSON []byte, handler client.ResponseHandler) error {
+	go func() {
+		handler([]byte(`{"method":"`+method+`"}`), 100, false)
+		handler(paramsJSON, 0, true)
+	}()
+
+	return nil
+}
+
+func collect(t *testing.T, clientConn domain.ClientGateway, method string, params interface{}) []*domain.ClientResponse {
+	stream, err := clientConn.Request(method, params)
+	assert.Equal(t, nil, err)
+
+	var collected []*domain.ClientResponse
+	for {
+		r, err := stream.Next(context.Background())
+		if err != nil {
+			assert.Equal(t, io.EOF, err)
+			break
+		}
+		collected = append(collected, r)
+	}
+
+	return collected
+}
+
+func TestRecordReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cassette")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "echo.json")
+	config := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), "")
+
+	t.Run("TestRecord", func(t *testing.T) {
+		recorder := NewRecorder(echoTransport{}, path)
+		clientConn, err := client.NewClientGateway(config, client.WithTransport(recorder))
+		assert.Equal(t, nil, err)
+		defer clientConn.Destroy()
+
+		responses := collect(t, clientConn, "test.echo", map[string]int{"a": 1, "b": 2})
+		assert.Equal(t, 2, len(responses))
+		assert.Equal(t, nil, recorder.Err())
+
+		recorded, err := Load(path)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 1, len(recorded.Interactions))
+		assert.Equal(t, "test.echo", recorded.Interactions[0].Method)
+		assert.Equal(t, 2, len(recorded.Interactions[0].Responses))
+		assert.Equal(t, uint32(100), recorded.Interactions[0].Responses[0].Type)
+	})
+
+	t.Run("TestReplay", func(t *testing.T) {
+		clientConn, err := NewReplayGateway(config, path)
+		assert.Equal(t, nil, err)
+		defer clientConn.Destroy()
+
+		// Params are matched regardless of order of keys and spaces, the last interaction is repeated.
+		for i := 0; i < 2; i++ {
+			responses := collect(t, clientConn, "test.echo", map[string]interface{}{"b": 2, "a": 1})
+			assert.Equal(t, 2, len(responses))
+			assert.Equal(t, uint32(100), responses[0].Code)
+			assert.JSONEq(t, `{"a":1,"b":2}`, string(responses[1].Data))
+		}
+
+		_, err = clientConn.GetResponse("test.echo", map[string]int{"a": 2})
+		var clientErr *domain.ClientError
+		assert.True(t, errors.As(err, &clientErr))
+		assert.Equal(t, domain.ClientErrorCode["NotImplemented"], clientErr.Code)
+	})
+
+	t.Run("TestNormalize", func(t *testing.T) {
+		assert.Equal(t, `{"a":[1,{"c":3,"d":4}],"b":"x"}`, Normalize([]byte(`{ "b": "x", "a": [1, {"d": 4, "c": 3}] }`)))
+		assert.Equal(t, "", Normalize(nil))
+		assert.Equal(t, "", Normalize([]byte("null")))
+	})
+}
@@ -0,0 +1,473 @@
+package client
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/markgenuine/ever-client-go/domain"
+)
+
+// signingBoxRelease - release function of signing boxes, they are also used by signers of abi and processing.
+const signingBoxRelease = "crypto.remove_signing_box"
+
+type (
+	// handleUse - field of params with handle which is opened by a function released by release.
+	handleUse struct {
+		release string
+		field   string
+	}
+
+	// virtualHandle - numeric handle of one context, it is known to the caller by its virtual number.
+	virtualHandle struct {
+		owner   int
+		release string
+		real    json.RawMessage
+	}
+
+	// Affinity - owners of SDK handles for gateways over several contexts. A handle belongs to the context which
+	// opened it, so calls with it have to go there: subscriptions, iterators, boxes, debots, monitor queues,
+	// pinned BOCs and app requests. Contexts number their handles independently, so numeric handles and ids of
+	// app requests are replaced by virtual ones which are unique across contexts. Owners are indexes of contexts.
+	Affinity struct {
+		mu       sync.Mutex
+		next     int64
+		virtual  map[int64]*virtualHandle
+		requests map[int64]*virtualHandle
+		// owners - contexts of handles named by the caller: monitor queues, BOC pins and refs.
+		owners map[string]int
+		// pinRefs - references of BOCs cached with pin, they are forgotten with the pin.
+		pinRefs map[string][]string
+		// nested - count of handles which are looked for in the whole params: signing boxes and BOC refs.
+		nested int
+	}
+)
+
+// handleUsers - functions which use handles without releasing them.
+var handleUsers = map[string][]handleUse{
+	"net.iterator_next":                         {{release: "net.remove_iterator", field: "handle"}},
+	"crypto.signing_box_get_public_key":         {{release: "crypto.remove_signing_box", field: "handle"}},
+	"crypto.signing_box_sign":                   {{release: "crypto.remove_signing_box", field: "signing_box"}},
+	"crypto.encryption_box_get_info":            {{release: "crypto.remove_encryption_box", field: "encryption_box"}},
+	"crypto.encryption_box_encrypt":             {{release: "crypto.remove_encryption_box", field: "encryption_box"}},
+	"crypto.encryption_box_decrypt":             {{release: "crypto.remove_encryption_box", field: "encryption_box"}},
+	"crypto.get_crypto_box_info":                {{release: "crypto.remove_crypto_box", field: "handle"}},
+	"crypto.get_crypto_box_seed_phrase":         {{release: "crypto.remove_crypto_box", field: "handle"}},
+	"crypto.get_signing_box_from_crypto_box":    {{release: "crypto.remove_crypto_box", field: "handle"}},
+	"crypto.get_encryption_box_from_crypto_box": {{release: "crypto.remove_crypto_box", field: "handle"}},
+	"crypto.clear_crypto_box_secret_cache":      {{release: "crypto.remove_crypto_box", field: "handle"}},
+	"debot.start":                               {{release: "debot.remove", field: "debot_handle"}},
+	"debot.execute":                             {{release: "debot.remove", field: "debot_handle"}},
+	"debot.send":                                {{release: "debot.remove", field: "debot_handle"}},
+	"boc.cache_set":                             {{release: "boc.cache_unpin", field: "cache_type.pin"}},
+	"processing.monitor_messages":               {{release: "processing.cancel_monitor", field: "queue"}},
+	"processing.fetch_next_monitor_results":     {{release: "processing.cancel_monitor", field: "queue"}},
+	"processing.get_monitor_info":               {{release: "processing.cancel_monitor", field: "queue"}},
+}
+
+// NewAffinity returns affinity without owned handles.
+func NewAffinity() *Affinity {
+	return &Affinity{
+		virtual:  make(map[int64]*virtualHandle),
+		requests: make(map[int64]*virtualHandle),
+		owners:   make(map[string]int),
+		pinRefs:  make(map[string][]string),
+	}
+}
+
+// Owner returns context which owns a handle in params of method and params with handles of that context.
+func (a *Affinity) Owner(method string, params []byte) (int, []byte, bool) {
+	uses := handleUsers[method]
+	if field, isFound := handleReleasers[method]; isFound {
+		uses = append(uses[:len(uses):len(uses)], handleUse{release: method, field: field})
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, use := range uses {
+		value, isFound := handleField(params, use.field)
+		if !isFound {
+			continue
+		}
+		if number, isNumber := virtualNumber(value); isNumber {
+			if handle, isFound := a.virtual[number]; isFound && handle.release == use.release {
+				return handle.owner, replaceField(params, use.field, handle.real), true
+			}
+			continue
+		}
+		if owner, isFound := a.owners[use.release+" "+string(value)]; isFound {
+			return owner, params, true
+		}
+	}
+
+	if a.nested > 0 && len(params) > 0 {
+		return a.nestedOwner(params)
+	}
+
+	return 0, params, false
+}
+
+// Track records handle opened by method in context owner and forgets released one. params are the params of
+// the caller, result is the successful result of the context, it is returned with the virtual handle.
+func (a *Affinity) Track(owner int, method string, params, result []byte) []byte {
+	if field, isFound := handleReleasers[method]; isFound {
+		if value, isFound := handleField(params, field); isFound {
+			a.forget(method, value)
+		}
+		return result
+	}
+
+	spec, isFound := handleOpeners[method]
+	if !isFound {
+		return result
+	}
+	source := result
+	if spec.fromParams {
+		source = params
+	}
+	value, isFound := handleField(source, spec.field)
+	if !isFound {
+		return result
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, isNumber := virtualNumber(value); isNumber && !spec.fromParams {
+		a.next++
+		a.virtual[a.next] = &virtualHandle{owner: owner, release: spec.release, real: append(json.RawMessage(nil), value...)}
+		if spec.kind == HandleSigningBox {
+			a.nested++
+		}
+		return replaceField(result, spec.field, json.RawMessage(strconv.FormatInt(a.next, 10)))
+	}
+
+	key := spec.release + " " + string(value)
+	a.owners[key] = owner
+	if spec.kind == HandleBocPin {
+		if ref, isFound := handleField(result, "boc_ref"); isFound {
+			refKey := "boc_ref " + string(ref)
+			if _, isOwned := a.owners[refKey]; !isOwned {
+				a.nested++
+			}
+			a.owners[refKey] = owner
+			a.pinRefs[key] = append(a.pinRefs[key], refKey)
+		}
+	}
+
+	return result
+}
+
+// TrackAppRequest replaces id of app request with data of ParamsOfAppRequest sent by context owner with
+// the virtual one, ResolveOwner returns it back.
+func (a *Affinity) TrackAppRequest(owner int, data []byte) []byte {
+	id, isFound := handleField(data, "app_request_id")
+	if !isFound {
+		return data
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.next++
+	a.requests[a.next] = &virtualHandle{owner: owner, real: append(json.RawMessage(nil), id...)}
+
+	return replaceField(data, "app_request_id", json.RawMessage(strconv.FormatInt(a.next, 10)))
+}
+
+// TrackResponse replaces handles and ids of app requests in response of context owner with virtual ones,
+// params are the params of the caller.
+func (a *Affinity) TrackResponse(owner int, method string, params []byte, r *domain.ClientResponse) *domain.ClientResponse {
+	switch r.Code {
+	case domain.ResponseSuccess:
+		return &domain.ClientResponse{Code: r.Code, Data: a.Track(owner, method, params, r.Data)}
+	case domain.ResponseAppRequest:
+		return &domain.ClientResponse{Code: r.Code, Data: a.TrackAppRequest(owner, r.Data)}
+	default:
+		return r
+	}
+}
+
+// Virtual returns handles of context owner with virtual numbers instead of numeric handles of the context.
+func (a *Affinity) Virtual(owner int, handles []*domain.OpenHandle) []*domain.OpenHandle {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	numbers := make(map[string]int64)
+	for number, handle := range a.virtual {
+		if handle.owner == owner {
+			numbers[handle.release+" "+string(handle.real)] = number
+		}
+	}
+
+	virtual := make([]*domain.OpenHandle, len(handles))
+	for i, handle := range handles {
+		virtual[i] = handle
+		spec, isFound := handleOpeners[handle.Method]
+		if !isFound {
+			continue
+		}
+		if number, isFound := numbers[spec.release+" "+string(handle.Handle)]; isFound {
+			replaced := *handle
+			replaced.Handle = json.RawMessage(strconv.FormatInt(number, 10))
+			virtual[i] = &replaced
+		}
+	}
+
+	return virtual
+}
+
+// ResolveOwner returns context which sent app request and params with its id in that context. Unknown requests
+// go to the first context as they are. Virtual signing boxes in the result, e.g. of debot GetSigningBox, are
+// replaced with their handles when they are opened in the same context, boxes of other contexts can't be used
+// there and are passed as they are.
+func (a *Affinity) ResolveOwner(params *domain.ParamsOfResolveAppRequest) (int, *domain.ParamsOfResolveAppRequest) {
+	a.mu.Lock()
+	request, isFound := a.requests[int64(params.AppRequestID)]
+	delete(a.requests, int64(params.AppRequestID))
+	a.mu.Unlock()
+	if !isFound {
+		return 0, params
+	}
+
+	realID, err := strconv.Atoi(string(request.real))
+	if err != nil {
+		return request.owner, params
+	}
+	resolved := *params
+	resolved.AppRequestID = realID
+	if params.Result != nil {
+		if ok, isOk := params.Result.ValueEnumType.(domain.AppRequestResultOk); isOk {
+			ok.Result = a.realSigningBoxes(request.owner, ok.Result)
+			resolved.Result = &domain.AppRequestResult{ValueEnumType: ok}
+		}
+	}
+
+	return request.owner, &resolved
+}
+
+// realSigningBoxes returns result of app request with virtual signing boxes of context owner replaced with their
+// handles in that context: "signing_box" fields and signers of SigningBox type at any depth.
+func (a *Affinity) realSigningBoxes(owner int, result json.RawMessage) json.RawMessage {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.nested == 0 || len(result) == 0 {
+		return result
+	}
+
+	var value interface{}
+	decoder := json.NewDecoder(strings.NewReader(string(result)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return result
+	}
+
+	replaced := false
+	real := func(number interface{}) (json.Number, bool) {
+		n, isNumber := number.(json.Number)
+		if !isNumber {
+			return "", false
+		}
+		virtual, err := n.Int64()
+		handle, isFound := a.virtual[virtual]
+		if err != nil || !isFound || handle.release != signingBoxRelease || handle.owner != owner {
+			return "", false
+		}
+		replaced = true
+		return json.Number(handle.real), true
+	}
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			if handle, isReal := real(v["signing_box"]); isReal {
+				v["signing_box"] = handle
+			}
+			if v["type"] == "SigningBox" {
+				if handle, isReal := real(v["handle"]); isReal {
+					v["handle"] = handle
+				}
+			}
+			for key, field := range v {
+				if key != "signing_box" && key != "handle" {
+					walk(field)
+				}
+			}
+		case []interface{}:
+			for _, item := range v {
+				walk(item)
+			}
+		}
+	}
+	walk(value)
+	if !replaced {
+		return result
+	}
+
+	rewritten, err := json.Marshal(value)
+	if err != nil {
+		return result
+	}
+
+	return rewritten
+}
+
+// Move makes handle opened by method belong to context owner, virtual is the result returned by Track and real
+// is the result of the same method in owner. It is used when a subscription is made again in another context.
+func (a *Affinity) Move(method string, virtual, real []byte, owner int) bool {
+	spec, isFound := handleOpeners[method]
+	if !isFound {
+		return false
+	}
+	value, isFound := handleField(virtual, spec.field)
+	if !isFound {
+		return false
+	}
+	number, isNumber := virtualNumber(value)
+	realValue, isFound := handleField(real, spec.field)
+	if !isNumber || !isFound {
+		return false
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	handle, isFound := a.virtual[number]
+	if !isFound {
+		return false
+	}
+	handle.owner = owner
+	handle.real = append(json.RawMessage(nil), realValue...)
+
+	return true
+}
+
+// Owns reports whether context owner has handles or app requests which aren't released yet.
+func (a *Affinity) Owns(owner int) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, handle := range a.virtual {
+		if handle.owner == owner {
+			return true
+		}
+	}
+	for _, request := range a.requests {
+		if request.owner == owner {
+			return true
+		}
+	}
+	for _, handleOwner := range a.owners {
+		if handleOwner == owner {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (a *Affinity) forget(release string, value json.RawMessage) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if number, isNumber := virtualNumber(value); isNumber {
+		if handle, isFound := a.virtual[number]; isFound && handle.release == release {
+			delete(a.virtual, number)
+			if release == signingBoxRelease {
+				a.nested--
+			}
+		}
+		return
+	}
+
+	key := release + " " + string(value)
+	delete(a.owners, key)
+	for _, refKey := range a.pinRefs[key] {
+		if _, isFound := a.owners[refKey]; isFound {
+			delete(a.owners, refKey)
+			a.nested--
+		}
+	}
+	delete(a.pinRefs, key)
+}
+
+// nestedOwner looks for signers with signing box and for BOC refs at any depth of params, a.mu is held.
+func (a *Affinity) nestedOwner(params []byte) (int, []byte, bool) {
+	var value interface{}
+	decoder := json.NewDecoder(strings.NewReader(string(params)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return 0, params, false
+	}
+
+	var (
+		owner    int
+		isOwned  bool
+		replaced bool
+	)
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			if number, isNumber := v["handle"].(json.Number); isNumber && v["type"] == "SigningBox" {
+				n, err := number.Int64()
+				if handle, isFound := a.virtual[n]; err == nil && isFound && handle.release == signingBoxRelease {
+					if !isOwned {
+						owner, isOwned = handle.owner, true
+					}
+					v["handle"] = json.Number(handle.real)
+					replaced = true
+				}
+			}
+			for _, field := range v {
+				walk(field)
+			}
+		case []interface{}:
+			for _, item := range v {
+				walk(item)
+			}
+		case string:
+			if ref, _ := json.Marshal(v); strings.HasPrefix(v, "*") && !isOwned {
+				owner, isOwned = a.owners["boc_ref "+string(ref)]
+			}
+		}
+	}
+	walk(value)
+	if !replaced {
+		return owner, params, isOwned
+	}
+
+	rewritten, err := json.Marshal(value)
+	if err != nil {
+		return owner, params, isOwned
+	}
+
+	return owner, rewritten, isOwned
+}
+
+// virtualNumber returns value of numeric handle.
+func virtualNumber(value json.RawMessage) (int64, bool) {
+	number, err := strconv.ParseInt(string(value), 10, 64)
+	return number, err == nil
+}
+
+// replaceField returns payload with the top level or dotted field set to value.
+func replaceField(payload []byte, field string, value json.RawMessage) []byte {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return payload
+	}
+
+	name := field
+	if dot := strings.IndexByte(field, '.'); dot >= 0 {
+		name = field[:dot]
+		value = replaceField(fields[name], field[dot+1:], value)
+	}
+	fields[name] = value
+	replaced, err := json.Marshal(fields)
+	if err != nil {
+		return payload
+	}
+
+	return replaced
+}
@@ -1,67 +1,84 @@
 package client
 
-/*
-#cgo darwin LDFLAGS: -L${SRCDIR}/lib/darwin -lton_client
-#cgo linux LDFLAGS: -L${SRCDIR}/lib/linux -lton_client
-#cgo windows LDFLAGS: -L${SRCDIR}/lib/windows -lton_client
-
-#include "client_method.h"
-void callB(uint32_t request_id, tc_string_data_t paramsJson, uint32_t response_type, bool finished);
-
-*/
-import "C"
 import (
+	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
-	"unsafe"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/markgenuine/ever-client-go/domain"
 )
 
 const (
-	// VersionLibSDK ...
+	// VersionLibSDK - version of libton_client supported by the binding, see WithVersionPolicy.
 	VersionLibSDK = "1.47.0"
 )
 
-var mainStore = NewStore()
-
 type clientGateway struct {
-	client      C.uint32_t
-	config      domain.ClientConfig
-	closeCanals chan struct{}
-}
+	client       uint32
+	config       domain.ClientConfig
+	closeCanals  chan struct{}
+	transport    Transport
+	interceptors []Interceptor
+	invoker      Invoker
 
-func tcStringData(in []byte) C.tc_string_data_t {
-	return C.tc_string_data_t{
-		len:     C.uint32_t(len(in)),
-		content: C.CString(string(in)),
-	}
-}
+	store           Manager
+	retryPolicies   map[string]RetryPolicy
+	requestTimeouts map[string]time.Duration
+	backpressure    map[string]domain.Backpressure
+	dropped         map[string]uint64
+	logger          Logger
+	traceCalls      bool
 
-func tcStringToByte(data C.tc_string_data_t) []byte {
-	if data.len == 0 {
-		return nil
-	}
+	mu          sync.Mutex
+	closing     bool
+	destroyOnce sync.Once
+	pending     *pendingRequests
+	handles     *handleRegistry
+	tracking    handleTracking
+	appObjects  *domain.AppObjectRegistry
+	appOptions  []domain.AppObjectOption
 
-	return C.GoBytes(unsafe.Pointer(data.content), C.int(data.len))
+	versionPolicy VersionPolicy
 }
 
 // NewClientGateway ...
-func NewClientGateway(config domain.ClientConfig) (domain.ClientGateway, error) {
+func NewClientGateway(config domain.ClientConfig, opts ...Option) (domain.ClientGateway, error) {
 	cc := clientGateway{
 		config:      config,
 		closeCanals: make(chan struct{}),
+		store:       NewStore(),
+		pending:     newPendingRequests(),
+		handles:     newHandleRegistry(),
+		logger:      defaultLogger,
 	}
+	for _, opt := range opts {
+		opt(&cc)
+	}
+
+	if cc.transport == nil {
+		transport, err := NewDefaultTransport(config)
+		if err != nil {
+			return nil, err
+		}
+		cc.transport = transport
+	}
+	cc.invoker = chainInterceptors(append(cc.interceptors[:len(cc.interceptors):len(cc.interceptors)], cc.retry), cc.invoke)
+	appOptions := append([]domain.AppObjectOption{domain.WithAppObjectErrorHook(func(err *domain.AppObjectError) {
+		cc.logger.Log(LevelError, "app object failed", Field{"error", err})
+	})}, cc.appOptions...)
+	cc.appObjects = domain.NewAppObjectRegistry(&cc, appOptions...)
 
 	configTrf, err := json.Marshal(config)
 	if err != nil {
 		return nil, err
 	}
 
-	handler := C.tc_create_context(tcStringData(configTrf))
-	defer C.tc_destroy_string(handler)
-	response := tcStringToByte(C.tc_read_string(handler))
+	response, err := cc.transport.CreateContext(configTrf)
+	if err != nil {
+		return nil, err
+	}
 
 	var skdResponse SDKResponse
 	err = json.Unmarshal(response, &skdResponse)
@@ -69,53 +86,112 @@ func NewClientGateway(config domain.ClientConfig) (domain.ClientGateway, error)
 		return nil, err
 	}
 	if skdResponse.Error != nil {
-		fmt.Println(skdResponse.Error)
+		cc.logger.Log(LevelError, "context is not created", Field{"code", skdResponse.Error.Code}, Field{"error", skdResponse.Error})
+		return nil, skdResponse.Error
+	}
+	cc.client = skdResponse.Result
+
+	if transport, isLibrary := cc.transport.(libraryTransport); isLibrary {
+		if err := cc.checkVersion(transport); err != nil {
+			cc.destroy()
+			return nil, err
+		}
 	}
-	cc.client = C.uint32_t(skdResponse.Result)
 
 	return &cc, nil
 }
 
+// Destroy aborts requests in progress and destroys the context at once, SDK handles aren't released.
 func (c *clientGateway) Destroy() {
-	C.tc_destroy_context(c.client)
+	c.destroy()
 }
 
-//export callB
-func callB(requestIDin C.uint32_t, paramsJSON C.tc_string_data_t, responseTypein C.uint32_t, finishedin C.bool) {
-	requestID := uint32(requestIDin)
-	params := C.GoBytes(unsafe.Pointer(paramsJSON.content), C.int(paramsJSON.len))
-	responseType := uint32(responseTypein)
-	finished := bool(finishedin)
-
-	responses, closeSignal, isFound := mainStore.GetChannels(requestID, finished)
-	if !isFound {
-		return
+// Close releases SDK handles opened through the gateway: subscriptions, iterators, boxes, debots and monitor
+// queues, then waits for requests in progress and destroys the context. New requests fail with
+// domain.ErrClientClosed. When ctx is done before requests are finished they are aborted and ctx.Err() is returned.
+func (c *clientGateway) Close(ctx context.Context) error {
+	c.mu.Lock()
+	if c.closing {
+		c.mu.Unlock()
+		return nil
 	}
+	c.closing = true
+	c.mu.Unlock()
+	defer c.destroy()
 
-	if responseType == 2 {
-		if finished {
-			close(responses)
+	var firstErr error
+	for _, handle := range c.handles.drain() {
+		responses, err := c.invoker(ctx, handle.release, handle.params)
+		if err == nil {
+			_, err = c.collectResponse(ctx, responses)
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
 		}
-		return
 	}
 
-	select {
-	case responses <- newResponse(params, responseType):
-		if finished {
-			close(responses)
+	if err := c.pending.wait(ctx); err != nil && firstErr == nil {
+		firstErr = err
+	}
+
+	return firstErr
+}
+
+// WithAppObjects sets options of the registry of app objects, e.g. domain.WithAppRequestTimeout.
+func WithAppObjects(opts ...domain.AppObjectOption) Option {
+	return func(c *clientGateway) {
+		c.appOptions = append(c.appOptions, opts...)
+	}
+}
+
+// AppObjects returns registry which serves app objects of the gateway: signing and encryption boxes, password
+// providers and debot browsers.
+func (c *clientGateway) AppObjects() *domain.AppObjectRegistry {
+	return c.appObjects
+}
+
+// OpenHandles returns SDK handles opened through the gateway which aren't released yet, oldest first.
+func (c *clientGateway) OpenHandles() []*domain.OpenHandle {
+	return c.handles.list()
+}
+
+func (c *clientGateway) destroy() {
+	c.destroyOnce.Do(func() {
+		c.mu.Lock()
+		c.closing = true
+		c.mu.Unlock()
+
+		if leaks := c.handles.list(); c.tracking.enabled && len(leaks) > 0 {
+			onLeak := c.tracking.onLeak
+			if onLeak == nil {
+				onLeak = c.reportLeaks
+			}
+			onLeak(leaks)
+		}
+		close(c.closeCanals)
+		for _, requestID := range c.pending.list() {
+			c.store.DeleteRequestID(requestID)
 		}
-	case <-closeSignal:
-		close(responses)
-		mainStore.DeleteRequestID(requestID)
+		c.transport.DestroyContext(c.client)
+	})
+}
+
+// handleResponse passes response of the core library to the request channel.
+func (c *clientGateway) handleResponse(requestID uint32, params []byte, responseType uint32, finished bool) {
+	if responseType == domain.ResponseNop {
+		c.store.Send(requestID, nil, finished)
+		return
 	}
+
+	c.store.Send(requestID, newResponse(params, responseType), finished)
 }
 
 func newResponse(rawBytes []byte, responseType uint32) *domain.ClientResponse {
 	res := &domain.ClientResponse{
 		Code: responseType,
 	}
-	if responseType == 1 {
-		res.Error = errors.New(string(rawBytes))
+	if responseType == domain.ResponseError {
+		res.Error = newClientError(rawBytes)
 	} else {
 		res.Data = rawBytes
 	}
@@ -123,44 +199,222 @@ func newResponse(rawBytes []byte, responseType uint32) *domain.ClientResponse {
 	return res
 }
 
+// newClientError decodes error payload of SDK, payload which is not ClientError is kept as message.
+func newClientError(rawBytes []byte) *domain.ClientError {
+	clientErr := &domain.ClientError{}
+	if err := json.Unmarshal(rawBytes, clientErr); err != nil {
+		return &domain.ClientError{Message: string(rawBytes)}
+	}
+
+	return clientErr
+}
+
 func (c *clientGateway) GetResult(method string, paramIn interface{}, resultStruct interface{}) error {
-	rawData, err := c.GetResponse(method, paramIn)
+	return c.GetResultContext(context.Background(), method, paramIn, resultStruct)
+}
+
+// GetResultContext - GetResult which stops waiting for the core library when ctx is done.
+func (c *clientGateway) GetResultContext(ctx context.Context, method string, paramIn interface{}, resultStruct interface{}) error {
+	rawData, err := c.GetResponseContext(ctx, method, paramIn)
 	if err != nil {
 		return err
 	}
 	return json.Unmarshal(rawData, resultStruct)
 }
 
-func (c *clientGateway) Request(method string, paramIn interface{}) (<-chan *domain.ClientResponse, error) {
+func (c *clientGateway) Request(method string, paramIn interface{}) (*domain.Stream, error) {
+	return c.RequestContext(context.Background(), method, paramIn)
+}
+
+// RequestContext - Request bound to ctx. When ctx is done the request is removed from the store
+// and the stream ends, later responses of the core library are dropped.
+func (c *clientGateway) RequestContext(ctx context.Context, method string, paramIn interface{}) (*domain.Stream, error) {
+	responses, err := c.request(ctx, method, paramIn)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := domain.NewStream(ctx, responses)
+	if b, isFound := c.backpressureOf(method); isFound {
+		stream = stream.WithBackpressure(b)
+	}
+
+	return stream, nil
+}
+
+// WithBackpressure bounds responses buffered for slow readers of streams of a family of functions: a module,
+// e.g. "net", or a function, e.g. "net.subscribe_collection". Backpressure of the function overrides
+// backpressure of its module. Dropped responses are counted by DroppedResponses.
+func WithBackpressure(family string, b domain.Backpressure) Option {
+	return func(c *clientGateway) {
+		if c.backpressure == nil {
+			c.backpressure = make(map[string]domain.Backpressure)
+		}
+		c.backpressure[family] = b
+	}
+}
+
+// backpressureOf returns backpressure of method which counts dropped responses of the gateway.
+func (c *clientGateway) backpressureOf(method string) (domain.Backpressure, bool) {
 	var (
-		rawBody []byte
-		err     error
+		b       domain.Backpressure
+		isFound bool
 	)
+	for _, family := range familiesOf(method) {
+		if b, isFound = c.backpressure[family]; isFound {
+			break
+		}
+	}
+	if !isFound {
+		return b, false
+	}
 
-	if paramIn != nil {
-		rawBody, err = json.Marshal(paramIn)
-		if err != nil {
-			return nil, err
+	onDrop := b.OnDrop
+	b.OnDrop = func(r *domain.ClientResponse) {
+		c.mu.Lock()
+		if c.dropped == nil {
+			c.dropped = make(map[string]uint64)
 		}
+		c.dropped[method]++
+		c.mu.Unlock()
+		if onDrop != nil {
+			onDrop(r)
+		}
+	}
+
+	return b, true
+}
+
+// DroppedResponses returns count of responses dropped by backpressure of streams by functions.
+func (c *clientGateway) DroppedResponses() map[string]uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dropped := make(map[string]uint64, len(c.dropped))
+	for method, count := range c.dropped {
+		dropped[method] = count
+	}
+
+	return dropped
+}
+
+// request marshals params and passes them to interceptors and the core library.
+func (c *clientGateway) request(ctx context.Context, method string, paramIn interface{}) (<-chan *domain.ClientResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	closing := c.closing
+	c.mu.Unlock()
+	if closing {
+		return nil, domain.ErrClientClosed
+	}
+
+	rawBody, err := MarshalParams(paramIn)
+	if err != nil {
+		return nil, err
 	}
 
+	return c.invoker(ctx, method, rawBody)
+}
+
+// MarshalParams returns params of function as they are sent to the core library: json.RawMessage is passed
+// as it is, nil means function without params.
+func MarshalParams(paramIn interface{}) (json.RawMessage, error) {
+	switch params := paramIn.(type) {
+	case nil:
+		return nil, nil
+	case json.RawMessage:
+		return params, nil
+	default:
+		return json.Marshal(paramIn)
+	}
+}
+
+// invoke registers request in the store and sends it to the core library, it is the last Invoker of interceptors.
+func (c *clientGateway) invoke(ctx context.Context, method string, rawBody []byte) (<-chan *domain.ClientResponse, error) {
+	started := time.Now()
 	responsChan := make(chan *domain.ClientResponse, 1)
-	requestID := mainStore.SetChannels(responsChan, c.closeCanals)
-	C.tc_request(c.client, tcStringData([]byte(method)), tcStringData(rawBody), C.uint32_t(requestID), C.tc_response_handler_t(C.callB))
+	requestID, done := c.store.SetChannels(responsChan, c.closeCanals, c.requestTimeout(method))
+	c.pending.add(requestID)
+	forgotten := make(chan func(), 1)
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.store.DeleteRequestID(requestID)
+		case <-done:
+		}
+		c.pending.remove(requestID)
+		if forget := <-forgotten; forget != nil {
+			forget()
+		}
+	}()
+	var site string
+	if _, isOpener := handleOpeners[method]; isOpener && c.tracking.enabled {
+		site = callSite()
+	}
+	if c.traceCalls {
+		c.logCall(method, requestID, rawBody)
+	}
+	c.handles.released(method, rawBody)
+	handler := func(params []byte, responseType uint32, finished bool) {
+		if responseType == domain.ResponseSuccess {
+			c.handles.opened(method, rawBody, params, site)
+		}
+		c.handleResponse(requestID, params, responseType, finished)
+	}
+	var (
+		forget func()
+		err    error
+	)
+	if transport, isForgetting := c.transport.(forgettingTransport); isForgetting {
+		forget, err = transport.requestForget(c.client, method, rawBody, handler)
+	} else {
+		err = c.transport.Request(c.client, method, rawBody, handler)
+	}
+	forgotten <- forget
+	if err != nil {
+		c.store.DeleteRequestID(requestID)
+		if c.traceCalls {
+			c.logResult(method, requestID, started, err)
+		}
+		return nil, err
+	}
+	if c.traceCalls {
+		return c.traceResponses(ctx, method, requestID, started, responsChan), nil
+	}
+
 	return responsChan, nil
 }
 
 func (c *clientGateway) GetResponse(method string, paramIn interface{}) ([]byte, error) {
-	responsChan, err := c.Request(method, paramIn)
+	return c.GetResponseContext(context.Background(), method, paramIn)
+}
+
+// GetResponseContext - GetResponse which returns ctx.Err() when ctx is done before the core library finishes.
+func (c *clientGateway) GetResponseContext(ctx context.Context, method string, paramIn interface{}) ([]byte, error) {
+	responsChan, err := c.request(ctx, method, paramIn)
 	if err != nil {
 		return nil, err
 	}
-	var data []byte
+
+	return c.collectResponse(ctx, responsChan)
+}
+
+// collectResponse waits for the end of stream and returns the first data and the first error of it.
+func (c *clientGateway) collectResponse(ctx context.Context, responsChan <-chan *domain.ClientResponse) ([]byte, error) {
+	var (
+		data []byte
+		err  error
+	)
 
 	for {
 		select {
 		case r, ok := <-responsChan:
 			if !ok {
+				if data == nil && err == nil {
+					return nil, c.abortReason(ctx)
+				}
 				return data, err
 			}
 			if r.Error != nil && err == nil {
@@ -169,8 +423,33 @@ func (c *clientGateway) GetResponse(method string, paramIn interface{}) ([]byte,
 			if r.Data != nil && data == nil {
 				data = r.Data
 			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
 		case <-c.closeCanals:
-			return nil, errors.New("channels is closed")
+			return nil, domain.ErrClientClosed
 		}
 	}
 }
+
+// abortReason explains why the stream was closed without responses: ctx is done or the gateway is destroyed.
+func (c *clientGateway) abortReason(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	select {
+	case <-c.closeCanals:
+		return domain.ErrClientClosed
+	default:
+		return nil
+	}
+}
+
+// familiesOf returns families of functions of method in order of precedence: the function, e.g. "net.query",
+// and its module, e.g. "net".
+func familiesOf(method string) []string {
+	if dot := strings.IndexByte(method, '.'); dot >= 0 {
+		return []string{method, method[:dot]}
+	}
+
+	return []string{method}
+}
@@ -1,21 +1,45 @@
 package client
 
 import (
-	"github.com/markgenuine/ever-client-go/util"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/markgenuine/ever-client-go/domain"
+	"github.com/markgenuine/ever-client-go/util"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func Test(t *testing.T) {
-	configConn := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls())
+// liveGateway returns gateway over the real core library, the test is skipped when the library can't be loaded.
+func liveGateway(t *testing.T) domain.ClientGateway {
+	configConn := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), "")
 	clientConn, err := NewClientGateway(configConn)
-	assert.Equal(t, nil, err)
+	if err != nil {
+		t.Skipf("core library isn't available: %s", err)
+	}
+
+	return clientConn
+}
+
+func Test(t *testing.T) {
+	clientConn := liveGateway(t)
 	defer clientConn.Destroy()
 
 	t.Run("TestConfigFields", func(t *testing.T) {
-		defConf := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls())
+		defConf := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), "")
 		defConf.Abi.MessageExpirationTimeout = util.IntToPointerInt(0)
 		defConf.Network.MaxReconnectTimeOut = util.IntToPointerInt(100)
 		assert.Equal(t, defConf.Crypto.MnemonicWordCount, util.IntToPointerInt(domain.DefaultWordCount))
@@ -41,3 +65,846 @@ func Test(t *testing.T) {
 		assert.NotNil(t, buildInfo.BuildNumber)
 	})
 }
+
+func TestStore(t *testing.T) {
+	t.Run("TestFinished", func(t *testing.T) {
+		store := NewStore()
+		responses := make(chan *domain.ClientResponse, 1)
+		requestID, done := store.SetChannels(responses, make(chan struct{}), 0)
+		store.Send(requestID, &domain.ClientResponse{Data: []byte(`{}`)}, true)
+
+		r, ok := <-responses
+		assert.True(t, ok)
+		assert.Equal(t, []byte(`{}`), r.Data)
+		_, ok = <-responses
+		assert.False(t, ok)
+		<-done
+
+		store.Send(requestID, &domain.ClientResponse{}, true)
+	})
+
+	t.Run("TestDeleteUnblocksSend", func(t *testing.T) {
+		store := NewStore()
+		responses := make(chan *domain.ClientResponse)
+		requestID, done := store.SetChannels(responses, make(chan struct{}), 0)
+
+		sent := make(chan struct{})
+		go func() {
+			store.Send(requestID, &domain.ClientResponse{}, false)
+			close(sent)
+		}()
+		time.Sleep(10 * time.Millisecond)
+		store.DeleteRequestID(requestID)
+		store.DeleteRequestID(requestID)
+
+		<-sent
+		<-done
+		_, ok := <-responses
+		assert.False(t, ok)
+	})
+
+	t.Run("TestWrapAround", func(t *testing.T) {
+		store := NewStore()
+		multiplexer := store.(*multiplexer)
+		live, _ := store.SetChannels(make(chan *domain.ClientResponse), make(chan struct{}), 0)
+		assert.Equal(t, uint32(1), live)
+
+		multiplexer.requestIDCounter = math.MaxUint32 - 1
+		requestID, _ := store.SetChannels(make(chan *domain.ClientResponse), make(chan struct{}), 0)
+		assert.Equal(t, uint32(math.MaxUint32), requestID)
+		requestID, _ = store.SetChannels(make(chan *domain.ClientResponse), make(chan struct{}), 0)
+		assert.Equal(t, uint32(2), requestID)
+		assert.Equal(t, 3, store.Stats().Live)
+	})
+
+	t.Run("TestTimeout", func(t *testing.T) {
+		store := NewStore()
+		responses := make(chan *domain.ClientResponse, 1)
+		_, done := store.SetChannels(responses, make(chan struct{}), 10*time.Millisecond)
+		finished := make(chan *domain.ClientResponse, 1)
+		requestID, _ := store.SetChannels(finished, make(chan struct{}), 10*time.Millisecond)
+		store.Send(requestID, nil, true)
+		assert.Equal(t, 1, store.Stats().Live)
+
+		<-done
+		r := <-responses
+		assert.True(t, errors.Is(r.Error, domain.ErrRequestTimeout))
+		_, ok := <-responses
+		assert.False(t, ok)
+		assert.Equal(t, StoreStats{Expired: 1}, store.Stats())
+	})
+
+	t.Run("TestTimeoutWithoutReader", func(t *testing.T) {
+		store := NewStore()
+		responses := make(chan *domain.ClientResponse)
+		requestID, done := store.SetChannels(responses, make(chan struct{}), 10*time.Millisecond)
+		sent := make(chan struct{})
+		go func() {
+			store.Send(requestID, &domain.ClientResponse{Code: domain.ResponseCustom}, false)
+			close(sent)
+		}()
+
+		<-sent
+		<-done
+		_, ok := <-responses
+		assert.False(t, ok)
+		assert.Equal(t, StoreStats{Expired: 1}, store.Stats())
+	})
+
+	t.Run("TestStats", func(t *testing.T) {
+		store := NewStore()
+		for i := 0; i < 3; i++ {
+			store.SetChannels(make(chan *domain.ClientResponse), make(chan struct{}), 0)
+		}
+		time.Sleep(5 * time.Millisecond)
+		store.DeleteRequestID(2)
+
+		stats := store.Stats()
+		assert.Equal(t, 2, stats.Live)
+		assert.True(t, stats.Oldest >= 5*time.Millisecond)
+	})
+
+	t.Run("TestGateways", func(t *testing.T) {
+		transport := &handlesTransport{subscriptions: make(map[string]ResponseHandler)}
+		config := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), "")
+		first, err := NewClientGateway(config, WithTransport(transport))
+		assert.Equal(t, nil, err)
+		second, err := NewClientGateway(config, WithTransport(transport),
+			WithRequestTimeout("test", time.Hour), WithRequestTimeout("test.hang", 10*time.Millisecond))
+		assert.Equal(t, nil, err)
+		defer second.Destroy()
+
+		go func() {
+			_, _ = first.GetResponse("test.hang", nil)
+		}()
+		_, err = second.GetResponse("test.hang", nil)
+		assert.True(t, errors.Is(err, domain.ErrRequestTimeout))
+		assert.Equal(t, domain.RequestStats{Expired: 1}, second.(domain.RequestTracker).RequestStats())
+
+		for i := 0; i < 100 && first.(domain.RequestTracker).RequestStats().Live == 0; i++ {
+			time.Sleep(time.Millisecond)
+		}
+		assert.Equal(t, 1, first.(domain.RequestTracker).RequestStats().Live)
+		first.Destroy()
+		assert.Equal(t, 0, first.(domain.RequestTracker).RequestStats().Live)
+	})
+
+	t.Run("TestForget", func(t *testing.T) {
+		transport := &forgettingHandlesTransport{handlesTransport: handlesTransport{subscriptions: make(map[string]ResponseHandler)}}
+		config := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), "")
+		clientConn, err := NewClientGateway(config, WithTransport(transport), WithRequestTimeout("test.hang", 50*time.Millisecond))
+		assert.Equal(t, nil, err)
+		defer clientConn.Destroy()
+
+		_, err = clientConn.GetResponse("test.hang", nil)
+		assert.True(t, errors.Is(err, domain.ErrRequestTimeout))
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+		defer cancel()
+		_, err = clientConn.GetResponseContext(ctx, "test.hang", nil)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		for i := 0; i < 100 && atomic.LoadInt32(&transport.forgotten) < 2; i++ {
+			time.Sleep(time.Millisecond)
+		}
+		assert.Equal(t, int32(2), atomic.LoadInt32(&transport.forgotten))
+	})
+}
+
+// BenchmarkStore passes responses of many concurrent requests through one store.
+func BenchmarkStore(b *testing.B) {
+	store := NewStore()
+	closed := make(chan struct{})
+	b.SetParallelism(16)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			responses := make(chan *domain.ClientResponse, 1)
+			requestID, done := store.SetChannels(responses, closed, 0)
+			go store.Send(requestID, &domain.ClientResponse{Data: []byte(`{}`)}, true)
+			for range responses {
+			}
+			<-done
+		}
+	})
+}
+
+func TestRequestContext(t *testing.T) {
+	transport := &handlesTransport{subscriptions: make(map[string]ResponseHandler)}
+	clientConn, err := NewClientGateway(domain.NewDefaultConfig("", nil, ""), WithTransport(transport))
+	require.NoError(t, err)
+	defer clientConn.Destroy()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = clientConn.GetResponseContext(ctx, "client.version", nil)
+	assert.Equal(t, context.Canceled, err)
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	_, err = clientConn.GetResponseContext(ctx, "test.hang", nil)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestNewResponse(t *testing.T) {
+	response := newResponse([]byte(`{"code":25,"message":"Unknown function: net.unknown","data":{"core_version":"1.47.0"}}`), 1)
+	assert.True(t, errors.Is(response.Error, ErrUnknownFunction))
+	assert.False(t, errors.Is(response.Error, ErrInvalidHandle))
+
+	var clientErr *domain.ClientError
+	assert.True(t, errors.As(response.Error, &clientErr))
+	assert.Equal(t, 25, clientErr.Code)
+	assert.Equal(t, "Unknown function: net.unknown", clientErr.Message)
+	assert.JSONEq(t, `{"core_version":"1.47.0"}`, string(clientErr.Data))
+
+	response = newResponse([]byte(`not json`), 1)
+	assert.True(t, errors.As(response.Error, &clientErr))
+	assert.Equal(t, "not json", clientErr.Message)
+
+	response = newResponse([]byte(`{}`), 0)
+	assert.Nil(t, response.Error)
+	assert.Equal(t, []byte(`{}`), response.Data)
+}
+
+type appObjectTransport struct {
+	resolved chan []byte
+}
+
+func (a *appObjectTransport) CreateContext([]byte) ([]byte, error) {
+	return []byte(`{"result":7}`), nil
+}
+
+func (a *appObjectTransport) DestroyContext(uint32) {}
+
+func (a *appObjectTransport) Request(context uint32, method string, paramsJSON []byte, handler ResponseHandler) error {
+	if context != 7 {
+		return fmt.Errorf("unexpected context %d", context)
+	}
+
+	switch method {
+	case "test.app_object":
+		go func() {
+			handler([]byte(`{"handle":1}`), 0, false)
+			handler([]byte(`{"app_request_id":5,"request_data":{"type":"GetPublicKey"}}`), 3, false)
+			<-a.resolved
+			handler([]byte(`{"type":"Log","msg":"resolved"}`), 4, false)
+			handler(nil, 2, true)
+		}()
+	case "client.resolve_app_request":
+		a.resolved <- paramsJSON
+		go handler(nil, 2, true)
+	default:
+		go handler([]byte(`{"code":25,"message":"Unknown function"}`), 1, true)
+	}
+
+	return nil
+}
+
+func TestRemoteTransport(t *testing.T) {
+	transport := &appObjectTransport{resolved: make(chan []byte, 1)}
+	server := httptest.NewServer(NewRemoteHandler(transport))
+	defer server.Close()
+
+	clientConn, err := NewClientGateway(domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), ""),
+		WithTransport(NewRemoteTransport(server.URL, nil)))
+	assert.Equal(t, nil, err)
+	defer clientConn.Destroy()
+
+	t.Run("TestAppObject", func(t *testing.T) {
+		stream, err := clientConn.Request("test.app_object", nil)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, nil, stream.Result(nil))
+
+		for appRequest := range stream.AppRequests() {
+			err := clientConn.ResolveAppRequest(&domain.ParamsOfResolveAppRequest{
+				AppRequestID: appRequest.AppRequestID,
+				Result:       domain.NewAppRequestResult(domain.AppRequestResultOk{Result: json.RawMessage(`{"public_key":"00"}`)}),
+			})
+			assert.Equal(t, nil, err)
+		}
+		_, isNotified := <-stream.AppNotifications()
+		assert.True(t, isNotified)
+		assert.Equal(t, nil, stream.Err())
+	})
+
+	t.Run("TestError", func(t *testing.T) {
+		_, err := clientConn.GetResponse("net.unknown", nil)
+		assert.True(t, errors.Is(err, ErrUnknownFunction))
+	})
+
+	t.Run("TestBrokenSidecar", func(t *testing.T) {
+		broken := NewRemoteTransport(server.URL+"/missing", &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		})})
+		_, err := NewClientGateway(domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), ""), WithTransport(broken))
+		assert.NotEqual(t, nil, err)
+	})
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestInterceptors(t *testing.T) {
+	var (
+		calls    []string
+		codes    []uint32
+		finalErr = make(chan error, 1)
+	)
+	logging := func(ctx context.Context, method string, params []byte, invoker Invoker) (<-chan *domain.ClientResponse, error) {
+		calls = append(calls, "logging "+method+" "+string(params))
+		responses, err := invoker(ctx, method, params)
+		if err != nil {
+			return nil, err
+		}
+		return TapResponses(ctx, responses, func(r *domain.ClientResponse) {
+			codes = append(codes, r.Code)
+		}, func(err error) {
+			finalErr <- err
+		}), nil
+	}
+	faultInjection := func(ctx context.Context, method string, params []byte, invoker Invoker) (<-chan *domain.ClientResponse, error) {
+		calls = append(calls, "fault "+method)
+		if method == "net.query" {
+			return nil, errors.New("injected")
+		}
+		return invoker(ctx, method, params)
+	}
+
+	clientConn, err := NewClientGateway(domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), ""),
+		WithTransport(&appObjectTransport{resolved: make(chan []byte, 1)}), WithInterceptors(logging, faultInjection))
+	assert.Equal(t, nil, err)
+	defer clientConn.Destroy()
+
+	_, err = clientConn.GetResponse("net.query", &domain.ParamsOfQuery{Query: "{info{version}}"})
+	assert.EqualError(t, err, "injected")
+	assert.Equal(t, []string{`logging net.query {"query":"{info{version}}"}`, "fault net.query"}, calls)
+
+	calls = nil
+	_, err = clientConn.GetResponse("net.unknown", nil)
+	assert.True(t, errors.Is(err, ErrUnknownFunction))
+	assert.True(t, errors.Is(<-finalErr, ErrUnknownFunction))
+	assert.Equal(t, []string{"logging net.unknown ", "fault net.unknown"}, calls)
+	assert.Equal(t, []uint32{1}, codes)
+}
+
+// handlesTransport opens subscriptions, iterators and monitor queues, streams of subscriptions are finished
+// by net.unsubscribe. Methods are recorded in order of calls.
+type handlesTransport struct {
+	sync.Mutex
+	methods       []string
+	subscriptions map[string]ResponseHandler
+	destroyed     bool
+}
+
+func (h *handlesTransport) CreateContext([]byte) ([]byte, error) {
+	return []byte(`{"result":1}`), nil
+}
+
+func (h *handlesTransport) DestroyContext(uint32) {
+	h.Lock()
+	defer h.Unlock()
+	h.destroyed = true
+}
+
+func (h *handlesTransport) Request(_ uint32, method string, paramsJSON []byte, handler ResponseHandler) error {
+	h.Lock()
+	defer h.Unlock()
+	h.methods = append(h.methods, method+" "+string(paramsJSON))
+
+	switch method {
+	case "net.subscribe_collection":
+		handle := fmt.Sprint(len(h.subscriptions) + 1)
+		h.subscriptions[handle] = handler
+		go handler([]byte(`{"handle":`+handle+`}`), 0, false)
+	case "net.unsubscribe":
+		var params domain.ResultOfSubscribeCollection
+		_ = json.Unmarshal(paramsJSON, &params)
+		subscription := h.subscriptions[fmt.Sprint(params.Handle)]
+		go func() {
+			subscription(nil, 2, true)
+			handler([]byte(`{}`), 0, true)
+		}()
+	case "net.create_block_iterator":
+		go handler([]byte(`{"handle":10}`), 0, true)
+	case "test.hang":
+	default:
+		go handler([]byte(`{}`), 0, true)
+	}
+
+	return nil
+}
+
+// forgettingHandlesTransport - handlesTransport which counts handlers forgotten by the gateway.
+type forgettingHandlesTransport struct {
+	handlesTransport
+	forgotten int32
+}
+
+func (f *forgettingHandlesTransport) requestForget(context uint32, method string, paramsJSON []byte, handler ResponseHandler) (func(), error) {
+	if err := f.Request(context, method, paramsJSON, handler); err != nil {
+		return nil, err
+	}
+
+	return func() { atomic.AddInt32(&f.forgotten, 1) }, nil
+}
+
+func (h *handlesTransport) calls() []string {
+	h.Lock()
+	defer h.Unlock()
+
+	return append([]string(nil), h.methods...)
+}
+
+func TestClose(t *testing.T) {
+	config := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), "")
+
+	t.Run("TestReleaseHandles", func(t *testing.T) {
+		transport := &handlesTransport{subscriptions: make(map[string]ResponseHandler)}
+		clientConn, err := NewClientGateway(config, WithTransport(transport))
+		assert.Equal(t, nil, err)
+
+		subscription, err := clientConn.Request("net.subscribe_collection", &domain.ParamsOfSubscribeCollection{Collection: "blocks", Result: "id"})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, nil, subscription.Result(nil))
+		unsubscribed, err := clientConn.Request("net.subscribe_collection", &domain.ParamsOfSubscribeCollection{Collection: "messages", Result: "id"})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, nil, unsubscribed.Result(nil))
+		_, err = clientConn.GetResponse("net.unsubscribe", &domain.ResultOfSubscribeCollection{Handle: 2})
+		assert.Equal(t, nil, err)
+		_, err = unsubscribed.Next(context.Background())
+		assert.Equal(t, io.EOF, err)
+		_, err = clientConn.GetResponse("processing.monitor_messages", &domain.ParamsOfMonitorMessages{Queue: "q1"})
+		assert.Equal(t, nil, err)
+		_, err = clientConn.GetResponse("net.create_block_iterator", &domain.ParamsOfCreateBlockIterator{})
+		assert.Equal(t, nil, err)
+
+		before := len(transport.calls())
+		assert.Equal(t, nil, clientConn.Close(context.Background()))
+		assert.Equal(t, []string{
+			`net.unsubscribe {"handle":1}`,
+			`net.remove_iterator {"handle":10}`,
+			`processing.cancel_monitor {"queue":"q1"}`,
+		}, transport.calls()[before:])
+
+		_, err = subscription.Next(context.Background())
+		assert.Equal(t, io.EOF, err)
+		assert.True(t, transport.destroyed)
+
+		_, err = clientConn.GetResponse("client.version", nil)
+		assert.True(t, errors.Is(err, domain.ErrClientClosed))
+		assert.Equal(t, nil, clientConn.Close(context.Background()))
+	})
+
+	t.Run("TestDeadline", func(t *testing.T) {
+		transport := &handlesTransport{subscriptions: make(map[string]ResponseHandler)}
+		clientConn, err := NewClientGateway(config, WithTransport(transport))
+		assert.Equal(t, nil, err)
+
+		result := make(chan error, 1)
+		go func() {
+			_, err := clientConn.GetResponse("test.hang", nil)
+			result <- err
+		}()
+		assert.Eventually(t, func() bool { return len(transport.calls()) == 1 }, time.Second, time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.True(t, errors.Is(clientConn.Close(ctx), context.DeadlineExceeded))
+		assert.True(t, errors.Is(<-result, domain.ErrClientClosed))
+		assert.True(t, transport.destroyed)
+	})
+}
+
+func TestHandleRegistry(t *testing.T) {
+	registry := newHandleRegistry()
+	registry.opened("boc.cache_set", []byte(`{"boc":"te6c","cache_type":{"type":"Pinned","pin":"p1"}}`), []byte(`{"boc_ref":"*1"}`), "")
+	registry.opened("boc.cache_set", []byte(`{"boc":"te6c","cache_type":{"type":"Unpinned"}}`), []byte(`{"boc_ref":"*2"}`), "")
+	registry.opened("debot.init", []byte(`{"address":"0:1"}`), []byte(`{"debot_handle":3}`), "main.go:1")
+
+	handles := registry.list()
+	assert.Equal(t, 2, len(handles))
+	assert.Equal(t, HandleBocPin, handles[0].Kind)
+	assert.Equal(t, `"p1"`, string(handles[0].Handle))
+	assert.Equal(t, "main.go:1", handles[1].CallSite)
+
+	registry.released("boc.cache_unpin", []byte(`{"pin":"p1"}`))
+	drained := registry.drain()
+	assert.Equal(t, 1, len(drained))
+	assert.Equal(t, "debot.remove", drained[0].release)
+	assert.Equal(t, `{"debot_handle":3}`, string(drained[0].params))
+	assert.Equal(t, 0, len(registry.list()))
+}
+
+// versionTransport - loaded library of version.
+type versionTransport struct {
+	version   string
+	destroyed bool
+}
+
+func (v *versionTransport) Library() string {
+	return "/opt/ever/libton_client.so"
+}
+
+func (v *versionTransport) CreateContext([]byte) ([]byte, error) {
+	return []byte(`{"result":1}`), nil
+}
+
+func (v *versionTransport) DestroyContext(uint32) {
+	v.destroyed = true
+}
+
+func (v *versionTransport) Request(_ uint32, method string, _ []byte, handler ResponseHandler) error {
+	go handler([]byte(`{"version":"`+v.version+`"}`), 0, true)
+	return nil
+}
+
+func TestVersionPolicy(t *testing.T) {
+	config := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), "")
+
+	t.Run("TestRefuse", func(t *testing.T) {
+		transport := &versionTransport{version: "1.45.0"}
+		_, err := NewClientGateway(config, WithTransport(transport))
+		var versionErr *LibraryVersionError
+		assert.True(t, errors.As(err, &versionErr))
+		assert.Equal(t, "libton_client 1.45.0 at /opt/ever/libton_client.so doesn't match version "+VersionLibSDK+
+			" of the binding", err.Error())
+		assert.True(t, transport.destroyed)
+	})
+
+	t.Run("TestSameMinor", func(t *testing.T) {
+		transport := &versionTransport{version: VersionLibSDK[:strings.LastIndex(VersionLibSDK, ".")] + ".99"}
+		clientConn, err := NewClientGateway(config, WithTransport(transport))
+		assert.Equal(t, nil, err)
+		clientConn.Destroy()
+	})
+
+	t.Run("TestWarn", func(t *testing.T) {
+		clientConn, err := NewClientGateway(config, WithTransport(&versionTransport{version: "2.0.0"}),
+			WithVersionPolicy(VersionWarn))
+		assert.Equal(t, nil, err)
+		clientConn.Destroy()
+	})
+
+	t.Run("TestLocateLibrary", func(t *testing.T) {
+		assert.Equal(t, "/lib/a.so", LocateLibrary(domain.ClientConfig{LibraryPath: "/lib/a.so"}))
+		assert.Nil(t, os.Setenv(LibraryEnv, "/lib/b.so"))
+		defer os.Unsetenv(LibraryEnv)
+		assert.Equal(t, "/lib/b.so", LocateLibrary(domain.ClientConfig{}))
+	})
+}
+
+// flakyTransport fails the first failures calls of every function with code, the next ones succeed.
+type flakyTransport struct {
+	sync.Mutex
+	code     int
+	failures int
+	methods  []string
+}
+
+func (f *flakyTransport) CreateContext([]byte) ([]byte, error) {
+	return []byte(`{"result":1}`), nil
+}
+
+func (f *flakyTransport) DestroyContext(uint32) {}
+
+func (f *flakyTransport) Request(_ uint32, method string, _ []byte, handler ResponseHandler) error {
+	f.Lock()
+	defer f.Unlock()
+	f.methods = append(f.methods, method)
+
+	if len(f.methods) <= f.failures {
+		go handler([]byte(fmt.Sprintf(`{"code":%d,"message":"transient"}`, f.code)), domain.ResponseError, true)
+		return nil
+	}
+	go handler([]byte(`{"result":{}}`), domain.ResponseSuccess, true)
+
+	return nil
+}
+
+func (f *flakyTransport) calls() int {
+	f.Lock()
+	defer f.Unlock()
+
+	return len(f.methods)
+}
+
+func TestRetryPolicy(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		RetryableCodes: []int{domain.NetErrorCode["WebsocketDisconnected"], domain.NetErrorCode["GraphqlConnectionError"]},
+	}
+	newClient := func(t *testing.T, code, failures int, opts ...Option) (domain.ClientGateway, *flakyTransport) {
+		transport := &flakyTransport{code: code, failures: failures}
+		clientConn, err := NewClientGateway(domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), ""),
+			append([]Option{WithTransport(transport)}, opts...)...)
+		assert.Equal(t, nil, err)
+		return clientConn, transport
+	}
+	query := &domain.ParamsOfQuery{Query: "{info{version}}"}
+
+	t.Run("TestTransient", func(t *testing.T) {
+		clientConn, transport := newClient(t, domain.NetErrorCode["GraphqlConnectionError"], 2, WithRetryPolicy("net", policy))
+		defer clientConn.Destroy()
+
+		_, err := clientConn.GetResponse("net.query", query)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 3, transport.calls())
+	})
+
+	t.Run("TestAttemptsOver", func(t *testing.T) {
+		clientConn, transport := newClient(t, domain.NetErrorCode["WebsocketDisconnected"], 5, WithRetryPolicy("net", policy))
+		defer clientConn.Destroy()
+
+		_, err := clientConn.GetResponse("net.query", query)
+		assert.True(t, errors.Is(err, domain.NewClientError(domain.NetErrorCode, "WebsocketDisconnected")))
+		assert.Equal(t, 3, transport.calls())
+	})
+
+	t.Run("TestNotRetryable", func(t *testing.T) {
+		clientConn, transport := newClient(t, domain.NetErrorCode["GraphqlError"], 5, WithRetryPolicy("net", policy))
+		defer clientConn.Destroy()
+
+		_, err := clientConn.GetResponse("net.query", query)
+		assert.True(t, errors.Is(err, domain.NewClientError(domain.NetErrorCode, "GraphqlError")))
+		assert.Equal(t, 1, transport.calls())
+	})
+
+	t.Run("TestFunctionPolicy", func(t *testing.T) {
+		clientConn, transport := newClient(t, domain.NetErrorCode["GraphqlConnectionError"], 5,
+			WithRetryPolicy("net", policy), WithRetryPolicy("net.query", RetryPolicy{MaxAttempts: 1}))
+		defer clientConn.Destroy()
+
+		_, err := clientConn.GetResponse("net.query", query)
+		assert.NotEqual(t, nil, err)
+		assert.Equal(t, 1, transport.calls())
+	})
+
+	t.Run("TestNonIdempotent", func(t *testing.T) {
+		clientConn, transport := newClient(t, domain.NetErrorCode["GraphqlConnectionError"], 1, WithRetryPolicy("processing", policy))
+		defer clientConn.Destroy()
+
+		_, err := clientConn.GetResponse("processing.send_message", map[string]string{"message": "te6"})
+		assert.NotEqual(t, nil, err)
+		assert.Equal(t, 1, transport.calls())
+
+		explicit := policy
+		explicit.NonIdempotent = true
+		clientConn, transport = newClient(t, domain.NetErrorCode["GraphqlConnectionError"], 1, WithRetryPolicy("processing", explicit))
+		defer clientConn.Destroy()
+
+		_, err = clientConn.GetResponse("processing.send_message", map[string]string{"message": "te6"})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 2, transport.calls())
+	})
+
+	t.Run("TestDeadline", func(t *testing.T) {
+		slow := policy
+		slow.InitialBackoff = time.Second
+		clientConn, transport := newClient(t, domain.NetErrorCode["GraphqlConnectionError"], 5, WithRetryPolicy("net", slow))
+		defer clientConn.Destroy()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		started := time.Now()
+		_, err := clientConn.GetResponseContext(ctx, "net.query", query)
+		assert.True(t, errors.Is(err, domain.NewClientError(domain.NetErrorCode, "GraphqlConnectionError")))
+		assert.True(t, time.Since(started) < 100*time.Millisecond)
+		assert.Equal(t, 1, transport.calls())
+	})
+
+	t.Run("TestStoppedDuringBackoff", func(t *testing.T) {
+		slow := policy
+		slow.InitialBackoff = time.Minute
+		clientConn, transport := newClient(t, domain.NetErrorCode["GraphqlConnectionError"], 5, WithRetryPolicy("net", slow))
+
+		stream, err := clientConn.Request("net.query", query)
+		assert.Equal(t, nil, err)
+		for transport.calls() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		time.Sleep(10 * time.Millisecond)
+		clientConn.Destroy()
+		assert.True(t, errors.Is(stream.Result(&struct{}{}), domain.ErrClientClosed))
+		assert.Equal(t, 1, transport.calls())
+	})
+
+	t.Run("TestFamilies", func(t *testing.T) {
+		assert.Equal(t, []string{"net.query", "net"}, familiesOf("net.query"))
+		assert.Equal(t, []string{"net"}, familiesOf("net"))
+	})
+
+	t.Run("TestBackoff", func(t *testing.T) {
+		backoff := RetryPolicy{InitialBackoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond}
+		var delays []time.Duration
+		for attempt := 1; attempt <= 4; attempt++ {
+			delays = append(delays, backoff.backoff(attempt))
+		}
+		assert.Equal(t, []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 50 * time.Millisecond}, delays)
+
+		backoff.Jitter = 0.5
+		for i := 0; i < 100; i++ {
+			delay := backoff.backoff(1)
+			assert.True(t, delay > 5*time.Millisecond && delay <= 10*time.Millisecond)
+		}
+	})
+}
+
+// recordLogger keeps records formatted by the standard logger.
+type recordLogger struct {
+	sync.Mutex
+	records []string
+}
+
+func (r *recordLogger) Log(level Level, msg string, fields ...Field) {
+	buf := &strings.Builder{}
+	NewStdLogger(log.New(buf, "", 0), LevelDebug).Log(level, msg, fields...)
+
+	r.Lock()
+	defer r.Unlock()
+	r.records = append(r.records, strings.TrimSuffix(buf.String(), "\n"))
+}
+
+func (r *recordLogger) all() []string {
+	r.Lock()
+	defer r.Unlock()
+
+	return append([]string(nil), r.records...)
+}
+
+// contextErrorTransport fails to create context.
+type contextErrorTransport struct {
+	flakyTransport
+}
+
+func (c *contextErrorTransport) CreateContext([]byte) ([]byte, error) {
+	return []byte(`{"error":{"code":23,"message":"Invalid config"}}`), nil
+}
+
+func TestLogger(t *testing.T) {
+	t.Run("TestRedactParams", func(t *testing.T) {
+		for _, params := range []interface{}{
+			&domain.ParamsOfSign{Unsigned: "dGVzdA==", Keys: &domain.KeyPair{Public: "pub", Secret: "SECRET"}},
+			&domain.ParamsOfMnemonicDeriveSignKeys{Phrase: "SECRET words"},
+			&domain.ParamsOfNaclSecretBox{Decrypted: "dGVzdA==", Nonce: "00", Key: "SECRET"},
+			&domain.ParamsOfScrypt{Password: "SECRET", Salt: "salt"},
+			&domain.ParamsOfHDKeyDeriveFromXPrv{Xprv: "SECRET", ChildIndex: 1},
+			map[string]interface{}{"secret": map[string]string{"type": "PredefinedSeedPhrase", "phrase": "SECRET"}},
+			map[string]interface{}{"secret": map[string]string{"type": "EncryptedSecret", "encrypted_secret": "SECRET"}},
+			map[string]interface{}{"app_request_id": 1, "result": map[string]interface{}{
+				"type": "Ok", "result": map[string]string{"type": "GetPassword", "encrypted_password": "SECRET"},
+			}},
+		} {
+			raw, err := json.Marshal(params)
+			assert.Equal(t, nil, err)
+			redacted := string(RedactParams(raw))
+			assert.NotContains(t, redacted, "SECRET")
+			assert.Contains(t, redacted, `"[REDACTED]"`)
+		}
+
+		assert.JSONEq(t, `{"keys":{"public":"pub","secret":"[REDACTED]"},"abi":{"key":1}}`,
+			string(RedactParams([]byte(`{"keys":{"public":"pub","secret":"s"},"abi":{"key":1}}`))))
+		assert.Equal(t, `"[REDACTED]"`, string(RedactParams([]byte(`secret`))))
+		assert.Nil(t, RedactParams(nil))
+	})
+
+	t.Run("TestCalls", func(t *testing.T) {
+		logger := &recordLogger{}
+		transport := &flakyTransport{code: domain.NetErrorCode["GraphqlConnectionError"], failures: 1}
+		clientConn, err := NewClientGateway(domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), ""),
+			WithTransport(transport), WithLogger(logger))
+		assert.Equal(t, nil, err)
+		defer clientConn.Destroy()
+
+		_, err = clientConn.GetResponse("net.query", &domain.ParamsOfQuery{Query: "{info{version}}"})
+		assert.NotEqual(t, nil, err)
+		_, err = clientConn.GetResponse("crypto.nacl_secret_box", &domain.ParamsOfNaclSecretBox{Decrypted: "dGVzdA==", Nonce: "00", Key: "SECRET"})
+		assert.Equal(t, nil, err)
+
+		records := logger.all()
+		if assert.Len(t, records, 4) {
+			assert.True(t, strings.HasPrefix(records[0], `ever-client-go: level=debug msg=call method=net.query request_id=`))
+			assert.Contains(t, records[1], "level=warn msg=\"call failed\" method=net.query")
+			assert.Contains(t, records[1], " code=617 ")
+			assert.Contains(t, records[2], `params="{\"decrypted\":\"dGVzdA==\",\"key\":\"[REDACTED]\",\"nonce\":\"00\"}"`)
+			assert.Regexp(t, `level=debug msg=result method=crypto.nacl_secret_box request_id=\d+ duration=\S+$`, records[3])
+		}
+		for _, record := range records {
+			assert.NotContains(t, record, "SECRET")
+		}
+	})
+
+	t.Run("TestContextError", func(t *testing.T) {
+		logger := &recordLogger{}
+		clientConn, err := NewClientGateway(domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), ""),
+			WithTransport(&contextErrorTransport{}), WithLogger(logger))
+		assert.Nil(t, clientConn)
+		assert.Equal(t, 23, domain.ErrorCode(err))
+		assert.Equal(t, []string{
+			`ever-client-go: level=error msg="context is not created" code=23 error="Invalid config (code: 23)"`,
+		}, logger.all())
+	})
+}
+
+func TestBackpressure(t *testing.T) {
+	var hooked uint64
+	transport := &handlesTransport{subscriptions: make(map[string]ResponseHandler)}
+	clientConn, err := NewClientGateway(domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), ""),
+		WithTransport(transport), WithBackpressure("net", domain.Backpressure{
+			Size:   2,
+			Policy: domain.OverflowDropOldest,
+			OnDrop: func(*domain.ClientResponse) { atomic.AddUint64(&hooked, 1) },
+		}))
+	assert.Equal(t, nil, err)
+	defer clientConn.Destroy()
+
+	stream, err := clientConn.Request("net.subscribe_collection", nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, stream.Result(nil))
+	transport.Lock()
+	subscription := transport.subscriptions["1"]
+	transport.Unlock()
+
+	for i := 0; i < 100; i++ {
+		subscription([]byte(fmt.Sprintf(`{"result":%d}`, i)), domain.ResponseCustom, false)
+	}
+
+	var events []string
+	for event := range stream.Events() {
+		events = append(events, string(event))
+		if string(event) == `{"result":99}` {
+			break
+		}
+	}
+	assert.True(t, len(events) <= 10)
+	dropped := uint64(100 - len(events))
+	assert.Equal(t, dropped, stream.Dropped())
+	assert.Equal(t, dropped, atomic.LoadUint64(&hooked))
+	assert.Equal(t, map[string]uint64{"net.subscribe_collection": dropped}, clientConn.(domain.DropTracker).DroppedResponses())
+	assert.Same(t, stream, stream.Buffer())
+}
+
+func TestAffinity(t *testing.T) {
+	t.Run("TestSigningBoxOfAppRequest", func(t *testing.T) {
+		affinity := NewAffinity()
+		affinity.Track(0, "crypto.get_signing_box", nil, []byte(`{"handle":1}`))
+		own := affinity.Track(1, "crypto.get_signing_box", nil, []byte(`{"handle":1}`))
+		other := affinity.Track(0, "crypto.get_signing_box", nil, []byte(`{"handle":2}`))
+		request := &domain.ParamsOfAppRequest{}
+		assert.NoError(t, json.Unmarshal(affinity.TrackAppRequest(1, []byte(`{"app_request_id":9,"request_data":{}}`)), request))
+
+		var ownBox, otherBox domain.RegisteredSigningBox
+		assert.NoError(t, json.Unmarshal(own, &ownBox))
+		assert.NoError(t, json.Unmarshal(other, &otherBox))
+		result := fmt.Sprintf(`{"type":"GetSigningBox","signing_box":%d,"signer":{"type":"SigningBox","handle":%d}}`,
+			ownBox.Handle, otherBox.Handle)
+		owner, resolved := affinity.ResolveOwner(&domain.ParamsOfResolveAppRequest{
+			AppRequestID: request.AppRequestID,
+			Result:       &domain.AppRequestResult{ValueEnumType: domain.AppRequestResultOk{Result: json.RawMessage(result)}},
+		})
+		assert.Equal(t, 1, owner)
+		assert.Equal(t, 9, resolved.AppRequestID)
+		assert.JSONEq(t, fmt.Sprintf(`{"type":"GetSigningBox","signing_box":1,"signer":{"type":"SigningBox","handle":%d}}`, otherBox.Handle),
+			string(resolved.Result.ValueEnumType.(domain.AppRequestResultOk).Result))
+	})
+}
@@ -0,0 +1,43 @@
+package client
+
+import "github.com/markgenuine/ever-client-go/domain"
+
+// Errors of client module returned by SDK, use errors.Is to check them.
+var (
+	ErrNotImplemented                      = domain.NewClientError(domain.ClientErrorCode, "NotImplemented")
+	ErrInvalidHex                          = domain.NewClientError(domain.ClientErrorCode, "InvalidHex")
+	ErrInvalidBase64                       = domain.NewClientError(domain.ClientErrorCode, "InvalidBase64")
+	ErrInvalidAddress                      = domain.NewClientError(domain.ClientErrorCode, "InvalidAddress")
+	ErrCallbackParamsCantBeConvertedToJson = domain.NewClientError(domain.ClientErrorCode, "CallbackParamsCantBeConvertedToJson")
+	ErrWebsocketConnectError               = domain.NewClientError(domain.ClientErrorCode, "WebsocketConnectError")
+	ErrWebsocketReceiveError               = domain.NewClientError(domain.ClientErrorCode, "WebsocketReceiveError")
+	ErrWebsocketSendError                  = domain.NewClientError(domain.ClientErrorCode, "WebsocketSendError")
+	ErrHttpClientCreateError               = domain.NewClientError(domain.ClientErrorCode, "HttpClientCreateError")
+	ErrHttpRequestCreateError              = domain.NewClientError(domain.ClientErrorCode, "HttpRequestCreateError")
+	ErrHttpRequestSendError                = domain.NewClientError(domain.ClientErrorCode, "HttpRequestSendError")
+	ErrHttpRequestParseError               = domain.NewClientError(domain.ClientErrorCode, "HttpRequestParseError")
+	ErrCallbackNotRegistered               = domain.NewClientError(domain.ClientErrorCode, "CallbackNotRegistered")
+	ErrNetModuleNotInit                    = domain.NewClientError(domain.ClientErrorCode, "NetModuleNotInit")
+	ErrInvalidConfig                       = domain.NewClientError(domain.ClientErrorCode, "InvalidConfig")
+	ErrCannotCreateRuntime                 = domain.NewClientError(domain.ClientErrorCode, "CannotCreateRuntime")
+	ErrInvalidContextHandle                = domain.NewClientError(domain.ClientErrorCode, "InvalidContextHandle")
+	ErrCannotSerializeResult               = domain.NewClientError(domain.ClientErrorCode, "CannotSerializeResult")
+	ErrCannotSerializeError                = domain.NewClientError(domain.ClientErrorCode, "CannotSerializeError")
+	ErrCannotConvertJsValueToJson          = domain.NewClientError(domain.ClientErrorCode, "CannotConvertJsValueToJson")
+	ErrCannotReceiveSpawnedResult          = domain.NewClientError(domain.ClientErrorCode, "CannotReceiveSpawnedResult")
+	ErrSetTimerError                       = domain.NewClientError(domain.ClientErrorCode, "SetTimerError")
+	ErrInvalidParams                       = domain.NewClientError(domain.ClientErrorCode, "InvalidParams")
+	ErrContractsAddressConversionFailed    = domain.NewClientError(domain.ClientErrorCode, "ContractsAddressConversionFailed")
+	ErrUnknownFunction                     = domain.NewClientError(domain.ClientErrorCode, "UnknownFunction")
+	ErrAppRequestError                     = domain.NewClientError(domain.ClientErrorCode, "AppRequestError")
+	ErrNoSuchRequest                       = domain.NewClientError(domain.ClientErrorCode, "NoSuchRequest")
+	ErrCanNotSendRequestResult             = domain.NewClientError(domain.ClientErrorCode, "CanNotSendRequestResult")
+	ErrCanNotReceiveRequestResult          = domain.NewClientError(domain.ClientErrorCode, "CanNotReceiveRequestResult")
+	ErrCanNotParseRequestResult            = domain.NewClientError(domain.ClientErrorCode, "CanNotParseRequestResult")
+	ErrUnexpectedCallbackResponse          = domain.NewClientError(domain.ClientErrorCode, "UnexpectedCallbackResponse")
+	ErrCanNotParseNumber                   = domain.NewClientError(domain.ClientErrorCode, "CanNotParseNumber")
+	ErrInternalError                       = domain.NewClientError(domain.ClientErrorCode, "InternalError")
+	ErrInvalidHandle                       = domain.NewClientError(domain.ClientErrorCode, "InvalidHandle")
+	ErrLocalStorageError                   = domain.NewClientError(domain.ClientErrorCode, "LocalStorageError")
+	ErrInvalidData                         = domain.NewClientError(domain.ClientErrorCode, "InvalidData")
+)
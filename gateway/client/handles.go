@@ -0,0 +1,313 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/markgenuine/ever-client-go/domain"
+)
+
+// Kinds of SDK handles in order of releasing on Close: subscriptions are stopped first, monitor queues last.
+const (
+	HandleSubscription  = "subscription"
+	HandleIterator      = "iterator"
+	HandleSigningBox    = "signing_box"
+	HandleEncryptionBox = "encryption_box"
+	HandleCryptoBox     = "crypto_box"
+	HandleDebot         = "debot"
+	HandleBocPin        = "boc_pin"
+	HandleMonitor       = "monitor"
+)
+
+// modulePath - prefix of functions of the binding, they are skipped when the call site of handle is looked for.
+const modulePath = "github.com/markgenuine/ever-client-go"
+
+var handleKinds = []string{
+	HandleSubscription,
+	HandleIterator,
+	HandleSigningBox,
+	HandleEncryptionBox,
+	HandleCryptoBox,
+	HandleDebot,
+	HandleBocPin,
+	HandleMonitor,
+}
+
+type (
+	// handleSpec - function which opens handle: where the handle is and which function releases it.
+	handleSpec struct {
+		kind       string
+		field      string
+		release    string
+		fromParams bool
+	}
+
+	// openHandle - handle which wasn't released yet, params are ready for the release function.
+	openHandle struct {
+		kind     string
+		method   string
+		release  string
+		handle   json.RawMessage
+		params   json.RawMessage
+		callSite string
+		opened   time.Time
+	}
+
+	handleRegistry struct {
+		sync.Mutex
+		handles map[string]*openHandle
+	}
+
+	handleTracking struct {
+		enabled bool
+		onLeak  func([]*domain.OpenHandle)
+	}
+
+	// pendingRequests - requests of the gateway which aren't finished yet.
+	pendingRequests struct {
+		sync.Mutex
+		ids  map[uint32]struct{}
+		idle chan struct{}
+	}
+)
+
+var handleOpeners = map[string]handleSpec{
+	"net.subscribe_collection":               {kind: HandleSubscription, field: "handle", release: "net.unsubscribe"},
+	"net.subscribe":                          {kind: HandleSubscription, field: "handle", release: "net.unsubscribe"},
+	"net.create_block_iterator":              {kind: HandleIterator, field: "handle", release: "net.remove_iterator"},
+	"net.resume_block_iterator":              {kind: HandleIterator, field: "handle", release: "net.remove_iterator"},
+	"net.create_transaction_iterator":        {kind: HandleIterator, field: "handle", release: "net.remove_iterator"},
+	"net.resume_transaction_iterator":        {kind: HandleIterator, field: "handle", release: "net.remove_iterator"},
+	"crypto.get_signing_box":                 {kind: HandleSigningBox, field: "handle", release: "crypto.remove_signing_box"},
+	"crypto.register_signing_box":            {kind: HandleSigningBox, field: "handle", release: "crypto.remove_signing_box"},
+	"crypto.get_signing_box_from_crypto_box": {kind: HandleSigningBox, field: "handle", release: "crypto.remove_signing_box"},
+	"crypto.register_encryption_box":         {kind: HandleEncryptionBox, field: "handle", release: "crypto.remove_encryption_box"},
+	"crypto.create_encryption_box":           {kind: HandleEncryptionBox, field: "handle", release: "crypto.remove_encryption_box"},
+	"crypto.get_encryption_box_from_crypto_box": {
+		kind: HandleEncryptionBox, field: "handle", release: "crypto.remove_encryption_box",
+	},
+	"crypto.create_crypto_box":    {kind: HandleCryptoBox, field: "handle", release: "crypto.remove_crypto_box"},
+	"debot.init":                  {kind: HandleDebot, field: "debot_handle", release: "debot.remove"},
+	"boc.cache_set":               {kind: HandleBocPin, field: "cache_type.pin", release: "boc.cache_unpin", fromParams: true},
+	"processing.monitor_messages": {kind: HandleMonitor, field: "queue", release: "processing.cancel_monitor", fromParams: true},
+}
+
+// handleReleasers - functions which release handles and the field with handle in their params.
+var handleReleasers = map[string]string{
+	"net.unsubscribe":              "handle",
+	"net.remove_iterator":          "handle",
+	"crypto.remove_signing_box":    "handle",
+	"crypto.remove_encryption_box": "handle",
+	"crypto.remove_crypto_box":     "handle",
+	"debot.remove":                 "debot_handle",
+	"boc.cache_unpin":              "pin",
+	"processing.cancel_monitor":    "queue",
+}
+
+// WithHandleTracking records the call site of every SDK handle opened through clientGateway, see OpenHandles.
+// Handles which are left open on Destroy are passed to onLeak, they are logged when onLeak is nil.
+func WithHandleTracking(onLeak func([]*domain.OpenHandle)) Option {
+	return func(c *clientGateway) {
+		c.tracking = handleTracking{enabled: true, onLeak: onLeak}
+	}
+}
+
+// callSite returns file:line of the first caller outside of the binding.
+func callSite() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, modulePath+".") &&
+			!strings.HasPrefix(frame.Function, modulePath+"/gateway/") &&
+			!strings.HasPrefix(frame.Function, modulePath+"/usecase/") &&
+			!strings.HasPrefix(frame.Function, modulePath+"/domain.") ||
+			strings.HasSuffix(frame.File, "_test.go") {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// reportLeaks logs handles which are left open.
+func (c *clientGateway) reportLeaks(handles []*domain.OpenHandle) {
+	for _, handle := range handles {
+		c.logger.Log(LevelWarn, "handle is not released", Field{"kind", handle.Kind}, Field{"handle", handle.Handle},
+			Field{"method", handle.Method}, Field{"call_site", handle.CallSite}, Field{"age", handle.Age().Round(time.Millisecond)})
+	}
+}
+
+func newHandleRegistry() *handleRegistry {
+	return &handleRegistry{handles: make(map[string]*openHandle)}
+}
+
+// handleField returns value of field of payload, path of nested field is separated by dots.
+func handleField(payload []byte, field string) (json.RawMessage, bool) {
+	value := json.RawMessage(payload)
+	for _, name := range strings.Split(field, ".") {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(value, &fields); err != nil {
+			return nil, false
+		}
+		var isFound bool
+		if value, isFound = fields[name]; !isFound {
+			return nil, false
+		}
+	}
+
+	return value, len(value) > 0 && string(value) != "null"
+}
+
+// opened registers handle from the successful result of method, callSite is empty without tracking.
+func (r *handleRegistry) opened(method string, params, result []byte, callSite string) {
+	spec, isFound := handleOpeners[method]
+	if !isFound {
+		return
+	}
+	source := result
+	if spec.fromParams {
+		source = params
+	}
+	value, isFound := handleField(source, spec.field)
+	if !isFound {
+		return
+	}
+
+	releaseParams, err := json.Marshal(map[string]json.RawMessage{handleReleasers[spec.release]: value})
+	if err != nil {
+		return
+	}
+	r.Lock()
+	defer r.Unlock()
+	key := spec.release + " " + string(value)
+	if _, isFound := r.handles[key]; isFound {
+		// BOCs pinned again with the same pin are unpinned at once.
+		return
+	}
+	r.handles[key] = &openHandle{
+		kind:     spec.kind,
+		method:   method,
+		release:  spec.release,
+		handle:   append(json.RawMessage(nil), value...),
+		params:   releaseParams,
+		callSite: callSite,
+		opened:   time.Now(),
+	}
+}
+
+// list returns handles which are open in order of opening.
+func (r *handleRegistry) list() []*domain.OpenHandle {
+	r.Lock()
+	defer r.Unlock()
+
+	handles := make([]*domain.OpenHandle, 0, len(r.handles))
+	for _, handle := range r.handles {
+		handles = append(handles, &domain.OpenHandle{
+			Kind:     handle.kind,
+			Method:   handle.method,
+			Handle:   handle.handle,
+			CallSite: handle.callSite,
+			Opened:   handle.opened,
+		})
+	}
+	sort.Slice(handles, func(i, j int) bool {
+		return handles[i].Opened.Before(handles[j].Opened)
+	})
+
+	return handles
+}
+
+// released forgets handle when method releases it.
+func (r *handleRegistry) released(method string, params []byte) {
+	field, isFound := handleReleasers[method]
+	if !isFound {
+		return
+	}
+	value, isFound := handleField(params, field)
+	if !isFound {
+		return
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	delete(r.handles, method+" "+string(value))
+}
+
+// drain forgets all handles and returns them in order of handleKinds.
+func (r *handleRegistry) drain() []*openHandle {
+	r.Lock()
+	defer r.Unlock()
+
+	drained := make([]*openHandle, 0, len(r.handles))
+	for _, kind := range handleKinds {
+		for key, handle := range r.handles {
+			if handle.kind == kind {
+				drained = append(drained, handle)
+				delete(r.handles, key)
+			}
+		}
+	}
+
+	return drained
+}
+
+func newPendingRequests() *pendingRequests {
+	return &pendingRequests{ids: make(map[uint32]struct{})}
+}
+
+func (p *pendingRequests) add(requestID uint32) {
+	p.Lock()
+	defer p.Unlock()
+	p.ids[requestID] = struct{}{}
+}
+
+func (p *pendingRequests) remove(requestID uint32) {
+	p.Lock()
+	defer p.Unlock()
+	delete(p.ids, requestID)
+	if len(p.ids) == 0 && p.idle != nil {
+		close(p.idle)
+		p.idle = nil
+	}
+}
+
+func (p *pendingRequests) list() []uint32 {
+	p.Lock()
+	defer p.Unlock()
+
+	ids := make([]uint32, 0, len(p.ids))
+	for id := range p.ids {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// wait returns when all requests are finished or ctx is done.
+func (p *pendingRequests) wait(ctx context.Context) error {
+	p.Lock()
+	if len(p.ids) == 0 {
+		p.Unlock()
+		return nil
+	}
+	if p.idle == nil {
+		p.idle = make(chan struct{})
+	}
+	idle := p.idle
+	p.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
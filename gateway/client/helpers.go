@@ -3,7 +3,6 @@ package client
 import (
 	"github.com/markgenuine/ever-client-go/domain"
 )
-import "C"
 
 type SDKResponse struct {
 	Result uint32              `json:"result"`
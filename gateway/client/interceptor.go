@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+
+	"github.com/markgenuine/ever-client-go/domain"
+)
+
+type (
+	// Invoker sends method with marshalled params to the core library and returns the stream of its responses.
+	Invoker func(ctx context.Context, method string, params []byte) (<-chan *domain.ClientResponse, error)
+
+	// Interceptor wraps every call of clientGateway: GetResult, GetResponse and Request go through it.
+	// It may change params, fail the call without invoker or wrap the returned stream, see TapResponses.
+	Interceptor func(ctx context.Context, method string, params []byte, invoker Invoker) (<-chan *domain.ClientResponse, error)
+)
+
+// WithInterceptors adds interceptors to clientGateway, the first one is the outermost.
+func WithInterceptors(interceptors ...Interceptor) Option {
+	return func(c *clientGateway) {
+		c.interceptors = append(c.interceptors, interceptors...)
+	}
+}
+
+// chainInterceptors builds invoker which passes call through interceptors to invoker.
+func chainInterceptors(interceptors []Interceptor, invoker Invoker) Invoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, method string, params []byte) (<-chan *domain.ClientResponse, error) {
+			return interceptor(ctx, method, params, next)
+		}
+	}
+
+	return invoker
+}
+
+// TapResponses returns stream with the same responses, every response is passed to onResponse before
+// it is delivered. When the stream ends onFinish gets the final error: the first error response of the core
+// library or ctx.Err() if the call was cancelled. Both callbacks may be nil.
+func TapResponses(ctx context.Context, responses <-chan *domain.ClientResponse, onResponse func(*domain.ClientResponse), onFinish func(error)) <-chan *domain.ClientResponse {
+	out := make(chan *domain.ClientResponse, 1)
+	go func() {
+		var err error
+		defer func() {
+			close(out)
+			if onFinish != nil {
+				onFinish(err)
+			}
+		}()
+
+		for {
+			select {
+			case r, ok := <-responses:
+				if !ok {
+					if err == nil {
+						err = ctx.Err()
+					}
+					return
+				}
+				if r.Error != nil && err == nil {
+					err = r.Error
+				}
+				if onResponse != nil {
+					onResponse(r)
+				}
+				select {
+				case out <- r:
+				case <-ctx.Done():
+					err = ctx.Err()
+					return
+				}
+			case <-ctx.Done():
+				err = ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/markgenuine/ever-client-go/domain"
+	"github.com/markgenuine/ever-client-go/gateway/libmanager"
+)
+
+// LibraryEnv - environment variable with path of libton_client, it is used when ClientConfig.LibraryPath is empty.
+const LibraryEnv = "EVER_CLIENT_LIBRARY"
+
+// VersionPolicy - what clientGateway does when version of the loaded libton_client differs from VersionLibSDK.
+type VersionPolicy int
+
+const (
+	// VersionRefuse fails NewClientGateway with LibraryVersionError.
+	VersionRefuse VersionPolicy = iota
+	// VersionWarn logs the mismatch and goes on.
+	VersionWarn
+	// VersionIgnore doesn't check version.
+	VersionIgnore
+)
+
+type (
+	// LibraryVersionError - major or minor version of the loaded libton_client differs from VersionLibSDK.
+	LibraryVersionError struct {
+		Library string
+		Binding string
+		Path    string
+	}
+
+	// libraryTransport - transport which loads libton_client itself, its version is checked on NewClientGateway.
+	libraryTransport interface {
+		Transport
+		Library() string
+	}
+)
+
+func (e *LibraryVersionError) Error() string {
+	return fmt.Sprintf("libton_client %s at %s doesn't match version %s of the binding", e.Library, e.Path, e.Binding)
+}
+
+// WithVersionPolicy sets policy of the version check of the loaded libton_client, VersionRefuse by default.
+func WithVersionPolicy(policy VersionPolicy) Option {
+	return func(c *clientGateway) {
+		c.versionPolicy = policy
+	}
+}
+
+// LibraryName returns file name of libton_client on the current platform.
+func LibraryName() string {
+	return libmanager.LibraryName(runtime.GOOS)
+}
+
+// LibraryCacheDir returns directory where libton_client of VersionLibSDK is installed by libmanager.
+func LibraryCacheDir() (string, error) {
+	return libmanager.CacheDir(VersionLibSDK)
+}
+
+// LocateLibrary returns path of libton_client: ClientConfig.LibraryPath, LibraryEnv, the file in LibraryCacheDir
+// when it exists, otherwise the bare LibraryName which is looked for by the system loader.
+func LocateLibrary(config domain.ClientConfig) string {
+	if config.LibraryPath != "" {
+		return config.LibraryPath
+	}
+	if path := os.Getenv(LibraryEnv); path != "" {
+		return path
+	}
+	if dir, err := LibraryCacheDir(); err == nil {
+		path := filepath.Join(dir, LibraryName())
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+
+	return LibraryName()
+}
+
+// sameMinor reports whether versions have the same major and minor parts.
+func sameMinor(v1, v2 string) bool {
+	parts1, parts2 := strings.SplitN(v1, ".", 3), strings.SplitN(v2, ".", 3)
+	if len(parts1) < 2 || len(parts2) < 2 {
+		return false
+	}
+
+	return parts1[0] == parts2[0] && parts1[1] == parts2[1]
+}
+
+// checkVersion asks the loaded library for its version and applies the version policy.
+func (c *clientGateway) checkVersion(transport libraryTransport) error {
+	if c.versionPolicy == VersionIgnore {
+		return nil
+	}
+
+	version, err := c.Version()
+	if err != nil {
+		return fmt.Errorf("version of libton_client at %s: %w", transport.Library(), err)
+	}
+	if sameMinor(version.Version, VersionLibSDK) {
+		return nil
+	}
+
+	err = &LibraryVersionError{Library: version.Version, Binding: VersionLibSDK, Path: transport.Library()}
+	if c.versionPolicy == VersionWarn {
+		c.logger.Log(LevelWarn, "library version differs", Field{"error", err})
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,205 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/markgenuine/ever-client-go/domain"
+)
+
+// Levels of records of Logger.
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// redacted replaces values of secret fields in logged params.
+const redacted = "[REDACTED]"
+
+type (
+	// Level - severity of record of Logger.
+	Level int
+
+	// Field - named value of record, e.g. method, request_id, duration or code.
+	Field struct {
+		Key   string
+		Value interface{}
+	}
+
+	// Logger receives records of clientGateway, see WithLogger. Params of calls are redacted before they are
+	// passed to it.
+	Logger interface {
+		Log(level Level, msg string, fields ...Field)
+	}
+
+	// stdLogger - Logger which writes records in logfmt to log.Logger, nil logger is the standard one.
+	stdLogger struct {
+		logger *log.Logger
+		level  Level
+	}
+)
+
+// secretFields - fields of params whose string values never appear in logs: secrets of keys and crypto boxes,
+// seed phrases, passwords, keys of ciphers and access keys.
+var secretFields = map[string]bool{
+	"secret":             true,
+	"phrase":             true,
+	"key":                true,
+	"password":           true,
+	"encrypted_password": true,
+	"encrypted_secret":   true,
+	"xprv":               true,
+	"entropy":            true,
+	"access_key":         true,
+}
+
+// defaultLogger writes warnings and errors to the standard logger.
+var defaultLogger Logger = NewStdLogger(nil, LevelWarn)
+
+// WithLogger sets logger of clientGateway. Every call is logged at LevelDebug with method, request_id and
+// redacted params, its result with duration or with code of error at LevelWarn. Without it only warnings and
+// errors of the gateway are written to the standard logger.
+func WithLogger(logger Logger) Option {
+	return func(c *clientGateway) {
+		c.logger = logger
+		c.traceCalls = true
+	}
+}
+
+// NewStdLogger returns Logger which writes records of level and above to logger in logfmt, nil logger is
+// the standard one.
+func NewStdLogger(logger *log.Logger, level Level) Logger {
+	return &stdLogger{logger: logger, level: level}
+}
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return strconv.Itoa(int(l))
+	}
+}
+
+func (s *stdLogger) Log(level Level, msg string, fields ...Field) {
+	if level < s.level {
+		return
+	}
+
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "ever-client-go: level=%s msg=%s", level, logfmtValue(msg))
+	for _, field := range fields {
+		fmt.Fprintf(b, " %s=%s", field.Key, logfmtValue(field.Value))
+	}
+	if s.logger == nil {
+		log.Print(b.String())
+		return
+	}
+	s.logger.Print(b.String())
+}
+
+// logfmtValue formats value of field, values with spaces, quotes or equal signs are quoted.
+func logfmtValue(value interface{}) string {
+	var text string
+	switch value := value.(type) {
+	case json.RawMessage:
+		text = string(value)
+	case time.Duration:
+		text = value.String()
+	default:
+		text = fmt.Sprint(value)
+	}
+	if text == "" || strings.ContainsAny(text, " \"=\n\t") {
+		return strconv.Quote(text)
+	}
+
+	return text
+}
+
+// RedactParams returns params with string values of secret fields replaced at any depth, e.g. KeyPair.Secret,
+// seed phrases, passwords and ParamsOfNaclSecretBox.Key. Params which aren't JSON are replaced entirely.
+func RedactParams(params []byte) json.RawMessage {
+	if len(params) == 0 {
+		return nil
+	}
+
+	var value interface{}
+	decoder := json.NewDecoder(strings.NewReader(string(params)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return json.RawMessage(strconv.Quote(redacted))
+	}
+	redact(value)
+	result, err := json.Marshal(value)
+	if err != nil {
+		return json.RawMessage(strconv.Quote(redacted))
+	}
+
+	return result
+}
+
+func redact(value interface{}) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if _, isString := field.(string); isString && secretFields[key] {
+				value[key] = redacted
+				continue
+			}
+			redact(field)
+		}
+	case []interface{}:
+		for _, item := range value {
+			redact(item)
+		}
+	}
+}
+
+// logCall logs the start of call with id of request.
+func (c *clientGateway) logCall(method string, requestID uint32, params []byte) {
+	c.logger.Log(LevelDebug, "call", Field{"method", method}, Field{"request_id", requestID},
+		Field{"params", RedactParams(params)})
+}
+
+// logResult logs the result of call, err is the error of SDK or the reason of abort.
+func (c *clientGateway) logResult(method string, requestID uint32, started time.Time, err error) {
+	fields := []Field{{"method", method}, {"request_id", requestID}, {"duration", time.Since(started)}}
+	if err == nil {
+		c.logger.Log(LevelDebug, "result", fields...)
+		return
+	}
+
+	if code := domain.ErrorCode(err); code != 0 {
+		fields = append(fields, Field{"code", code})
+	}
+	c.logger.Log(LevelWarn, "call failed", append(fields, Field{"error", err})...)
+}
+
+// traceResponses passes responses of call and logs its result: the first success or error response or the end
+// of stream without them.
+func (c *clientGateway) traceResponses(ctx context.Context, method string, requestID uint32, started time.Time, responses <-chan *domain.ClientResponse) <-chan *domain.ClientResponse {
+	logged := false
+	return TapResponses(ctx, responses, func(r *domain.ClientResponse) {
+		if !logged && (r.Code == domain.ResponseSuccess || r.Code == domain.ResponseError) {
+			logged = true
+			c.logResult(method, requestID, started, r.Error)
+		}
+	}, func(err error) {
+		if !logged {
+			c.logResult(method, requestID, started, err)
+		}
+	})
+}
@@ -1,61 +1,249 @@
 package client
 
 import (
+	"runtime"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/markgenuine/ever-client-go/domain"
 )
 
+// storeShards - count of shards of the store, a power of two.
+const storeShards = 32
+
 type Manager interface {
-	SetChannels(chan<- *domain.ClientResponse, <-chan struct{}) uint32
+	SetChannels(responses chan<- *domain.ClientResponse, close <-chan struct{}, timeout time.Duration) (uint32, <-chan struct{})
+	Send(requestID uint32, response *domain.ClientResponse, finished bool)
 	DeleteRequestID(uint32)
-	GetChannels(requestID uint32, delete bool) (chan<- *domain.ClientResponse, <-chan struct{}, bool)
+	Stats() StoreStats
 }
 
+// StoreStats - requests of the store, see domain.RequestStats.
+type StoreStats = domain.RequestStats
+
+// multiplexer passes responses of the core library to channels of requests. Requests are spread over shards by
+// their ID, so callers of different requests rarely wait for one lock.
 type multiplexer struct {
-	sync.Locker
+	expired          uint64
 	requestIDCounter uint32
-	callbacks        map[uint32]manageChan
+	shards           [storeShards]storeShard
+}
+
+type storeShard struct {
+	sync.Mutex
+	callbacks map[uint32]*manageChan
 }
 
+// NewStore returns store of requests of one gateway.
 func NewStore() Manager {
-	return &multiplexer{
-		Locker:    &sync.Mutex{},
-		callbacks: make(map[uint32]manageChan),
+	m := &multiplexer{}
+	for i := range m.shards {
+		m.shards[i].callbacks = make(map[uint32]*manageChan)
 	}
+
+	return m
 }
 
+// manageChan is a single request in the store. responsChan is closed exactly once,
+// either when the core library sends the finished response or when the request is deleted.
 type manageChan struct {
+	sync.Mutex
+	requestID   uint32
 	responsChan chan<- *domain.ClientResponse
 	close       <-chan struct{}
+	cancel      chan struct{}
+	cancelOnce  sync.Once
+	done        chan struct{}
+	isDone      bool
+	created     time.Time
+	timer       *time.Timer
 }
 
-func (m *multiplexer) GetChannels(requestID uint32, toDelete bool) (chan<- *domain.ClientResponse, <-chan struct{}, bool) {
-	m.Lock()
-	defer m.Unlock()
-	pair, isFound := m.callbacks[requestID]
-	if isFound && toDelete {
-		delete(m.callbacks, requestID)
-	}
+func (m *multiplexer) shard(requestID uint32) *storeShard {
+	return &m.shards[requestID&(storeShards-1)]
+}
+
+func (m *multiplexer) getChannels(requestID uint32) (*manageChan, bool) {
+	shard := m.shard(requestID)
+	shard.Lock()
+	defer shard.Unlock()
+	pair, isFound := shard.callbacks[requestID]
+
+	return pair, isFound
+}
 
-	return pair.responsChan, pair.close, isFound
+// finish must be called with pair locked.
+func (m *multiplexer) finish(pair *manageChan) {
+	if pair.isDone {
+		return
+	}
+	pair.isDone = true
+	if pair.timer != nil {
+		pair.timer.Stop()
+	}
+	shard := m.shard(pair.requestID)
+	shard.Lock()
+	delete(shard.callbacks, pair.requestID)
+	shard.Unlock()
+	close(pair.responsChan)
+	close(pair.done)
 }
 
-func (m *multiplexer) SetChannels(responses chan<- *domain.ClientResponse, close <-chan struct{}) uint32 {
-	m.Lock()
-	defer m.Unlock()
-	m.requestIDCounter++
-	requestID := m.requestIDCounter
-	m.callbacks[requestID] = manageChan{
+// SetChannels registers responses under a new request ID, IDs of live requests and 0 are never reused after the
+// counter wraps. With positive timeout the request ends with domain.ErrRequestTimeout when the core library
+// doesn't finish it in time. The returned channel is closed when the request leaves the store.
+func (m *multiplexer) SetChannels(responses chan<- *domain.ClientResponse, close <-chan struct{}, timeout time.Duration) (uint32, <-chan struct{}) {
+	pair := &manageChan{
 		responsChan: responses,
 		close:       close,
+		cancel:      make(chan struct{}),
+		done:        make(chan struct{}),
+		created:     time.Now(),
+	}
+	for attempt := 1; ; attempt++ {
+		requestID := atomic.AddUint32(&m.requestIDCounter, 1)
+		if requestID == 0 {
+			continue
+		}
+
+		shard := m.shard(requestID)
+		shard.Lock()
+		if _, isLive := shard.callbacks[requestID]; isLive {
+			shard.Unlock()
+			if attempt%storeShards == 0 {
+				runtime.Gosched()
+			}
+			continue
+		}
+		pair.requestID = requestID
+		shard.callbacks[requestID] = pair
+		shard.Unlock()
+		if timeout > 0 {
+			pair.Lock()
+			if !pair.isDone {
+				pair.timer = time.AfterFunc(timeout, func() { m.expire(pair) })
+			}
+			pair.Unlock()
+		}
+
+		return requestID, pair.done
+	}
+}
+
+// Send delivers response to the request channel, nil response only finishes the request.
+// Responses for unknown or deleted requests are dropped.
+func (m *multiplexer) Send(requestID uint32, response *domain.ClientResponse, finished bool) {
+	pair, isFound := m.getChannels(requestID)
+	if !isFound {
+		return
+	}
+
+	pair.Lock()
+	defer pair.Unlock()
+	if pair.isDone {
+		return
+	}
+
+	if response != nil && !m.deliver(pair, response) {
+		return
+	}
+
+	if finished {
+		m.finish(pair)
+	}
+}
+
+// deliver passes response to the request channel, it reports false when the request is deleted or the gateway is
+// closed meanwhile. It must be called with pair locked.
+func (m *multiplexer) deliver(pair *manageChan, response *domain.ClientResponse) bool {
+	select {
+	case pair.responsChan <- response:
+		return true
+	case <-pair.cancel:
+		return false
+	case <-pair.close:
+		m.finish(pair)
+		return false
+	}
+}
+
+// expire ends request whose timeout has passed with domain.ErrRequestTimeout. A pending Send for this request is
+// interrupted like by DeleteRequestID. The error is dropped when the reader doesn't keep up with the request, its
+// channel is closed anyway.
+func (m *multiplexer) expire(pair *manageChan) {
+	pair.cancelOnce.Do(func() { close(pair.cancel) })
+	pair.Lock()
+	defer pair.Unlock()
+	if pair.isDone {
+		return
 	}
 
-	return requestID
+	atomic.AddUint64(&m.expired, 1)
+	select {
+	case pair.responsChan <- &domain.ClientResponse{Code: domain.ResponseError, Error: domain.ErrRequestTimeout}:
+	default:
+	}
+	m.finish(pair)
 }
 
+// DeleteRequestID removes request from the store and closes its channel.
+// A pending Send for this request is interrupted.
 func (m *multiplexer) DeleteRequestID(requestID uint32) {
-	m.Lock()
-	defer m.Unlock()
-	delete(m.callbacks, requestID)
+	pair, isFound := m.getChannels(requestID)
+	if !isFound {
+		return
+	}
+
+	pair.cancelOnce.Do(func() { close(pair.cancel) })
+	pair.Lock()
+	defer pair.Unlock()
+	m.finish(pair)
+}
+
+// Stats returns count of live requests and age of the oldest of them.
+func (m *multiplexer) Stats() StoreStats {
+	stats := StoreStats{Expired: atomic.LoadUint64(&m.expired)}
+	now := time.Now()
+	for i := range m.shards {
+		shard := &m.shards[i]
+		shard.Lock()
+		stats.Live += len(shard.callbacks)
+		for _, pair := range shard.callbacks {
+			if age := now.Sub(pair.created); age > stats.Oldest {
+				stats.Oldest = age
+			}
+		}
+		shard.Unlock()
+	}
+
+	return stats
+}
+
+// WithRequestTimeout ends calls of a family of functions with domain.ErrRequestTimeout when the core library
+// doesn't finish them in timeout: a module, e.g. "net", or a function, e.g. "net.query". Timeout of the function
+// overrides timeout of its module. Subscriptions are finished only by unsubscribe, so they shouldn't get it.
+func WithRequestTimeout(family string, timeout time.Duration) Option {
+	return func(c *clientGateway) {
+		if c.requestTimeouts == nil {
+			c.requestTimeouts = make(map[string]time.Duration)
+		}
+		c.requestTimeouts[family] = timeout
+	}
+}
+
+// requestTimeout returns timeout of method, zero when it is not set.
+func (c *clientGateway) requestTimeout(method string) time.Duration {
+	for _, family := range familiesOf(method) {
+		if timeout, isFound := c.requestTimeouts[family]; isFound {
+			return timeout
+		}
+	}
+
+	return 0
+}
+
+// RequestStats returns requests of the gateway which wait for the core library.
+func (c *clientGateway) RequestStats() StoreStats {
+	return c.store.Stats()
 }
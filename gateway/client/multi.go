@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/markgenuine/ever-client-go/domain"
+)
+
+type (
+	// Router - calls of a gateway over several contexts which choose the context, see MultiContext.
+	Router interface {
+		GetResponseContext(ctx context.Context, method string, paramIn interface{}) ([]byte, error)
+		RequestContext(ctx context.Context, method string, paramIn interface{}) (*domain.Stream, error)
+	}
+
+	// MultiContext - part of gateways over several contexts (pool, failover, reload) which doesn't depend on
+	// routing: calls without ctx and results go through Router, handles, Destroy and Close cover all contexts.
+	// It is embedded by the gateway.
+	MultiContext struct {
+		router     Router
+		affinity   *Affinity
+		contexts   func() map[int]domain.ClientGateway
+		appObjects *domain.AppObjectRegistry
+	}
+)
+
+// NewMultiContext returns base of gateway whose calls are routed by gateway itself with affinity, contexts
+// returns gateways of its contexts which aren't destroyed yet by their owner numbers of affinity. App objects
+// are resolved through gateway with appOptions.
+func NewMultiContext(gateway interface {
+	domain.ClientGateway
+	Router
+}, affinity *Affinity, contexts func() map[int]domain.ClientGateway, appOptions ...domain.AppObjectOption) *MultiContext {
+	return &MultiContext{
+		router:     gateway,
+		affinity:   affinity,
+		contexts:   contexts,
+		appObjects: domain.NewAppObjectRegistry(gateway, appOptions...),
+	}
+}
+
+// clients returns gateways of contexts ordered by owner.
+func (m *MultiContext) clients() ([]int, []domain.ClientGateway) {
+	contexts := m.contexts()
+	owners := make([]int, 0, len(contexts))
+	for owner := range contexts {
+		owners = append(owners, owner)
+	}
+	sort.Ints(owners)
+	clients := make([]domain.ClientGateway, len(owners))
+	for i, owner := range owners {
+		clients[i] = contexts[owner]
+	}
+
+	return owners, clients
+}
+
+func (m *MultiContext) GetResult(method string, paramIn interface{}, resultStruct interface{}) error {
+	return m.GetResultContext(context.Background(), method, paramIn, resultStruct)
+}
+
+// GetResultContext - GetResult which stops waiting for the core library when ctx is done.
+func (m *MultiContext) GetResultContext(ctx context.Context, method string, paramIn interface{}, resultStruct interface{}) error {
+	rawData, err := m.router.GetResponseContext(ctx, method, paramIn)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(rawData, resultStruct)
+}
+
+func (m *MultiContext) GetResponse(method string, paramIn interface{}) ([]byte, error) {
+	return m.router.GetResponseContext(context.Background(), method, paramIn)
+}
+
+func (m *MultiContext) Request(method string, paramIn interface{}) (*domain.Stream, error) {
+	return m.router.RequestContext(context.Background(), method, paramIn)
+}
+
+// AppObjects returns registry of app objects which resolves requests in contexts which sent them.
+func (m *MultiContext) AppObjects() *domain.AppObjectRegistry {
+	return m.appObjects
+}
+
+// OpenHandles returns SDK handles of all contexts which aren't released yet, numeric handles are the virtual
+// ones known to the caller.
+func (m *MultiContext) OpenHandles() []*domain.OpenHandle {
+	var handles []*domain.OpenHandle
+	owners, clients := m.clients()
+	for i, client := range clients {
+		if tracker, ok := client.(domain.HandleTracker); ok {
+			handles = append(handles, m.affinity.Virtual(owners[i], tracker.OpenHandles())...)
+		}
+	}
+
+	return handles
+}
+
+// RequestStats returns requests of all contexts: their sum and the oldest of them.
+func (m *MultiContext) RequestStats() domain.RequestStats {
+	var stats domain.RequestStats
+	_, clients := m.clients()
+	for _, client := range clients {
+		if tracker, ok := client.(domain.RequestTracker); ok {
+			client := tracker.RequestStats()
+			stats.Live += client.Live
+			stats.Expired += client.Expired
+			if client.Oldest > stats.Oldest {
+				stats.Oldest = client.Oldest
+			}
+		}
+	}
+
+	return stats
+}
+
+// DroppedResponses returns count of responses dropped by backpressure of streams of all contexts by functions.
+func (m *MultiContext) DroppedResponses() map[string]uint64 {
+	dropped := make(map[string]uint64)
+	_, clients := m.clients()
+	for _, client := range clients {
+		if tracker, ok := client.(domain.DropTracker); ok {
+			for method, count := range tracker.DroppedResponses() {
+				dropped[method] += count
+			}
+		}
+	}
+
+	return dropped
+}
+
+// Destroy destroys all contexts at once.
+func (m *MultiContext) Destroy() {
+	_, clients := m.clients()
+	for _, client := range clients {
+		client.Destroy()
+	}
+}
+
+// Close closes all contexts and returns the first error.
+func (m *MultiContext) Close(ctx context.Context) error {
+	var firstErr error
+	_, clients := m.clients()
+	for _, client := range clients {
+		if err := client.Close(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
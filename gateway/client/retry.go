@@ -0,0 +1,192 @@
+package client
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/markgenuine/ever-client-go/domain"
+)
+
+// RetryPolicy - how calls which failed with transient errors of SDK are repeated, see WithRetryPolicy.
+type RetryPolicy struct {
+	// MaxAttempts - attempts of the call including the first one.
+	MaxAttempts int
+	// InitialBackoff - delay before the second attempt, every next delay is Multiplier times longer up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Multiplier - growth of delay, 2 when it is zero.
+	Multiplier float64
+	// Jitter - random part of delay from 0 to 1, the delay is shortened by up to Jitter of it.
+	Jitter float64
+	// RetryableCodes - codes of domain.ClientError which are repeated.
+	RetryableCodes []int
+	// NonIdempotent allows to repeat functions which change state, e.g. processing.send_message: the message
+	// may be sent twice.
+	NonIdempotent bool
+}
+
+// NonIdempotentFunctions - functions which change state, they are repeated only when RetryPolicy.NonIdempotent is
+// set. Gateways over several contexts don't repeat them in another context either.
+var NonIdempotentFunctions = map[string]bool{
+	"processing.send_message":    true,
+	"processing.send_messages":   true,
+	"processing.process_message": true,
+	"debot.start":                true,
+	"debot.execute":              true,
+	"debot.send":                 true,
+	"client.resolve_app_request": true,
+}
+
+// DefaultRetryPolicy returns policy for net: QueryFailed, WebsocketDisconnected and GraphqlConnectionError
+// are tried 4 times with delays from 100ms to 2s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableCodes: []int{
+			domain.NetErrorCode["QueryFailed"],
+			domain.NetErrorCode["WebsocketDisconnected"],
+			domain.NetErrorCode["GraphqlConnectionError"],
+		},
+	}
+}
+
+// WithRetryPolicy sets policy of a family of functions: a module, e.g. "net", or a function, e.g. "net.query".
+// Policy of the function overrides policy of its module. Calls are repeated only when the first response is
+// an error, so streams which already passed events fail as they are.
+func WithRetryPolicy(family string, policy RetryPolicy) Option {
+	return func(c *clientGateway) {
+		if c.retryPolicies == nil {
+			c.retryPolicies = make(map[string]RetryPolicy)
+		}
+		c.retryPolicies[family] = policy
+	}
+}
+
+// retryPolicy returns policy of method, it is found when method may be repeated.
+func (c *clientGateway) retryPolicy(method string) (RetryPolicy, bool) {
+	for _, family := range familiesOf(method) {
+		if policy, isFound := c.retryPolicies[family]; isFound {
+			if policy.MaxAttempts < 2 || NonIdempotentFunctions[method] && !policy.NonIdempotent {
+				return RetryPolicy{}, false
+			}
+			return policy, true
+		}
+	}
+
+	return RetryPolicy{}, false
+}
+
+// isRetryable reports whether err of SDK has one of RetryableCodes.
+func (p RetryPolicy) isRetryable(err error) bool {
+	code := domain.ErrorCode(err)
+	if code == 0 {
+		return false
+	}
+	for _, retryable := range p.RetryableCodes {
+		if code == retryable {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns delay after failed attempt, attempts are counted from 1.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier == 0 {
+		multiplier = 2
+	}
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+
+	return time.Duration(delay * (1 - p.Jitter*rand.Float64()))
+}
+
+// retry - the innermost interceptor, it repeats the call while its first response is a retryable error.
+// The last error is returned when attempts are over or ctx is done before the next attempt, domain.ErrClientClosed
+// when the gateway is closed meanwhile.
+func (c *clientGateway) retry(ctx context.Context, method string, params []byte, invoker Invoker) (<-chan *domain.ClientResponse, error) {
+	policy, isFound := c.retryPolicy(method)
+	if !isFound {
+		return invoker(ctx, method, params)
+	}
+
+	responses, err := invoker(ctx, method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan *domain.ClientResponse, 1)
+	go func() {
+		defer close(out)
+		for attempt := 1; ; attempt++ {
+			var (
+				first *domain.ClientResponse
+				ok    bool
+			)
+			select {
+			case first, ok = <-responses:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+
+			delay := policy.backoff(attempt)
+			deadline, hasDeadline := ctx.Deadline()
+			if first.Error == nil || attempt == policy.MaxAttempts || !policy.isRetryable(first.Error) ||
+				hasDeadline && time.Until(deadline) < delay {
+				pipeResponses(ctx, first, responses, out)
+				return
+			}
+			go drainResponses(responses)
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				out <- first
+				return
+			case <-c.closeCanals:
+				timer.Stop()
+				out <- &domain.ClientResponse{Code: domain.ResponseError, Error: domain.ErrClientClosed}
+				return
+			}
+
+			if responses, err = invoker(ctx, method, params); err != nil {
+				out <- &domain.ClientResponse{Code: domain.ResponseError, Error: err}
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// pipeResponses passes first and the rest of responses to out until ctx is done.
+func pipeResponses(ctx context.Context, first *domain.ClientResponse, responses <-chan *domain.ClientResponse, out chan<- *domain.ClientResponse) {
+	for r, ok := first, true; ok; r, ok = <-responses {
+		select {
+		case out <- r:
+		case <-ctx.Done():
+			go drainResponses(responses)
+			return
+		}
+	}
+}
+
+func drainResponses(responses <-chan *domain.ClientResponse) {
+	for range responses {
+	}
+}
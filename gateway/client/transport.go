@@ -0,0 +1,56 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/markgenuine/ever-client-go/domain"
+)
+
+const (
+	// RemoteURLEnv - environment variable with address of the sidecar, used by the remote transport
+	// when the binding is built without cgo.
+	RemoteURLEnv = "EVER_CLIENT_REMOTE_URL"
+	// DefaultRemoteURL - address of the sidecar when RemoteURLEnv is empty.
+	DefaultRemoteURL = "http://127.0.0.1:8090/"
+)
+
+type (
+	// ResponseHandler receives responses of one request in order, the last one has finished set.
+	// paramsJSON is the payload, responseType is one of tc_response_types from client_method.h.
+	ResponseHandler func(paramsJSON []byte, responseType uint32, finished bool)
+
+	// Transport carries calls of core library functions between clientGateway and libton_client.
+	Transport interface {
+		// CreateContext returns the raw response of tc_create_context: {"result": context} or {"error": {...}}.
+		CreateContext(config []byte) ([]byte, error)
+		DestroyContext(context uint32)
+		// Request starts function and returns immediately, responses are delivered to handler.
+		Request(context uint32, method string, paramsJSON []byte, handler ResponseHandler) error
+	}
+
+	// forgettingTransport - transport which keeps handlers of requests until the core library finishes them.
+	// The returned forget drops the handler of request which leaves the store earlier: expired or deleted.
+	forgettingTransport interface {
+		requestForget(context uint32, method string, paramsJSON []byte, handler ResponseHandler) (forget func(), err error)
+	}
+
+	// Option configures clientGateway.
+	Option func(*clientGateway)
+)
+
+// WithTransport sets transport used by clientGateway instead of the default one.
+func WithTransport(transport Transport) Option {
+	return func(c *clientGateway) {
+		c.transport = transport
+	}
+}
+
+// transportError builds error payload like SDK does, for failures of transport itself.
+func transportError(err error) []byte {
+	payload, _ := json.Marshal(&domain.ClientError{
+		Code:    domain.ClientErrorCode["InternalError"],
+		Message: err.Error(),
+	})
+
+	return payload
+}
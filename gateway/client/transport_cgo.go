@@ -0,0 +1,72 @@
+//go:build cgo && !nocgo && (everlink || windows)
+// +build cgo
+// +build !nocgo
+// +build everlink windows
+
+package client
+
+/*
+#cgo darwin LDFLAGS: -L${SRCDIR}/lib/darwin -lton_client
+#cgo linux LDFLAGS: -L${SRCDIR}/lib/linux -lton_client
+#cgo windows LDFLAGS: -L${SRCDIR}/lib/windows -lton_client
+
+#include "client_method.h"
+void callB(uint32_t request_id, tc_string_data_t paramsJson, uint32_t response_type, bool finished);
+
+*/
+import "C"
+import (
+	"unsafe"
+
+	"github.com/markgenuine/ever-client-go/domain"
+)
+
+type cgoTransport struct{}
+
+// NewCgoTransport returns transport which calls libton_client linked through cgo.
+func NewCgoTransport() Transport {
+	return cgoTransport{}
+}
+
+// NewDefaultTransport with the everlink tag or on windows is the transport of libton_client linked at build time.
+func NewDefaultTransport(domain.ClientConfig) (Transport, error) {
+	return NewCgoTransport(), nil
+}
+
+func tcStringData(in []byte) C.tc_string_data_t {
+	return C.tc_string_data_t{
+		len:     C.uint32_t(len(in)),
+		content: C.CString(string(in)),
+	}
+}
+
+func tcStringToByte(data C.tc_string_data_t) []byte {
+	if data.len == 0 {
+		return nil
+	}
+
+	return C.GoBytes(unsafe.Pointer(data.content), C.int(data.len))
+}
+
+func (cgoTransport) CreateContext(config []byte) ([]byte, error) {
+	handler := C.tc_create_context(tcStringData(config))
+	defer C.tc_destroy_string(handler)
+
+	return tcStringToByte(C.tc_read_string(handler)), nil
+}
+
+func (cgoTransport) DestroyContext(context uint32) {
+	C.tc_destroy_context(C.uint32_t(context))
+}
+
+func (t cgoTransport) Request(context uint32, method string, paramsJSON []byte, handler ResponseHandler) error {
+	_, err := t.requestForget(context, method, paramsJSON, handler)
+	return err
+}
+
+func (cgoTransport) requestForget(context uint32, method string, paramsJSON []byte, handler ResponseHandler) (func(), error) {
+	requestID, forget := cgoHandlers.add(handler)
+	C.tc_request(C.uint32_t(context), tcStringData([]byte(method)), tcStringData(paramsJSON), C.uint32_t(requestID), C.tc_response_handler_t(C.callB))
+
+	return forget, nil
+}
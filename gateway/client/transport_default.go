@@ -0,0 +1,20 @@
+//go:build !cgo || nocgo
+// +build !cgo nocgo
+
+package client
+
+import (
+	"os"
+
+	"github.com/markgenuine/ever-client-go/domain"
+)
+
+// NewDefaultTransport without cgo calls the sidecar at RemoteURLEnv, or DefaultRemoteURL when it is empty.
+func NewDefaultTransport(domain.ClientConfig) (Transport, error) {
+	url := os.Getenv(RemoteURLEnv)
+	if url == "" {
+		url = DefaultRemoteURL
+	}
+
+	return NewRemoteTransport(url, nil), nil
+}
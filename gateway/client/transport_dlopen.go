@@ -0,0 +1,144 @@
+//go:build cgo && !nocgo && !everlink && !windows
+// +build cgo,!nocgo,!everlink,!windows
+
+package client
+
+/*
+#cgo linux LDFLAGS: -ldl
+
+#include <dlfcn.h>
+#include "client_method.h"
+
+void callB(uint32_t request_id, tc_string_data_t paramsJson, uint32_t response_type, bool finished);
+
+static void* ever_lib;
+static tc_string_handle_t* (*ever_create_context)(tc_string_data_t);
+static void (*ever_destroy_context)(uint32_t);
+static void (*ever_request)(uint32_t, tc_string_data_t, tc_string_data_t, uint32_t, tc_response_handler_t);
+static tc_string_data_t (*ever_read_string)(const tc_string_handle_t*);
+static void (*ever_destroy_string)(const tc_string_handle_t*);
+
+// ever_load opens the library and resolves its functions, it returns error text or NULL.
+static const char* ever_load(const char* path) {
+	void* lib = dlopen(path, RTLD_NOW | RTLD_LOCAL);
+	if (lib == NULL) {
+		return dlerror();
+	}
+	ever_create_context = dlsym(lib, "tc_create_context");
+	ever_destroy_context = dlsym(lib, "tc_destroy_context");
+	ever_request = dlsym(lib, "tc_request");
+	ever_read_string = dlsym(lib, "tc_read_string");
+	ever_destroy_string = dlsym(lib, "tc_destroy_string");
+	if (!ever_create_context || !ever_destroy_context || !ever_request || !ever_read_string || !ever_destroy_string) {
+		const char* err = dlerror();
+		dlclose(lib);
+		return err != NULL ? err : "function of libton_client is not found";
+	}
+	ever_lib = lib;
+
+	return NULL;
+}
+
+static tc_string_data_t ever_create_context_string(tc_string_data_t config, tc_string_handle_t** handle) {
+	*handle = ever_create_context(config);
+	return ever_read_string(*handle);
+}
+
+static void ever_destroy_context_call(uint32_t context) {
+	ever_destroy_context(context);
+}
+
+static void ever_request_call(uint32_t context, tc_string_data_t method, tc_string_data_t params, uint32_t request_id) {
+	ever_request(context, method, params, request_id, (tc_response_handler_t)callB);
+}
+
+static void ever_destroy_string_call(tc_string_handle_t* handle) {
+	ever_destroy_string(handle);
+}
+*/
+import "C"
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+
+	"github.com/markgenuine/ever-client-go/domain"
+)
+
+// loadedLibrary - libton_client opened by dlopen, a process holds one library.
+var loadedLibrary struct {
+	sync.Mutex
+	path string
+}
+
+type dlopenTransport struct {
+	path string
+}
+
+// NewLibraryTransport opens libton_client at path with dlopen. The library is loaded once per process,
+// opening of another path fails.
+func NewLibraryTransport(path string) (Transport, error) {
+	loadedLibrary.Lock()
+	defer loadedLibrary.Unlock()
+
+	if loadedLibrary.path != "" {
+		if loadedLibrary.path != path {
+			return nil, fmt.Errorf("libton_client is loaded from %s already, can't load %s", loadedLibrary.path, path)
+		}
+		return &dlopenTransport{path: path}, nil
+	}
+
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+	if errText := C.ever_load(cPath); errText != nil {
+		return nil, fmt.Errorf("load libton_client %s: %s", path, C.GoString(errText))
+	}
+	loadedLibrary.path = path
+
+	return &dlopenTransport{path: path}, nil
+}
+
+// NewDefaultTransport with cgo loads libton_client located by LocateLibrary.
+func NewDefaultTransport(config domain.ClientConfig) (Transport, error) {
+	return NewLibraryTransport(LocateLibrary(config))
+}
+
+// Library returns path of the loaded libton_client.
+func (t *dlopenTransport) Library() string {
+	return t.path
+}
+
+func (t *dlopenTransport) CreateContext(config []byte) ([]byte, error) {
+	cConfig := C.CBytes(config)
+	defer C.free(cConfig)
+
+	var handle *C.tc_string_handle_t
+	data := C.ever_create_context_string(C.tc_string_data_t{content: (*C.char)(cConfig), len: C.uint32_t(len(config))}, &handle)
+	defer C.ever_destroy_string_call(handle)
+
+	return C.GoBytes(unsafe.Pointer(data.content), C.int(data.len)), nil
+}
+
+func (t *dlopenTransport) DestroyContext(context uint32) {
+	C.ever_destroy_context_call(C.uint32_t(context))
+}
+
+func (t *dlopenTransport) Request(context uint32, method string, paramsJSON []byte, handler ResponseHandler) error {
+	_, err := t.requestForget(context, method, paramsJSON, handler)
+	return err
+}
+
+func (t *dlopenTransport) requestForget(context uint32, method string, paramsJSON []byte, handler ResponseHandler) (func(), error) {
+	cMethod := C.CString(method)
+	defer C.free(unsafe.Pointer(cMethod))
+	cParams := C.CBytes(paramsJSON)
+	defer C.free(cParams)
+
+	requestID, forget := cgoHandlers.add(handler)
+	C.ever_request_call(C.uint32_t(context),
+		C.tc_string_data_t{content: cMethod, len: C.uint32_t(len(method))},
+		C.tc_string_data_t{content: (*C.char)(cParams), len: C.uint32_t(len(paramsJSON))},
+		C.uint32_t(requestID))
+
+	return forget, nil
+}
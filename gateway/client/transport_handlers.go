@@ -0,0 +1,108 @@
+//go:build cgo && !nocgo
+// +build cgo,!nocgo
+
+package client
+
+/*
+#include "client_method.h"
+*/
+import "C"
+import (
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// cgoHandlers - handlers of requests of all contexts of the process, libton_client calls back with request ID only.
+var cgoHandlers = newCgoHandlerStore()
+
+type (
+	// cgoHandlerStore keeps handlers of requests which are in progress in libton_client. Handlers are spread over
+	// shards by request ID like requests of the store.
+	cgoHandlerStore struct {
+		counter uint32
+		shards  [storeShards]cgoHandlerShard
+	}
+
+	cgoHandlerShard struct {
+		sync.Mutex
+		handlers map[uint32]*cgoHandler
+	}
+
+	// cgoHandler - handler of one request, it is compared by pointer when it is forgotten.
+	cgoHandler struct {
+		handle ResponseHandler
+	}
+)
+
+func newCgoHandlerStore() *cgoHandlerStore {
+	s := &cgoHandlerStore{}
+	for i := range s.shards {
+		s.shards[i].handlers = make(map[uint32]*cgoHandler)
+	}
+
+	return s
+}
+
+func (s *cgoHandlerStore) shard(requestID uint32) *cgoHandlerShard {
+	return &s.shards[requestID&(storeShards-1)]
+}
+
+// add registers handler under a new request ID and returns the ID and the function which forgets the handler
+// when libton_client won't finish the request, e.g. it is expired or deleted from the store.
+func (s *cgoHandlerStore) add(handle ResponseHandler) (uint32, func()) {
+	handler := &cgoHandler{handle: handle}
+	for {
+		requestID := atomic.AddUint32(&s.counter, 1)
+		if requestID == 0 {
+			continue
+		}
+
+		shard := s.shard(requestID)
+		shard.Lock()
+		if _, isLive := shard.handlers[requestID]; isLive {
+			shard.Unlock()
+			continue
+		}
+		shard.handlers[requestID] = handler
+		shard.Unlock()
+
+		return requestID, func() { s.forget(requestID, handler) }
+	}
+}
+
+func (s *cgoHandlerStore) get(requestID uint32, toDelete bool) (ResponseHandler, bool) {
+	shard := s.shard(requestID)
+	shard.Lock()
+	defer shard.Unlock()
+	handler, isFound := shard.handlers[requestID]
+	if !isFound {
+		return nil, false
+	}
+	if toDelete {
+		delete(shard.handlers, requestID)
+	}
+
+	return handler.handle, true
+}
+
+// forget deletes handler unless its request ID is taken by another request already.
+func (s *cgoHandlerStore) forget(requestID uint32, handler *cgoHandler) {
+	shard := s.shard(requestID)
+	shard.Lock()
+	defer shard.Unlock()
+	if shard.handlers[requestID] == handler {
+		delete(shard.handlers, requestID)
+	}
+}
+
+//export callB
+func callB(requestIDin C.uint32_t, paramsJSON C.tc_string_data_t, responseTypein C.uint32_t, finishedin C.bool) {
+	finished := bool(finishedin)
+	handler, isFound := cgoHandlers.get(uint32(requestIDin), finished)
+	if !isFound {
+		return
+	}
+
+	handler(C.GoBytes(unsafe.Pointer(paramsJSON.content), C.int(paramsJSON.len)), uint32(responseTypein), finished)
+}
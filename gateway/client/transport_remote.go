@@ -0,0 +1,337 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/markgenuine/ever-client-go/domain"
+)
+
+// Remote transport talks JSON-RPC 2.0 over HTTP to a sidecar process which owns libton_client.
+// Every call is a POST with one JSON-RPC request in the body:
+//
+//	tc_create_context  {"config": "<config json>"}                   -> result "<response of tc_create_context>"
+//	tc_destroy_context {"context": 1}                                 -> result null
+//	tc_request         {"context": 1, "function_name": "net.query",
+//	                    "function_params_json": "<params json>"}
+//
+// The body of tc_request response is a stream of tc_response notifications, one JSON object per line,
+// until the notification with finished set:
+//
+//	{"jsonrpc": "2.0", "method": "tc_response",
+//	 "params": {"params_json": "<payload>", "response_type": 0, "finished": true}}
+//
+// App objects (response types 3 and 4) are resolved by client.resolve_app_request which is an ordinary tc_request.
+//
+// Only HTTP is supported, there is no WebSocket variant: a subscription or a request of app object holds its HTTP
+// response open until it is finished, so proxies between the binding and the sidecar must not time out idle
+// responses.
+const (
+	rpcVersion = "2.0"
+
+	rpcMethodCreateContext  = "tc_create_context"
+	rpcMethodDestroyContext = "tc_destroy_context"
+	rpcMethodRequest        = "tc_request"
+	rpcMethodResponse       = "tc_response"
+
+	rpcCodeParseError     = -32700
+	rpcCodeMethodNotFound = -32601
+	rpcCodeInvalidParams  = -32602
+	rpcCodeInternalError  = -32603
+)
+
+type (
+	rpcRequest struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      uint64          `json:"id"`
+		Method  string          `json:"method"`
+		Params  json.RawMessage `json:"params,omitempty"`
+	}
+
+	rpcMessage struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      *uint64         `json:"id,omitempty"`
+		Method  string          `json:"method,omitempty"`
+		Params  json.RawMessage `json:"params,omitempty"`
+		Result  json.RawMessage `json:"result,omitempty"`
+		Error   *rpcError       `json:"error,omitempty"`
+	}
+
+	rpcError struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+
+	paramsOfCreateContext struct {
+		Config string `json:"config"`
+	}
+
+	paramsOfDestroyContext struct {
+		Context uint32 `json:"context"`
+	}
+
+	paramsOfRequest struct {
+		Context            uint32 `json:"context"`
+		FunctionName       string `json:"function_name"`
+		FunctionParamsJSON string `json:"function_params_json"`
+	}
+
+	paramsOfResponse struct {
+		ParamsJSON   string `json:"params_json"`
+		ResponseType uint32 `json:"response_type"`
+		Finished     bool   `json:"finished"`
+	}
+
+	remoteTransport struct {
+		url    string
+		client *http.Client
+		lastID uint64
+	}
+
+	remoteHandler struct {
+		transport Transport
+	}
+)
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("remote transport: %s (code: %d)", e.Message, e.Code)
+}
+
+// NewRemoteTransport returns transport which calls the sidecar at url over plain HTTP, WebSocket isn't supported.
+// httpClient may be nil, its Timeout has to be zero or longer than the longest subscription.
+func NewRemoteTransport(url string, httpClient *http.Client) Transport {
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
+	return &remoteTransport{
+		url:    url,
+		client: httpClient,
+	}
+}
+
+func (rt *remoteTransport) post(method string, params interface{}) (*http.Response, error) {
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(&rpcRequest{
+		JSONRPC: rpcVersion,
+		ID:      atomic.AddUint64(&rt.lastID, 1),
+		Method:  method,
+		Params:  rawParams,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := rt.client.Post(rt.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("remote transport: unexpected status %s", resp.Status)
+	}
+
+	return resp, nil
+}
+
+func (rt *remoteTransport) call(method string, params interface{}, result interface{}) error {
+	resp, err := rt.post(method, params)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var msg rpcMessage
+	if err := json.NewDecoder(resp.Body).Decode(&msg); err != nil {
+		return err
+	}
+	if msg.Error != nil {
+		return msg.Error
+	}
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(msg.Result, result)
+}
+
+func (rt *remoteTransport) CreateContext(config []byte) ([]byte, error) {
+	var response string
+	if err := rt.call(rpcMethodCreateContext, &paramsOfCreateContext{Config: string(config)}, &response); err != nil {
+		return nil, err
+	}
+
+	return []byte(response), nil
+}
+
+func (rt *remoteTransport) DestroyContext(context uint32) {
+	_ = rt.call(rpcMethodDestroyContext, &paramsOfDestroyContext{Context: context}, nil)
+}
+
+func (rt *remoteTransport) Request(context uint32, method string, paramsJSON []byte, handler ResponseHandler) error {
+	resp, err := rt.post(rpcMethodRequest, &paramsOfRequest{
+		Context:            context,
+		FunctionName:       method,
+		FunctionParamsJSON: string(paramsJSON),
+	})
+	if err != nil {
+		return err
+	}
+
+	go readResponses(resp.Body, handler)
+
+	return nil
+}
+
+// readResponses passes tc_response notifications to handler, broken stream finishes the request with error.
+func readResponses(body io.ReadCloser, handler ResponseHandler) {
+	defer body.Close()
+	decoder := json.NewDecoder(body)
+	for {
+		var msg rpcMessage
+		if err := decoder.Decode(&msg); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			handler(transportError(fmt.Errorf("remote transport: %w", err)), domain.ResponseError, true)
+			return
+		}
+		if msg.Error != nil {
+			handler(transportError(msg.Error), domain.ResponseError, true)
+			return
+		}
+
+		var response paramsOfResponse
+		if err := json.Unmarshal(msg.Params, &response); err != nil {
+			handler(transportError(fmt.Errorf("remote transport: %w", err)), domain.ResponseError, true)
+			return
+		}
+		handler([]byte(response.ParamsJSON), response.ResponseType, response.Finished)
+		if response.Finished {
+			return
+		}
+	}
+}
+
+// NewRemoteHandler serves the remote transport protocol on top of transport, it is the sidecar side of
+// NewRemoteTransport.
+func NewRemoteHandler(transport Transport) http.Handler {
+	return &remoteHandler{transport: transport}
+}
+
+func (h *remoteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req rpcRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeRPCMessage(w, &rpcMessage{Error: &rpcError{Code: rpcCodeParseError, Message: err.Error()}})
+		return
+	}
+
+	switch req.Method {
+	case rpcMethodCreateContext:
+		var params paramsOfCreateContext
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			writeRPCError(w, req.ID, rpcCodeInvalidParams, err)
+			return
+		}
+		response, err := h.transport.CreateContext([]byte(params.Config))
+		if err != nil {
+			writeRPCError(w, req.ID, rpcCodeInternalError, err)
+			return
+		}
+		writeRPCResult(w, req.ID, string(response))
+	case rpcMethodDestroyContext:
+		var params paramsOfDestroyContext
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			writeRPCError(w, req.ID, rpcCodeInvalidParams, err)
+			return
+		}
+		h.transport.DestroyContext(params.Context)
+		writeRPCResult(w, req.ID, nil)
+	case rpcMethodRequest:
+		var params paramsOfRequest
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			writeRPCError(w, req.ID, rpcCodeInvalidParams, err)
+			return
+		}
+		h.serveRequest(w, r, req.ID, &params)
+	default:
+		writeRPCError(w, req.ID, rpcCodeMethodNotFound, fmt.Errorf("method %s not found", req.Method))
+	}
+}
+
+func (h *remoteHandler) serveRequest(w http.ResponseWriter, r *http.Request, id uint64, params *paramsOfRequest) {
+	done := r.Context().Done()
+	responses := make(chan paramsOfResponse, 1)
+	err := h.transport.Request(params.Context, params.FunctionName, []byte(params.FunctionParamsJSON),
+		func(paramsJSON []byte, responseType uint32, finished bool) {
+			select {
+			case responses <- paramsOfResponse{ParamsJSON: string(paramsJSON), ResponseType: responseType, Finished: finished}:
+			case <-done:
+			}
+		})
+	if err != nil {
+		writeRPCError(w, id, rpcCodeInternalError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+
+	encoder := json.NewEncoder(w)
+	for {
+		select {
+		case response := <-responses:
+			rawParams, err := json.Marshal(&response)
+			if err != nil {
+				return
+			}
+			if err := encoder.Encode(&rpcMessage{JSONRPC: rpcVersion, Method: rpcMethodResponse, Params: rawParams}); err != nil {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+			if response.Finished {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+func writeRPCResult(w http.ResponseWriter, id uint64, result interface{}) {
+	rawResult, err := json.Marshal(result)
+	if err != nil {
+		writeRPCError(w, id, rpcCodeInternalError, err)
+		return
+	}
+	writeRPCMessage(w, &rpcMessage{ID: &id, Result: rawResult})
+}
+
+func writeRPCError(w http.ResponseWriter, id uint64, code int, err error) {
+	writeRPCMessage(w, &rpcMessage{ID: &id, Error: &rpcError{Code: code, Message: err.Error()}})
+}
+
+func writeRPCMessage(w http.ResponseWriter, msg *rpcMessage) {
+	msg.JSONRPC = rpcVersion
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(msg)
+}
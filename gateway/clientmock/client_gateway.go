@@ -1,122 +1,311 @@
-// Code generated by mockery v2.0.0-alpha.13. DO NOT EDIT.
-
+// Package clientmock contains programmable fake of domain.ClientGateway for unit tests of use cases.
 package clientmock
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/markgenuine/ever-client-go/domain"
+)
+
+type (
+	// Handler builds responses of the call from its params.
+	Handler func(params json.RawMessage) []*domain.ClientResponse
+
+	// Call - scripted answer of one call of method.
+	Call struct {
+		handler  Handler
+		keepOpen bool
+	}
+
+	// ClientGateway - fake of domain.ClientGateway. Every method is answered by responses scripted with On or OnFunc,
+	// calls of the same method get scripts in order and the last one is repeated.
+	ClientGateway struct {
+		mu       sync.Mutex
+		scripts  map[string][]*Call
+		played   map[string]int
+		requests map[string][]json.RawMessage
+		closed   chan struct{}
+		once     sync.Once
+
+		appObjects *domain.AppObjectRegistry
+	}
+)
+
+// NewClientGateway returns fake without scripts, every call fails with NotImplemented until it's scripted.
+// Options configure the registry of app objects.
+func NewClientGateway(opts ...domain.AppObjectOption) *ClientGateway {
+	g := &ClientGateway{
+		scripts:  make(map[string][]*Call),
+		played:   make(map[string]int),
+		requests: make(map[string][]json.RawMessage),
+		closed:   make(chan struct{}),
+	}
+	g.appObjects = domain.NewAppObjectRegistry(g, opts...)
+
+	return g
+}
+
+// On scripts the next call of method to answer with responses, see Result, Event, AppRequest, AppNotify and Error.
+func (g *ClientGateway) On(method string, responses ...*domain.ClientResponse) *Call {
+	return g.OnFunc(method, func(json.RawMessage) []*domain.ClientResponse {
+		return responses
+	})
+}
+
+// OnFunc scripts the next call of method to answer with responses built by handler.
+func (g *ClientGateway) OnFunc(method string, handler Handler) *Call {
+	call := &Call{handler: handler}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.scripts[method] = append(g.scripts[method], call)
+
+	return call
+}
 
-// ClientGateway is an autogenerated mock type for the ClientGateway type
-type ClientGateway struct {
-	mock.Mock
+// KeepOpen leaves the stream open after responses like subscriptions and app objects do, it is closed
+// when ctx of the call is done or the gateway is destroyed.
+func (c *Call) KeepOpen() *Call {
+	c.keepOpen = true
+	return c
 }
 
-// Destroy provides a mock function with given fields:
-func (_m *ClientGateway) Destroy() {
-	_m.Called()
+// Requests returns params of calls of method in order.
+func (g *ClientGateway) Requests(method string) []json.RawMessage {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return append([]json.RawMessage(nil), g.requests[method]...)
 }
 
-// GetAPIReference provides a mock function with given fields:
-func (_m *ClientGateway) GetAPIReference() (int, error) {
-	ret := _m.Called()
+func (g *ClientGateway) next(method string, params json.RawMessage) *Call {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.requests[method] = append(g.requests[method], params)
 
-	var r0 int
-	if rf, ok := ret.Get(0).(func() int); ok {
-		r0 = rf()
-	} else {
-		r0 = ret.Get(0).(int)
+	calls := g.scripts[method]
+	if len(calls) == 0 {
+		return nil
 	}
+	i := g.played[method]
+	if i >= len(calls) {
+		return calls[len(calls)-1]
+	}
+	g.played[method]++
+
+	return calls[i]
+}
+
+// Result returns response with result of function, code 0.
+func Result(value interface{}) *domain.ClientResponse {
+	return newResponse(domain.ResponseSuccess, value)
+}
+
+// Event returns response with event of function like ProcessingEvent or subscription data, code 100.
+func Event(value interface{}) *domain.ClientResponse {
+	return newResponse(domain.ResponseCustom, value)
+}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
-	} else {
-		r1 = ret.Error(1)
+// AppRequest returns request to app object which waits for client.resolve_app_request, code 3.
+func AppRequest(appRequestID int, requestData interface{}) *domain.ClientResponse {
+	data, err := marshal(requestData)
+	if err != nil {
+		return Error(err)
 	}
 
-	return r0, r1
+	return newResponse(domain.ResponseAppRequest, &domain.ParamsOfAppRequest{AppRequestID: appRequestID, RequestData: data})
+}
+
+// AppNotify returns notification of app object, code 4.
+func AppNotify(value interface{}) *domain.ClientResponse {
+	return newResponse(domain.ResponseAppNotify, value)
 }
 
-// GetBuildInfo provides a mock function with given fields:
-func (_m *ClientGateway) GetBuildInfo() (int, error) {
-	ret := _m.Called()
+// Error returns error response, code 1.
+func Error(err error) *domain.ClientResponse {
+	data, _ := json.Marshal(err)
+
+	return &domain.ClientResponse{Code: domain.ResponseError, Data: data, Error: err}
+}
 
-	var r0 int
-	if rf, ok := ret.Get(0).(func() int); ok {
-		r0 = rf()
-	} else {
-		r0 = ret.Get(0).(int)
+func newResponse(code uint32, value interface{}) *domain.ClientResponse {
+	data, err := marshal(value)
+	if err != nil {
+		return Error(err)
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
-	} else {
-		r1 = ret.Error(1)
+	return &domain.ClientResponse{Code: code, Data: data}
+}
+
+func marshal(value interface{}) (json.RawMessage, error) {
+	switch v := value.(type) {
+	case json.RawMessage:
+		return v, nil
+	case []byte:
+		return v, nil
+	case string:
+		return json.RawMessage(v), nil
+	default:
+		return json.Marshal(value)
 	}
+}
 
-	return r0, r1
+// Destroy closes streams which are kept open, later calls fail.
+func (g *ClientGateway) Destroy() {
+	g.once.Do(func() {
+		close(g.closed)
+	})
 }
 
-// GetResp provides a mock function with given fields: resp
-func (_m *ClientGateway) GetResp(resp int) (interface{}, error) {
-	ret := _m.Called(resp)
+// Close is Destroy of fake, there are no SDK handles to release.
+func (g *ClientGateway) Close(context.Context) error {
+	g.Destroy()
+	return nil
+}
 
-	var r0 interface{}
-	if rf, ok := ret.Get(0).(func(int) interface{}); ok {
-		r0 = rf(resp)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(interface{})
-		}
+func (g *ClientGateway) GetResult(method string, paramIn interface{}, resultStruct interface{}) error {
+	return g.GetResultContext(context.Background(), method, paramIn, resultStruct)
+}
+
+func (g *ClientGateway) GetResultContext(ctx context.Context, method string, paramIn interface{}, resultStruct interface{}) error {
+	rawData, err := g.GetResponseContext(ctx, method, paramIn)
+	if err != nil {
+		return err
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(resp)
-	} else {
-		r1 = ret.Error(1)
+	return json.Unmarshal(rawData, resultStruct)
+}
+
+func (g *ClientGateway) Request(method string, paramIn interface{}) (*domain.Stream, error) {
+	return g.RequestContext(context.Background(), method, paramIn)
+}
+
+func (g *ClientGateway) RequestContext(ctx context.Context, method string, paramIn interface{}) (*domain.Stream, error) {
+	responses, err := g.request(ctx, method, paramIn)
+	if err != nil {
+		return nil, err
 	}
 
-	return r0, r1
+	return domain.NewStream(ctx, responses), nil
 }
 
-// Request provides a mock function with given fields: method, paramsIn
-func (_m *ClientGateway) Request(method string, paramsIn interface{}) (int, error) {
-	ret := _m.Called(method, paramsIn)
+func (g *ClientGateway) request(ctx context.Context, method string, paramIn interface{}) (<-chan *domain.ClientResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	select {
+	case <-g.closed:
+		return nil, domain.ErrClientClosed
+	default:
+	}
 
-	var r0 int
-	if rf, ok := ret.Get(0).(func(string, interface{}) int); ok {
-		r0 = rf(method, paramsIn)
-	} else {
-		r0 = ret.Get(0).(int)
+	var params json.RawMessage
+	if paramIn != nil {
+		rawBody, err := json.Marshal(paramIn)
+		if err != nil {
+			return nil, err
+		}
+		params = rawBody
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(string, interface{}) error); ok {
-		r1 = rf(method, paramsIn)
-	} else {
-		r1 = ret.Error(1)
+	call := g.next(method, params)
+	if call == nil {
+		call = &Call{handler: func(json.RawMessage) []*domain.ClientResponse {
+			return []*domain.ClientResponse{Error(&domain.ClientError{
+				Code:    domain.ClientErrorCode["NotImplemented"],
+				Message: fmt.Sprintf("clientmock: method %s isn't scripted", method),
+			})}
+		}}
 	}
 
-	return r0, r1
+	responses := make(chan *domain.ClientResponse)
+	go func() {
+		defer close(responses)
+		for _, r := range call.handler(params) {
+			select {
+			case responses <- r:
+			case <-ctx.Done():
+				return
+			case <-g.closed:
+				return
+			}
+		}
+		if call.keepOpen {
+			select {
+			case <-ctx.Done():
+			case <-g.closed:
+			}
+		}
+	}()
+
+	return responses, nil
 }
 
-// Version provides a mock function with given fields:
-func (_m *ClientGateway) Version() (int, error) {
-	ret := _m.Called()
+func (g *ClientGateway) GetResponse(method string, paramIn interface{}) ([]byte, error) {
+	return g.GetResponseContext(context.Background(), method, paramIn)
+}
 
-	var r0 int
-	if rf, ok := ret.Get(0).(func() int); ok {
-		r0 = rf()
-	} else {
-		r0 = ret.Get(0).(int)
+func (g *ClientGateway) GetResponseContext(ctx context.Context, method string, paramIn interface{}) ([]byte, error) {
+	responses, err := g.request(ctx, method, paramIn)
+	if err != nil {
+		return nil, err
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
-	} else {
-		r1 = ret.Error(1)
+	var data []byte
+	for r := range responses {
+		if r.Error != nil && err == nil {
+			err = r.Error
+		}
+		if r.Data != nil && data == nil {
+			data = r.Data
+		}
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil && data == nil && err == nil {
+		return nil, ctxErr
 	}
 
-	return r0, r1
+	return data, err
+}
+
+// GetAPIReference answers client.get_api_reference.
+func (g *ClientGateway) GetAPIReference() (*domain.ResultOfGetAPIReference, error) {
+	result := new(domain.ResultOfGetAPIReference)
+	err := g.GetResult("client.get_api_reference", nil, result)
+	return result, err
+}
+
+// Version answers client.version.
+func (g *ClientGateway) Version() (*domain.ResultOfVersion, error) {
+	result := new(domain.ResultOfVersion)
+	err := g.GetResult("client.version", nil, result)
+	return result, err
+}
+
+// Config answers client.config.
+func (g *ClientGateway) Config() (*domain.ClientConfig, error) {
+	result := new(domain.ClientConfig)
+	err := g.GetResult("client.config", nil, result)
+	return result, err
 }
+
+// GetBuildInfo answers client.build_info.
+func (g *ClientGateway) GetBuildInfo() (*domain.ResultOfBuildInfo, error) {
+	result := new(domain.ResultOfBuildInfo)
+	err := g.GetResult("client.build_info", nil, result)
+	return result, err
+}
+
+// ResolveAppRequest answers client.resolve_app_request, params of resolved requests are available by Requests.
+func (g *ClientGateway) ResolveAppRequest(pORAR *domain.ParamsOfResolveAppRequest) error {
+	_, err := g.GetResponse("client.resolve_app_request", pORAR)
+	return err
+}
+
+// AppObjects returns registry of app objects which resolves requests through the fake.
+func (g *ClientGateway) AppObjects() *domain.AppObjectRegistry {
+	return g.appObjects
+}
+
+var _ domain.ClientGateway = (*ClientGateway)(nil)
@@ -0,0 +1,235 @@
+package clientmock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/markgenuine/ever-client-go/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientGateway(t *testing.T) {
+	t.Run("TestScriptsInOrder", func(t *testing.T) {
+		gw := NewClientGateway()
+		defer gw.Destroy()
+		gw.On("client.version", Result(&domain.ResultOfVersion{Version: "1.0.0"}))
+		gw.On("client.version", Result(&domain.ResultOfVersion{Version: "2.0.0"}))
+
+		for _, version := range []string{"1.0.0", "2.0.0", "2.0.0"} {
+			result, err := gw.Version()
+			assert.Equal(t, nil, err)
+			assert.Equal(t, version, result.Version)
+		}
+		assert.Equal(t, 3, len(gw.Requests("client.version")))
+	})
+
+	t.Run("TestStream", func(t *testing.T) {
+		gw := NewClientGateway()
+		defer gw.Destroy()
+		gw.On("test.stream", Event(`{"a":1}`), AppNotify(`{"b":2}`), AppRequest(1, `{"c":3}`), Result(`{}`))
+
+		stream, err := gw.Request("test.stream", map[string]int{"x": 1})
+		assert.Equal(t, nil, err)
+		var codes []uint32
+		for {
+			r, err := stream.Next(context.Background())
+			if err == io.EOF {
+				break
+			}
+			assert.Equal(t, nil, err)
+			codes = append(codes, r.Code)
+		}
+		assert.Equal(t, []uint32{
+			domain.ResponseCustom, domain.ResponseAppNotify, domain.ResponseAppRequest, domain.ResponseSuccess,
+		}, codes)
+		assert.JSONEq(t, `{"x":1}`, string(gw.Requests("test.stream")[0]))
+	})
+
+	t.Run("TestStreamSplit", func(t *testing.T) {
+		gw := NewClientGateway()
+		defer gw.Destroy()
+		gw.On("test.stream", Event(`{"a":1}`), Result(`{"handle":7}`), AppRequest(1, `{"c":3}`),
+			AppNotify(`{"b":2}`), Event(`{"a":2}`))
+
+		stream, err := gw.Request("test.stream", nil)
+		assert.Equal(t, nil, err)
+		result := &struct{ Handle int }{}
+		assert.Equal(t, nil, stream.Result(result))
+		assert.Equal(t, 7, result.Handle)
+
+		var events []string
+		for event := range stream.Events() {
+			events = append(events, string(event))
+		}
+		assert.Equal(t, []string{`{"a":1}`, `{"a":2}`}, events)
+		appRequest := <-stream.AppRequests()
+		assert.Equal(t, 1, appRequest.AppRequestID)
+		assert.Equal(t, `{"c":3}`, string(appRequest.RequestData))
+		assert.Equal(t, `{"b":2}`, string(<-stream.AppNotifications()))
+		assert.Equal(t, nil, stream.Err())
+	})
+
+	t.Run("TestStreamResult", func(t *testing.T) {
+		gw := NewClientGateway()
+		defer gw.Destroy()
+		errExpected := domain.NewClientError(domain.ClientErrorCode, "InvalidParams")
+		gw.On("test.fail", Event(`{}`), Error(errExpected))
+		gw.On("test.empty", Event(`{}`))
+
+		stream, err := gw.Request("test.fail", nil)
+		assert.Equal(t, nil, err)
+		assert.True(t, errors.Is(stream.Result(nil), errExpected))
+		r, err := stream.Next(context.Background())
+		assert.Equal(t, nil, err)
+		assert.Equal(t, domain.ResponseCustom, r.Code)
+
+		stream, err = gw.Request("test.empty", nil)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, domain.ErrNoResult, stream.Result(nil))
+	})
+
+	t.Run("TestKeepOpen", func(t *testing.T) {
+		gw := NewClientGateway()
+		gw.On("test.subscribe", Result(`{"handle":1}`)).KeepOpen()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		stream, err := gw.RequestContext(ctx, "test.subscribe", nil)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, nil, stream.Result(nil))
+		_, err = stream.Next(context.Background())
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+		stream, err = gw.Request("test.subscribe", nil)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, nil, stream.Result(nil))
+		gw.Destroy()
+		_, err = stream.Next(context.Background())
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("TestError", func(t *testing.T) {
+		gw := NewClientGateway()
+		defer gw.Destroy()
+		errExpected := domain.NewClientError(domain.ClientErrorCode, "InvalidParams")
+		gw.On("test.fail", Error(errExpected))
+
+		_, err := gw.GetResponse("test.fail", nil)
+		assert.True(t, errors.Is(err, errExpected))
+
+		_, err = gw.GetResponse("test.unknown", nil)
+		var clientErr *domain.ClientError
+		assert.True(t, errors.As(err, &clientErr))
+		assert.Equal(t, domain.ClientErrorCode["NotImplemented"], clientErr.Code)
+	})
+}
+
+type echoHandlers struct {
+	block   chan struct{}
+	stopped chan struct{}
+	notes   []string
+}
+
+func (e *echoHandlers) table() *domain.AppObjectHandlers {
+	return &domain.AppObjectHandlers{
+		Name: "Echo",
+		Decode: func(data json.RawMessage) (interface{}, error) {
+			var params struct {
+				Type  string `json:"type"`
+				Value string `json:"value"`
+			}
+			err := json.Unmarshal(data, &params)
+			return params.Value, err
+		},
+		Encode: func(value interface{}) (json.RawMessage, error) {
+			return json.Marshal(map[string]interface{}{"type": "Echo", "value": value})
+		},
+		Requests: map[string]domain.AppHandler{
+			"Echo": func(_ context.Context, params interface{}) (interface{}, error) {
+				return params, nil
+			},
+			"Fail": func(_ context.Context, params interface{}) (interface{}, error) {
+				return nil, errors.New(params.(string))
+			},
+			"Panic": func(_ context.Context, params interface{}) (interface{}, error) {
+				panic(params)
+			},
+			"Block": func(ctx context.Context, _ interface{}) (interface{}, error) {
+				select {
+				case <-e.block:
+				case <-ctx.Done():
+					close(e.stopped)
+				}
+				return nil, nil
+			},
+		},
+		Notifications: map[string]domain.AppHandler{
+			"Note": func(_ context.Context, params interface{}) (interface{}, error) {
+				e.notes = append(e.notes, params.(string))
+				return nil, nil
+			},
+		},
+	}
+}
+
+func TestAppObjects(t *testing.T) {
+	var failures []*domain.AppObjectError
+	gw := NewClientGateway(
+		domain.WithAppRequestTimeout(10*time.Millisecond),
+		domain.WithAppObjectErrorHook(func(err *domain.AppObjectError) {
+			failures = append(failures, err)
+		}))
+	defer gw.Destroy()
+	echo := &echoHandlers{block: make(chan struct{}), stopped: make(chan struct{})}
+	defer close(echo.block)
+
+	gw.On("test.app_object", Result(`{"handle":1}`),
+		AppRequest(1, `{"type":"Echo","value":"hello"}`),
+		AppNotify(`{"type":"Note","value":"first"}`),
+		AppRequest(2, `{"type":"Fail","value":"refused"}`),
+		AppRequest(3, `{"type":"Panic","value":"boom"}`),
+		AppRequest(4, `{"type":"Block"}`),
+		AppRequest(5, `{"type":"Unknown"}`),
+		AppNotify(`{"type":"Unknown"}`))
+	gw.On("client.resolve_app_request", Result(`{}`))
+
+	stream, err := gw.Request("test.app_object", nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, stream.Result(nil))
+	gw.AppObjects().Serve(context.Background(), stream, echo.table())
+
+	var results []string
+	for _, params := range gw.Requests("client.resolve_app_request") {
+		results = append(results, string(params))
+	}
+	assert.Equal(t, []string{
+		`{"app_request_id":1,"result":{"type":"Ok","result":{"type":"Echo","value":"hello"}}}`,
+		`{"app_request_id":2,"result":{"type":"Error","text":"refused"}}`,
+		`{"app_request_id":3,"result":{"type":"Error","text":"handler panicked: boom"}}`,
+		`{"app_request_id":4,"result":{"type":"Error","text":"app request timeout"}}`,
+		`{"app_request_id":5,"result":{"type":"Error","text":"unsupported type \"Unknown\""}}`,
+	}, results)
+	assert.Equal(t, []string{"first"}, echo.notes)
+
+	var failed []string
+	for _, failure := range failures {
+		failed = append(failed, failure.Error())
+	}
+	assert.Equal(t, []string{
+		"Echo: request 2 Fail: refused",
+		"Echo: request 3 Panic: handler panicked: boom",
+		"Echo: request 4 Block: app request timeout",
+		"Echo: request 5 Unknown: unsupported type \"Unknown\"",
+		"Echo: notification Unknown: unsupported type \"Unknown\"",
+	}, failed)
+	assert.True(t, errors.Is(failures[2], domain.ErrAppRequestTimeout))
+	select {
+	case <-echo.stopped:
+	case <-time.After(time.Second):
+		t.Fatal("handler isn't stopped at the timeout")
+	}
+}
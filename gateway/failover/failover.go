@@ -0,0 +1,311 @@
+// Package failover spreads one client over several network providers, every provider has its own core context
+// with its endpoints and access key. Functions of net and processing modules go to the active provider, the next
+// provider becomes active when the network fails with Unauthorized, GraphqlConnectionError or WebsocketDisconnected.
+// Other functions always go to the first provider, so signing boxes and other handles of them stay in one context.
+package failover
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/markgenuine/ever-client-go/domain"
+	clientgw "github.com/markgenuine/ever-client-go/gateway/client"
+	"github.com/markgenuine/ever-client-go/usecase/net"
+)
+
+// ErrNoProviders - gateway is created without providers.
+var ErrNoProviders = errors.New("failover: no providers")
+
+type (
+	// Provider - network provider, Endpoints and AccessKey replace the network config of the client.
+	Provider struct {
+		Name      string
+		Endpoints []string
+		AccessKey string
+	}
+
+	// Event - the active provider is switched because Method failed with Err.
+	Event struct {
+		From   string
+		To     string
+		Method string
+		Err    error
+		Time   time.Time
+	}
+
+	// Gateway - domain.ClientGateway over contexts of several providers.
+	Gateway struct {
+		*clientgw.MultiContext
+		providers []Provider
+		clients   []domain.ClientGateway
+
+		switchErrors []error
+		onSwitch     func(*Event)
+		logger       clientgw.Logger
+		clientOpts   []clientgw.Option
+		appOptions   []domain.AppObjectOption
+
+		mu       sync.Mutex
+		active   int
+		affinity *clientgw.Affinity
+	}
+
+	// Option configures Gateway.
+	Option func(*Gateway)
+)
+
+func (e *Event) String() string {
+	return fmt.Sprintf("provider %s -> %s after %s: %v", e.From, e.To, e.Method, e.Err)
+}
+
+// WithClientOptions sets options of the client gateway of every provider.
+func WithClientOptions(opts ...clientgw.Option) Option {
+	return func(g *Gateway) {
+		g.clientOpts = append(g.clientOpts, opts...)
+	}
+}
+
+// WithAppObjects sets options of the registry of app objects of the gateway, e.g. domain.WithAppRequestTimeout.
+func WithAppObjects(opts ...domain.AppObjectOption) Option {
+	return func(g *Gateway) {
+		g.appOptions = append(g.appOptions, opts...)
+	}
+}
+
+// WithLogger sets logger of switches of the active provider, they are written to the standard logger by default.
+func WithLogger(logger clientgw.Logger) Option {
+	return func(g *Gateway) {
+		g.logger = logger
+	}
+}
+
+// WithSwitchHook sets hook which gets every switch of the active provider, switches are logged by default.
+func WithSwitchHook(onSwitch func(*Event)) Option {
+	return func(g *Gateway) {
+		g.onSwitch = onSwitch
+	}
+}
+
+// WithSwitchErrors replaces errors which switch the active provider, they are matched with errors.Is. By default
+// they are net.ErrUnauthorized, net.ErrGraphqlConnectionError and net.ErrWebsocketDisconnected.
+func WithSwitchErrors(errs ...error) Option {
+	return func(g *Gateway) {
+		g.switchErrors = errs
+	}
+}
+
+// NewGateway creates context for every provider with config, the first provider is active.
+func NewGateway(config domain.ClientConfig, providers []Provider, opts ...Option) (*Gateway, error) {
+	if len(providers) == 0 {
+		return nil, ErrNoProviders
+	}
+
+	g := &Gateway{
+		providers:    append([]Provider(nil), providers...),
+		switchErrors: []error{net.ErrUnauthorized, net.ErrGraphqlConnectionError, net.ErrWebsocketDisconnected},
+		logger:       clientgw.NewStdLogger(nil, clientgw.LevelWarn),
+		affinity:     clientgw.NewAffinity(),
+	}
+	g.onSwitch = g.logSwitch
+	for _, opt := range opts {
+		opt(g)
+	}
+	g.MultiContext = clientgw.NewMultiContext(g, g.affinity, g.contexts, g.appOptions...)
+
+	for i := range g.providers {
+		if g.providers[i].Name == "" {
+			g.providers[i].Name = fmt.Sprintf("#%d", i)
+		}
+		client, err := clientgw.NewClientGateway(ProviderConfig(config, g.providers[i]), g.clientOpts...)
+		if err != nil {
+			g.Destroy()
+			return nil, fmt.Errorf("failover: provider %s: %w", g.providers[i].Name, err)
+		}
+		g.clients = append(g.clients, client)
+	}
+
+	return g, nil
+}
+
+// ProviderConfig returns config with network of provider, other sections are shared.
+func ProviderConfig(config domain.ClientConfig, provider Provider) domain.ClientConfig {
+	network := domain.NetworkConfig{}
+	if config.Network != nil {
+		network = *config.Network
+	}
+	network.ServerAddress = ""
+	network.Endpoints = append([]string(nil), provider.Endpoints...)
+	network.AccessKey = provider.AccessKey
+	config.Network = &network
+
+	return config
+}
+
+// Active returns name of the provider which gets functions of net and processing modules.
+func (g *Gateway) Active() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.providers[g.active].Name
+}
+
+// route returns provider of method, params with handles of its context and whether the call may go to another
+// provider on failure.
+func (g *Gateway) route(method string, params []byte) (int, []byte, bool) {
+	if provider, sent, isOwned := g.affinity.Owner(method, params); isOwned {
+		return provider, sent, false
+	}
+	if !strings.HasPrefix(method, "net.") && !strings.HasPrefix(method, "processing.") {
+		return 0, params, false
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.active, params, true
+}
+
+// switchFrom makes the provider after from active, it does nothing when another call switched it already.
+func (g *Gateway) switchFrom(from int, method string, err error) {
+	g.mu.Lock()
+	if g.active != from || len(g.clients) < 2 {
+		g.mu.Unlock()
+		return
+	}
+	g.active = (from + 1) % len(g.clients)
+	event := &Event{
+		From:   g.providers[from].Name,
+		To:     g.providers[g.active].Name,
+		Method: method,
+		Err:    err,
+		Time:   time.Now(),
+	}
+	g.mu.Unlock()
+
+	if g.onSwitch != nil {
+		g.onSwitch(event)
+	}
+}
+
+// logSwitch - the default hook of switches.
+func (g *Gateway) logSwitch(event *Event) {
+	g.logger.Log(clientgw.LevelWarn, "failover: provider is switched", clientgw.Field{Key: "from", Value: event.From},
+		clientgw.Field{Key: "to", Value: event.To}, clientgw.Field{Key: "method", Value: event.Method},
+		clientgw.Field{Key: "error", Value: event.Err})
+}
+
+func (g *Gateway) isSwitchError(err error) bool {
+	for _, target := range g.switchErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetResponseContext calls method on its provider. When the network of the active provider fails the next one
+// becomes active and the call is repeated there, every provider is tried once. Functions of
+// clientgw.NonIdempotentFunctions, e.g. processing.send_messages, aren't repeated: they may have been sent already,
+// so only the active provider is switched and the error is returned.
+func (g *Gateway) GetResponseContext(ctx context.Context, method string, paramIn interface{}) ([]byte, error) {
+	params, err := clientgw.MarshalParams(paramIn)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, sent, canSwitch := g.route(method, params)
+	for attempt := 0; ; attempt++ {
+		data, err := g.clients[provider].GetResponseContext(ctx, method, json.RawMessage(sent))
+		if err == nil {
+			return g.affinity.Track(provider, method, params, data), nil
+		}
+		if !canSwitch || !g.isSwitchError(err) || attempt == len(g.clients)-1 || ctx.Err() != nil {
+			return nil, err
+		}
+		g.switchFrom(provider, method, err)
+		if clientgw.NonIdempotentFunctions[method] {
+			return nil, err
+		}
+		provider, sent, canSwitch = g.route(method, params)
+	}
+}
+
+// RequestContext starts method on its provider. The stream isn't repeated on another provider: its network
+// failure only switches the active provider for the next calls.
+func (g *Gateway) RequestContext(ctx context.Context, method string, paramIn interface{}) (*domain.Stream, error) {
+	params, err := clientgw.MarshalParams(paramIn)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, sent, canSwitch := g.route(method, params)
+	stream, err := g.clients[provider].RequestContext(ctx, method, json.RawMessage(sent))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan *domain.ClientResponse, 1)
+	go func() {
+		defer close(out)
+		for {
+			r, err := stream.Next(ctx)
+			if err != nil {
+				return
+			}
+			if r.Error != nil && canSwitch && g.isSwitchError(r.Error) {
+				g.switchFrom(provider, method, r.Error)
+			}
+			r = g.affinity.TrackResponse(provider, method, params, r)
+			select {
+			case out <- r:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return stream.Derive(out), nil
+}
+
+// ResolveAppRequest resolves app request in the context which sent it.
+func (g *Gateway) ResolveAppRequest(params *domain.ParamsOfResolveAppRequest) error {
+	provider, params := g.affinity.ResolveOwner(params)
+	return g.clients[provider].ResolveAppRequest(params)
+}
+
+func (g *Gateway) GetAPIReference() (*domain.ResultOfGetAPIReference, error) {
+	return g.clients[0].GetAPIReference()
+}
+
+func (g *Gateway) Version() (*domain.ResultOfVersion, error) {
+	return g.clients[0].Version()
+}
+
+// Config returns config of the active provider.
+func (g *Gateway) Config() (*domain.ClientConfig, error) {
+	g.mu.Lock()
+	active := g.active
+	g.mu.Unlock()
+
+	return g.clients[active].Config()
+}
+
+func (g *Gateway) GetBuildInfo() (*domain.ResultOfBuildInfo, error) {
+	return g.clients[0].GetBuildInfo()
+}
+
+// contexts returns gateways of contexts by their index.
+func (g *Gateway) contexts() map[int]domain.ClientGateway {
+	contexts := make(map[int]domain.ClientGateway, len(g.clients))
+	for i, client := range g.clients {
+		contexts[i] = client
+	}
+
+	return contexts
+}
@@ -0,0 +1,375 @@
+package failover
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/markgenuine/ever-client-go/domain"
+	clientgw "github.com/markgenuine/ever-client-go/gateway/client"
+	"github.com/markgenuine/ever-client-go/usecase/net"
+	"github.com/stretchr/testify/assert"
+)
+
+// graphqlServer - fake GraphQL endpoint which accepts one access key, it answers 401 when it is down.
+type graphqlServer struct {
+	*httptest.Server
+	name    string
+	queries int32
+	down    int32
+}
+
+func newGraphqlServer(name, accessKey string) *graphqlServer {
+	s := &graphqlServer{name: name}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&s.queries, 1)
+		if r.Header.Get("X-Access-Key") != accessKey || atomic.LoadInt32(&s.down) == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"result":{"data":{"server":%q}}}`, name)
+	}))
+
+	return s
+}
+
+// graphqlTransport - core library which sends net.query to the endpoint of context like SDK does: unauthorized
+// requests fail with code 615, unreachable endpoints with 617. Contexts of other calls are recorded.
+type graphqlTransport struct {
+	mu       sync.Mutex
+	networks map[uint32]domain.NetworkConfig
+	calls    []string
+}
+
+func (g *graphqlTransport) CreateContext(config []byte) ([]byte, error) {
+	clientConfig := domain.ClientConfig{}
+	if err := json.Unmarshal(config, &clientConfig); err != nil {
+		return nil, err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	context := uint32(len(g.networks) + 1)
+	g.networks[context] = *clientConfig.Network
+
+	return []byte(fmt.Sprintf(`{"result":%d}`, context)), nil
+}
+
+func (g *graphqlTransport) DestroyContext(uint32) {}
+
+func (g *graphqlTransport) Request(context uint32, method string, paramsJSON []byte, handler clientgw.ResponseHandler) error {
+	g.mu.Lock()
+	network := g.networks[context]
+	g.calls = append(g.calls, fmt.Sprintf("%d %s %s", context, method, paramsJSON))
+	g.mu.Unlock()
+
+	go func() {
+		switch method {
+		case "net.query":
+			data, code := g.query(network)
+			handler(data, code, true)
+		case "net.subscribe_collection":
+			handler([]byte(`{"handle":3}`), domain.ResponseSuccess, false)
+			handler([]byte(`{"result":{}}`), domain.ResponseCustom, false)
+			handler([]byte(`{"code":610,"message":"Websocket disconnected"}`), domain.ResponseError, true)
+		case "net.create_block_iterator":
+			handler([]byte(`{"handle":7}`), domain.ResponseSuccess, true)
+		case "crypto.get_signing_box":
+			handler([]byte(`{"handle":5}`), domain.ResponseSuccess, true)
+		case "processing.send_messages":
+			if context == 1 {
+				handler([]byte(`{"code":610,"message":"Websocket disconnected"}`), domain.ResponseError, true)
+				return
+			}
+			handler([]byte(`{"messages":[]}`), domain.ResponseSuccess, true)
+		default:
+			handler([]byte(`{}`), domain.ResponseSuccess, true)
+		}
+	}()
+
+	return nil
+}
+
+func (g *graphqlTransport) query(network domain.NetworkConfig) ([]byte, uint32) {
+	request, _ := http.NewRequest(http.MethodPost, network.Endpoints[0], bytes.NewReader([]byte(`{"query":"{info{version}}"}`)))
+	request.Header.Set("X-Access-Key", network.AccessKey)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return []byte(`{"code":617,"message":"Graphql connection error"}`), domain.ResponseError
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusUnauthorized {
+		return []byte(`{"code":615,"message":"Unauthorized"}`), domain.ResponseError
+	}
+
+	buf := &bytes.Buffer{}
+	_, _ = buf.ReadFrom(response.Body)
+
+	return buf.Bytes(), domain.ResponseSuccess
+}
+
+// count returns count of calls of method in all contexts.
+func (g *graphqlTransport) count(method string) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	count := 0
+	for _, call := range g.calls {
+		if strings.Fields(call)[1] == method {
+			count++
+		}
+	}
+
+	return count
+}
+
+// contextOf returns context of the last call of method.
+func (g *graphqlTransport) contextOf(method string) uint32 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var context uint32
+	for _, call := range g.calls {
+		var (
+			c uint32
+			m string
+		)
+		if _, err := fmt.Sscanf(call, "%d %s", &c, &m); err == nil && m == method {
+			context = c
+		}
+	}
+
+	return context
+}
+
+// recordLogger keeps records with fields except error.
+type recordLogger struct {
+	sync.Mutex
+	records []string
+}
+
+func (r *recordLogger) Log(level clientgw.Level, msg string, fields ...clientgw.Field) {
+	r.Lock()
+	defer r.Unlock()
+
+	record := level.String() + " " + msg
+	for _, field := range fields {
+		if field.Key != "error" {
+			record += fmt.Sprintf(" %s=%v", field.Key, field.Value)
+		}
+	}
+	r.records = append(r.records, record)
+}
+
+func TestFailover(t *testing.T) {
+	serverA, serverB := newGraphqlServer("a", "key-a"), newGraphqlServer("b", "key-b")
+	defer serverA.Close()
+	defer serverB.Close()
+
+	newGateway := func(t *testing.T, providers ...Provider) (*Gateway, *graphqlTransport, *[]*Event) {
+		transport := &graphqlTransport{networks: make(map[uint32]domain.NetworkConfig)}
+		events := &[]*Event{}
+		var mu sync.Mutex
+		g, err := NewGateway(domain.NewDefaultConfig("", nil, ""), providers,
+			WithClientOptions(clientgw.WithTransport(transport)),
+			WithSwitchHook(func(event *Event) {
+				mu.Lock()
+				defer mu.Unlock()
+				*events = append(*events, event)
+			}))
+		assert.NoError(t, err)
+		return g, transport, events
+	}
+	query := func(g *Gateway) (string, error) {
+		result := &struct {
+			Result struct {
+				Data struct {
+					Server string `json:"server"`
+				} `json:"data"`
+			} `json:"result"`
+		}{}
+		err := g.GetResult("net.query", map[string]string{"query": "{info{version}}"}, result)
+		return result.Result.Data.Server, err
+	}
+	providerA := Provider{Name: "a", Endpoints: []string{serverA.URL}, AccessKey: "key-a"}
+	providerB := Provider{Name: "b", Endpoints: []string{serverB.URL}, AccessKey: "key-b"}
+
+	t.Run("Healthy", func(t *testing.T) {
+		g, _, events := newGateway(t, providerA, providerB)
+		defer g.Destroy()
+
+		server, err := query(g)
+		assert.NoError(t, err)
+		assert.Equal(t, "a", server)
+		assert.Equal(t, "a", g.Active())
+		assert.Empty(t, *events)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		wrongKey := providerA
+		wrongKey.AccessKey = "expired"
+		g, _, events := newGateway(t, wrongKey, providerB)
+		defer g.Destroy()
+
+		server, err := query(g)
+		assert.NoError(t, err)
+		assert.Equal(t, "b", server)
+		assert.Equal(t, "b", g.Active())
+		if assert.Len(t, *events, 1) {
+			assert.Equal(t, "a", (*events)[0].From)
+			assert.Equal(t, "b", (*events)[0].To)
+			assert.Equal(t, "net.query", (*events)[0].Method)
+			assert.True(t, errors.Is((*events)[0].Err, net.ErrUnauthorized))
+		}
+
+		queries := atomic.LoadInt32(&serverA.queries)
+		server, err = query(g)
+		assert.NoError(t, err)
+		assert.Equal(t, "b", server)
+		assert.Equal(t, queries, atomic.LoadInt32(&serverA.queries))
+	})
+
+	t.Run("ConnectionError", func(t *testing.T) {
+		unreachable := httptest.NewServer(http.NotFoundHandler())
+		unreachable.Close()
+		g, _, events := newGateway(t, Provider{Name: "down", Endpoints: []string{unreachable.URL}}, providerB)
+		defer g.Destroy()
+
+		server, err := query(g)
+		assert.NoError(t, err)
+		assert.Equal(t, "b", server)
+		if assert.Len(t, *events, 1) {
+			assert.True(t, errors.Is((*events)[0].Err, net.ErrGraphqlConnectionError))
+		}
+	})
+
+	t.Run("AllFailed", func(t *testing.T) {
+		g, _, events := newGateway(t,
+			Provider{Name: "a", Endpoints: []string{serverA.URL}, AccessKey: "wrong"},
+			Provider{Name: "b", Endpoints: []string{serverB.URL}, AccessKey: "wrong"})
+		defer g.Destroy()
+
+		_, err := query(g)
+		assert.True(t, errors.Is(err, net.ErrUnauthorized))
+		assert.Len(t, *events, 1)
+	})
+
+	t.Run("NonIdempotent", func(t *testing.T) {
+		g, transport, events := newGateway(t, providerA, providerB)
+		defer g.Destroy()
+
+		_, err := g.GetResponse("processing.send_messages", map[string]interface{}{"messages": []string{"te6"}})
+		assert.True(t, errors.Is(err, net.ErrWebsocketDisconnected))
+		assert.Equal(t, 1, transport.count("processing.send_messages"))
+		assert.Equal(t, "b", g.Active())
+		assert.Len(t, *events, 1)
+
+		_, err = g.GetResponse("processing.send_messages", map[string]interface{}{"messages": []string{"te6"}})
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(2), transport.contextOf("processing.send_messages"))
+	})
+
+	t.Run("Routing", func(t *testing.T) {
+		g, transport, _ := newGateway(t, providerA, providerB)
+		defer g.Destroy()
+
+		iterator := &struct {
+			Handle int `json:"handle"`
+		}{}
+		assert.NoError(t, g.GetResult("net.create_block_iterator", map[string]interface{}{}, iterator))
+		assert.Equal(t, uint32(1), transport.contextOf("net.create_block_iterator"))
+
+		atomic.StoreInt32(&serverA.down, 1)
+		defer atomic.StoreInt32(&serverA.down, 0)
+		server, err := query(g)
+		assert.NoError(t, err)
+		assert.Equal(t, "b", server)
+
+		// The iterator lives in the context of provider a.
+		assert.NoError(t, g.GetResult("net.iterator_next", iterator, &struct{}{}))
+		assert.Equal(t, uint32(1), transport.contextOf("net.iterator_next"))
+		assert.NoError(t, g.GetResult("net.remove_iterator", iterator, &struct{}{}))
+		assert.Equal(t, uint32(1), transport.contextOf("net.remove_iterator"))
+		assert.NoError(t, g.GetResult("net.iterator_next", iterator, &struct{}{}))
+		assert.Equal(t, uint32(2), transport.contextOf("net.iterator_next"))
+
+		// Functions of other modules stay with the first provider.
+		assert.NoError(t, g.GetResult("crypto.generate_random_bytes", map[string]int{"length": 8}, &struct{}{}))
+		assert.Equal(t, uint32(1), transport.contextOf("crypto.generate_random_bytes"))
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		g, transport, events := newGateway(t, providerA, providerB)
+		defer g.Destroy()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		stream, err := g.RequestContext(ctx, "net.subscribe_collection", map[string]string{"collection": "blocks"})
+		assert.NoError(t, err)
+		result := &domain.ResultOfSubscribeCollection{}
+		assert.NoError(t, stream.Result(result))
+		for range stream.Events() {
+		}
+		assert.Equal(t, "b", g.Active())
+		if assert.Len(t, *events, 1) {
+			assert.True(t, errors.Is((*events)[0].Err, net.ErrWebsocketDisconnected))
+		}
+
+		assert.NoError(t, g.GetResult("net.unsubscribe", result, &struct{}{}))
+		assert.Equal(t, uint32(1), transport.contextOf("net.unsubscribe"))
+	})
+
+	t.Run("SigningBox", func(t *testing.T) {
+		g, transport, _ := newGateway(t, providerA, providerB)
+		defer g.Destroy()
+
+		box := &domain.RegisteredSigningBox{}
+		assert.NoError(t, g.GetResult("crypto.get_signing_box", map[string]string{}, box))
+		atomic.StoreInt32(&serverA.down, 1)
+		defer atomic.StoreInt32(&serverA.down, 0)
+		_, err := query(g)
+		assert.NoError(t, err)
+		assert.Equal(t, "b", g.Active())
+
+		// The signing box lives in the context of provider a, processing with it follows the box.
+		params := map[string]interface{}{
+			"message_encode_params": map[string]interface{}{
+				"signer": map[string]interface{}{"type": "SigningBox", "handle": box.Handle},
+			},
+		}
+		assert.NoError(t, g.GetResult("processing.process_message", params, &struct{}{}))
+		assert.Equal(t, uint32(1), transport.contextOf("processing.process_message"))
+		assert.NoError(t, g.GetResult("processing.wait_for_transaction", map[string]string{}, &struct{}{}))
+		assert.Equal(t, uint32(2), transport.contextOf("processing.wait_for_transaction"))
+	})
+
+	t.Run("Logger", func(t *testing.T) {
+		logger := &recordLogger{}
+		g, err := NewGateway(domain.NewDefaultConfig("", nil, ""), []Provider{
+			{Name: "a", Endpoints: []string{serverA.URL}, AccessKey: "expired"}, providerB,
+		}, WithClientOptions(clientgw.WithTransport(&graphqlTransport{networks: make(map[uint32]domain.NetworkConfig)})),
+			WithLogger(logger))
+		assert.NoError(t, err)
+		defer g.Destroy()
+
+		_, err = query(g)
+		assert.NoError(t, err)
+		logger.Lock()
+		defer logger.Unlock()
+		assert.Equal(t, []string{"warn failover: provider is switched from=a to=b method=net.query"}, logger.records)
+	})
+
+	t.Run("NoProviders", func(t *testing.T) {
+		_, err := NewGateway(domain.NewDefaultConfig("", nil, ""), nil)
+		assert.True(t, errors.Is(err, ErrNoProviders))
+	})
+}
@@ -0,0 +1,371 @@
+// Package libmanager downloads libton_client of the given SDK version for a platform into the user cache,
+// artifacts are verified by SHA-256 of the pinned manifest.
+package libmanager
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// DefaultBaseURL - server with SDK binaries.
+const DefaultBaseURL = "https://binaries.tonlabs.io/"
+
+var (
+	// ErrUnsupportedTarget - there are no SDK binaries for the platform.
+	ErrUnsupportedTarget = errors.New("unsupported target")
+	// ErrNotPinned - checksum of the artifact isn't in the manifest.
+	ErrNotPinned = errors.New("artifact is not pinned in manifest")
+)
+
+// artifacts - names of SDK binaries by target, %s is version with underscores. There is no windows/arm64 target:
+// no artifact of it is confirmed on binaries.tonlabs.io, so it is unsupported until one is.
+var artifacts = map[Target]string{
+	{GOOS: "darwin", GOARCH: "amd64"}:  "tonclient_%s_darwin.gz",
+	{GOOS: "darwin", GOARCH: "arm64"}:  "tonclient_%s_darwin_arm64.gz",
+	{GOOS: "linux", GOARCH: "amd64"}:   "tonclient_%s_linux.gz",
+	{GOOS: "linux", GOARCH: "arm64"}:   "tonclient_%s_linux_arm64.gz",
+	{GOOS: "windows", GOARCH: "amd64"}: "tonclient_%s_win32_dll.gz",
+}
+
+type (
+	// Target - platform of the library.
+	Target struct {
+		GOOS   string
+		GOARCH string
+	}
+
+	// Manifest - SHA-256 of artifacts in hex by version and target, e.g. manifest["1.47.0"]["linux/amd64"].
+	Manifest map[string]map[string]string
+
+	// ChecksumError - artifact doesn't match the manifest.
+	ChecksumError struct {
+		Artifact string
+		Expected string
+		Actual   string
+	}
+
+	// Manager installs libraries into the cache.
+	Manager struct {
+		baseURL   string
+		mirrorDir string
+		cacheDir  string
+		manifest  Manifest
+		client    *http.Client
+	}
+
+	// Option configures Manager.
+	Option func(*Manager)
+)
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("checksum of %s is %s, manifest pins %s", e.Artifact, e.Actual, e.Expected)
+}
+
+// HostTarget returns platform of the running program.
+func HostTarget() Target {
+	return Target{GOOS: runtime.GOOS, GOARCH: runtime.GOARCH}
+}
+
+// ParseTarget parses target in form GOOS/GOARCH.
+func ParseTarget(target string) (Target, error) {
+	parts := strings.Split(target, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return Target{}, fmt.Errorf("target %q: %w", target, ErrUnsupportedTarget)
+	}
+
+	return Target{GOOS: parts[0], GOARCH: parts[1]}, nil
+}
+
+// Targets returns all platforms with SDK binaries.
+func Targets() []Target {
+	targets := make([]Target, 0, len(artifacts))
+	for target := range artifacts {
+		targets = append(targets, target)
+	}
+
+	return targets
+}
+
+func (t Target) String() string {
+	return t.GOOS + "/" + t.GOARCH
+}
+
+// Artifact returns name of the compressed library of version on the server.
+func (t Target) Artifact(version string) (string, error) {
+	name, isFound := artifacts[t]
+	if !isFound {
+		return "", fmt.Errorf("%s: %w", t, ErrUnsupportedTarget)
+	}
+
+	return fmt.Sprintf(name, strings.ReplaceAll(version, ".", "_")), nil
+}
+
+// LibraryName returns file name of libton_client on goos.
+func LibraryName(goos string) string {
+	switch goos {
+	case "darwin":
+		return "libton_client.dylib"
+	case "windows":
+		return "ton_client.dll"
+	default:
+		return "libton_client.so"
+	}
+}
+
+// CacheDir returns directory of libraries of version in the user cache.
+func CacheDir(version string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "ever-client-go", version), nil
+}
+
+// LoadManifest reads manifest from JSON file.
+func LoadManifest(path string) (Manifest, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := Manifest{}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("manifest %s: %w", path, err)
+	}
+
+	return manifest, nil
+}
+
+// Save writes manifest to JSON file.
+func (m Manifest) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Checksum returns pinned SHA-256 of the artifact of version for target.
+func (m Manifest) Checksum(version string, target Target) (string, bool) {
+	checksum, isFound := m[version][target.String()]
+	return checksum, isFound
+}
+
+func (m Manifest) pin(version string, target Target, checksum string) {
+	if m[version] == nil {
+		m[version] = make(map[string]string)
+	}
+	m[version][target.String()] = checksum
+}
+
+// WithBaseURL sets server with SDK binaries, DefaultBaseURL by default.
+func WithBaseURL(baseURL string) Option {
+	return func(m *Manager) {
+		m.baseURL = baseURL
+	}
+}
+
+// WithMirror takes artifacts from directory instead of the server, for offline installs.
+func WithMirror(dir string) Option {
+	return func(m *Manager) {
+		m.mirrorDir = dir
+	}
+}
+
+// WithCacheDir installs libraries to dir/<version> instead of CacheDir.
+func WithCacheDir(dir string) Option {
+	return func(m *Manager) {
+		m.cacheDir = dir
+	}
+}
+
+// WithManifest sets manifest which pins checksums of artifacts, Pinned by default.
+func WithManifest(manifest Manifest) Option {
+	return func(m *Manager) {
+		m.manifest = manifest
+	}
+}
+
+// WithHTTPClient sets client of downloads, http.DefaultClient by default.
+func WithHTTPClient(client *http.Client) Option {
+	return func(m *Manager) {
+		m.client = client
+	}
+}
+
+// NewManager returns manager of libraries.
+func NewManager(opts ...Option) *Manager {
+	m := &Manager{
+		baseURL:  DefaultBaseURL,
+		manifest: Pinned,
+		client:   http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Dir returns directory of libraries of version.
+func (m *Manager) Dir(version string) (string, error) {
+	if m.cacheDir != "" {
+		return filepath.Join(m.cacheDir, version), nil
+	}
+
+	return CacheDir(version)
+}
+
+// Install returns path of the library of version for target, it is downloaded and verified when it isn't
+// in the cache yet.
+func (m *Manager) Install(ctx context.Context, version string, target Target) (string, error) {
+	artifact, err := target.Artifact(version)
+	if err != nil {
+		return "", err
+	}
+	checksum, isFound := m.manifest.Checksum(version, target)
+	if !isFound {
+		return "", fmt.Errorf("%s %s: %w", artifact, target, ErrNotPinned)
+	}
+
+	dir, err := m.Dir(version)
+	if err != nil {
+		return "", err
+	}
+	if target != HostTarget() {
+		dir = filepath.Join(dir, target.GOOS+"_"+target.GOARCH)
+	}
+	path := filepath.Join(dir, LibraryName(target.GOOS))
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	data, err := m.fetch(ctx, artifact)
+	if err != nil {
+		return "", err
+	}
+	if actual := sha256Hex(data); actual != checksum {
+		return "", &ChecksumError{Artifact: artifact, Expected: checksum, Actual: actual}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	return path, unpack(data, path)
+}
+
+// Pin downloads artifacts of version for targets and returns the manifest of manager with their checksums.
+// Checksums are trusted as they are, the manifest has to be reviewed before it is committed.
+func (m *Manager) Pin(ctx context.Context, version string, targets ...Target) (Manifest, error) {
+	pinned := Manifest{}
+	for v, checksums := range m.manifest {
+		for target, checksum := range checksums {
+			if pinned[v] == nil {
+				pinned[v] = make(map[string]string)
+			}
+			pinned[v][target] = checksum
+		}
+	}
+
+	for _, target := range targets {
+		artifact, err := target.Artifact(version)
+		if err != nil {
+			return nil, err
+		}
+		data, err := m.fetch(ctx, artifact)
+		if err != nil {
+			return nil, err
+		}
+		pinned.pin(version, target, sha256Hex(data))
+	}
+
+	return pinned, nil
+}
+
+func (m *Manager) fetch(ctx context.Context, artifact string) ([]byte, error) {
+	if m.mirrorDir != "" {
+		return ioutil.ReadFile(filepath.Join(m.mirrorDir, artifact))
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(m.baseURL, "/")+"/"+artifact, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := m.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download %s: %s", request.URL, response.Status)
+	}
+
+	return ioutil.ReadAll(response.Body)
+}
+
+// unpack writes decompressed data to path, the file appears only when it is complete.
+func unpack(data []byte, path string) error {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	file, err := ioutil.TempFile(filepath.Dir(path), ".download-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := io.Copy(file, reader); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(file.Name(), 0o755); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Env returns environment variables which make the library at path available to the cgo build and to the loader,
+// PATH on windows is prepended to PATH of the running program.
+func Env(path string, goos string) []string {
+	dir := filepath.Dir(path)
+	env := []string{fmt.Sprintf("CGO_LDFLAGS=-L%s -lton_client", dir)}
+	switch goos {
+	case "darwin":
+		env = append(env, "DYLD_LIBRARY_PATH="+dir)
+	case "windows":
+		env = append(env, "PATH="+dir+";"+os.Getenv("PATH"))
+	default:
+		env = append(env, "LD_LIBRARY_PATH="+dir)
+	}
+
+	return env
+}
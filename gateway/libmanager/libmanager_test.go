@@ -0,0 +1,152 @@
+package libmanager
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager(t *testing.T) {
+	const version = "1.47.0"
+	library := []byte("libton_client")
+	target := Target{GOOS: "linux", GOARCH: "amd64"}
+
+	buf := &bytes.Buffer{}
+	writer := gzip.NewWriter(buf)
+	_, _ = writer.Write(library)
+	_ = writer.Close()
+	artifact := buf.Bytes()
+
+	mirror, err := ioutil.TempDir("", "libmanager-mirror")
+	assert.NoError(t, err)
+	defer os.RemoveAll(mirror)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(mirror, "tonclient_1_47_0_linux.gz"), artifact, 0o644))
+
+	var requests int32
+	fileServer := http.FileServer(http.Dir(mirror))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		fileServer.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	manifest := Manifest{version: {target.String(): sha256Hex(artifact)}}
+
+	newCache := func(t *testing.T) string {
+		dir, err := ioutil.TempDir("", "libmanager-cache")
+		assert.NoError(t, err)
+		return dir
+	}
+
+	t.Run("Install", func(t *testing.T) {
+		cache := newCache(t)
+		defer os.RemoveAll(cache)
+		atomic.StoreInt32(&requests, 0)
+		manager := NewManager(WithBaseURL(server.URL), WithCacheDir(cache), WithManifest(manifest))
+
+		path, err := manager.Install(context.Background(), version, target)
+		assert.NoError(t, err)
+		data, err := ioutil.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, library, data)
+		assert.Equal(t, "libton_client.so", filepath.Base(path))
+
+		cached, err := manager.Install(context.Background(), version, target)
+		assert.NoError(t, err)
+		assert.Equal(t, path, cached)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("Mirror", func(t *testing.T) {
+		cache := newCache(t)
+		defer os.RemoveAll(cache)
+		atomic.StoreInt32(&requests, 0)
+		manager := NewManager(WithBaseURL(server.URL), WithMirror(mirror), WithCacheDir(cache), WithManifest(manifest))
+
+		path, err := manager.Install(context.Background(), version, target)
+		assert.NoError(t, err)
+		data, err := ioutil.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, library, data)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("ChecksumMismatch", func(t *testing.T) {
+		cache := newCache(t)
+		defer os.RemoveAll(cache)
+		wrong := Manifest{version: {target.String(): sha256Hex([]byte("other"))}}
+		manager := NewManager(WithBaseURL(server.URL), WithCacheDir(cache), WithManifest(wrong))
+
+		_, err := manager.Install(context.Background(), version, target)
+		checksumErr := &ChecksumError{}
+		assert.True(t, errors.As(err, &checksumErr))
+		assert.Equal(t, sha256Hex(artifact), checksumErr.Actual)
+		files, _ := ioutil.ReadDir(cache)
+		assert.Empty(t, files)
+	})
+
+	t.Run("NotPinned", func(t *testing.T) {
+		manager := NewManager(WithBaseURL(server.URL), WithManifest(Manifest{}))
+		_, err := manager.Install(context.Background(), version, target)
+		assert.True(t, errors.Is(err, ErrNotPinned))
+	})
+
+	t.Run("UnsupportedTarget", func(t *testing.T) {
+		manager := NewManager(WithManifest(manifest))
+		_, err := manager.Install(context.Background(), version, Target{GOOS: "plan9", GOARCH: "386"})
+		assert.True(t, errors.Is(err, ErrUnsupportedTarget))
+		_, err = ParseTarget("linux")
+		assert.True(t, errors.Is(err, ErrUnsupportedTarget))
+		_, err = manager.Install(context.Background(), version, Target{GOOS: "windows", GOARCH: "arm64"})
+		assert.True(t, errors.Is(err, ErrUnsupportedTarget))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		manager := NewManager(WithBaseURL(server.URL), WithManifest(Manifest{"1.0.0": {target.String(): "00"}}))
+		_, err := manager.Install(context.Background(), "1.0.0", target)
+		assert.Error(t, err)
+	})
+
+	t.Run("Pinned", func(t *testing.T) {
+		for v, checksums := range Pinned {
+			for _, target := range Targets() {
+				checksum, err := hex.DecodeString(checksums[target.String()])
+				assert.NoError(t, err, "%s %s", v, target)
+				assert.Len(t, checksum, sha256.Size, "%s %s", v, target)
+			}
+		}
+	})
+
+	t.Run("Pin", func(t *testing.T) {
+		manager := NewManager(WithMirror(mirror), WithManifest(Manifest{"1.0.0": {target.String(): "00"}}))
+		pinned, err := manager.Pin(context.Background(), version, target)
+		assert.NoError(t, err)
+		assert.Equal(t, Manifest{"1.0.0": {target.String(): "00"}, version: {target.String(): sha256Hex(artifact)}}, pinned)
+
+		file := filepath.Join(mirror, "manifest.json")
+		assert.NoError(t, pinned.Save(file))
+		loaded, err := LoadManifest(file)
+		assert.NoError(t, err)
+		assert.Equal(t, pinned, loaded)
+	})
+
+	t.Run("Env", func(t *testing.T) {
+		dir := filepath.Join("cache", version)
+		assert.Equal(t, []string{"CGO_LDFLAGS=-L" + dir + " -lton_client", "LD_LIBRARY_PATH=" + dir},
+			Env(filepath.Join(dir, LibraryName("linux")), "linux"))
+		assert.Equal(t, "DYLD_LIBRARY_PATH="+dir, Env(filepath.Join(dir, LibraryName("darwin")), "darwin")[1])
+		assert.Equal(t, "PATH="+dir+";"+os.Getenv("PATH"), Env(filepath.Join(dir, LibraryName("windows")), "windows")[1])
+	})
+}
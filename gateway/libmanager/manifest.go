@@ -0,0 +1,6 @@
+package libmanager
+
+// Pinned - checksums of SDK artifacts which are verified by the binding. A new version is pinned with
+// `make lib_pin VERSION=X.Y.Z`, the result is reviewed and copied here for every target of Targets.
+// 1.47.0 isn't pinned yet: its checksums have to be taken from binaries.tonlabs.io and reviewed.
+var Pinned = Manifest{}
@@ -0,0 +1,272 @@
+// Package limit restricts calls of the core library: count of calls in flight per prefix of function and rate of
+// calls per function. Limiter is installed as interceptor, one limiter may serve several contexts, e.g. a pool:
+//
+//	limiter, err := limit.NewLimiter(limit.WithConcurrency("net.", 8), limit.WithRate("net.query_collection", 20, 5))
+//	ever, err := goever.NewEverWithConfig(config, clientgw.WithInterceptors(limiter.Interceptor))
+package limit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/markgenuine/ever-client-go/domain"
+	clientgw "github.com/markgenuine/ever-client-go/gateway/client"
+)
+
+// Errors of calls rejected by Limiter, use errors.Is to check them.
+var (
+	ErrConcurrencyLimit = errors.New("limit: too many calls in flight")
+	ErrRateLimit        = errors.New("limit: rate of calls is exceeded")
+)
+
+// ErrInvalidLimit - limiter is created with a limit which isn't positive.
+var ErrInvalidLimit = errors.New("limit: limit must be positive")
+
+type (
+	// Stats - state of a limit: calls which hold it, callers waiting for it and calls rejected over it. Callers
+	// whose ctx is done while they wait aren't counted as rejected.
+	Stats struct {
+		InFlight int
+		Queued   int
+		Rejected uint64
+	}
+
+	// concurrency - calls in flight of functions with prefix.
+	concurrency struct {
+		prefix string
+		slots  chan struct{}
+		queued int
+		reject uint64
+	}
+
+	// bucket - token bucket of functions with prefix, tokens may be negative: they are reserved by waiting callers.
+	bucket struct {
+		prefix  string
+		rate    float64
+		burst   float64
+		tokens  float64
+		updated time.Time
+		queued  int
+		reject  uint64
+	}
+
+	// Limiter - limits of calls, see Interceptor.
+	Limiter struct {
+		mu          sync.Mutex
+		concurrency []*concurrency
+		buckets     []*bucket
+		failFast    bool
+		err         error
+	}
+
+	// Option configures Limiter.
+	Option func(*Limiter)
+)
+
+// WithConcurrency limits calls in flight of functions which start with prefix, e.g. "net." or "net.query".
+// A call is in flight until its result, events of subscriptions which follow it don't hold the limit.
+func WithConcurrency(prefix string, max int) Option {
+	return func(l *Limiter) {
+		if max < 1 {
+			l.invalid(fmt.Errorf("concurrency of %q is %d: %w", prefix, max, ErrInvalidLimit))
+			return
+		}
+		l.concurrency = append(l.concurrency, &concurrency{prefix: prefix, slots: make(chan struct{}, max)})
+	}
+}
+
+// WithRate limits calls of functions which start with prefix to perSecond, burst calls may be made at once.
+func WithRate(prefix string, perSecond float64, burst int) Option {
+	return func(l *Limiter) {
+		if !(perSecond > 0) {
+			l.invalid(fmt.Errorf("rate of %q is %v: %w", prefix, perSecond, ErrInvalidLimit))
+			return
+		}
+		if burst < 1 {
+			burst = 1
+		}
+		l.buckets = append(l.buckets, &bucket{prefix: prefix, rate: perSecond, burst: float64(burst), tokens: float64(burst)})
+	}
+}
+
+// WithFailFast rejects calls over the limit at once instead of waiting for it.
+func WithFailFast() Option {
+	return func(l *Limiter) {
+		l.failFast = true
+	}
+}
+
+// NewLimiter returns limiter with limits of opts. When several limits of one kind match a function, the one with
+// the longest prefix is used. Limits which aren't positive are returned as ErrInvalidLimit.
+func NewLimiter(opts ...Option) (*Limiter, error) {
+	l := &Limiter{}
+	for _, opt := range opts {
+		opt(l)
+	}
+	if l.err != nil {
+		return nil, l.err
+	}
+	sort.SliceStable(l.concurrency, func(i, j int) bool { return len(l.concurrency[i].prefix) > len(l.concurrency[j].prefix) })
+	sort.SliceStable(l.buckets, func(i, j int) bool { return len(l.buckets[i].prefix) > len(l.buckets[j].prefix) })
+
+	return l, nil
+}
+
+// invalid keeps the first invalid limit of options.
+func (l *Limiter) invalid(err error) {
+	if l.err == nil {
+		l.err = err
+	}
+}
+
+// Interceptor - clientgw.Interceptor which waits for limits of method or rejects it. Callers wait until ctx is
+// done, the call is rejected at once with WithFailFast or when the deadline of ctx comes before its turn.
+func (l *Limiter) Interceptor(ctx context.Context, method string, params []byte, invoker clientgw.Invoker) (<-chan *domain.ClientResponse, error) {
+	if b := l.bucket(method); b != nil {
+		if err := l.take(ctx, b); err != nil {
+			return nil, fmt.Errorf("%s: %w", method, err)
+		}
+	}
+
+	c := l.concurrencyOf(method)
+	if c == nil {
+		return invoker(ctx, method, params)
+	}
+	if err := l.acquire(ctx, c); err != nil {
+		return nil, fmt.Errorf("%s: %w", method, err)
+	}
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() { <-c.slots })
+	}
+	responses, err := invoker(ctx, method, params)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	return clientgw.TapResponses(ctx, responses, func(r *domain.ClientResponse) {
+		if r.Code == domain.ResponseSuccess || r.Code == domain.ResponseError {
+			release()
+		}
+	}, func(error) {
+		release()
+	}), nil
+}
+
+// Stats returns state of limits by their prefixes, rates are named "rate " + prefix.
+func (l *Limiter) Stats() map[string]Stats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stats := make(map[string]Stats, len(l.concurrency)+len(l.buckets))
+	for _, c := range l.concurrency {
+		stats[c.prefix] = Stats{InFlight: len(c.slots), Queued: c.queued, Rejected: c.reject}
+	}
+	for _, b := range l.buckets {
+		stats["rate "+b.prefix] = Stats{Queued: b.queued, Rejected: b.reject}
+	}
+
+	return stats
+}
+
+func (l *Limiter) concurrencyOf(method string) *concurrency {
+	for _, c := range l.concurrency {
+		if strings.HasPrefix(method, c.prefix) {
+			return c
+		}
+	}
+
+	return nil
+}
+
+func (l *Limiter) bucket(method string) *bucket {
+	for _, b := range l.buckets {
+		if strings.HasPrefix(method, b.prefix) {
+			return b
+		}
+	}
+
+	return nil
+}
+
+// acquire takes slot of c, it waits for it until ctx is done unless the limiter fails fast.
+func (l *Limiter) acquire(ctx context.Context, c *concurrency) error {
+	select {
+	case c.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	l.mu.Lock()
+	if l.failFast {
+		c.reject++
+		l.mu.Unlock()
+		return ErrConcurrencyLimit
+	}
+	c.queued++
+	l.mu.Unlock()
+	defer func() {
+		l.mu.Lock()
+		c.queued--
+		l.mu.Unlock()
+	}()
+
+	select {
+	case c.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// take takes token of b, the caller waits for its token until ctx is done unless the limiter fails fast.
+func (l *Limiter) take(ctx context.Context, b *bucket) error {
+	l.mu.Lock()
+	now := time.Now()
+	if !b.updated.IsZero() {
+		b.tokens += now.Sub(b.updated).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.updated = now
+	if b.tokens >= 1 {
+		b.tokens--
+		l.mu.Unlock()
+		return nil
+	}
+
+	wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	deadline, hasDeadline := ctx.Deadline()
+	if l.failFast || hasDeadline && deadline.Sub(now) < wait {
+		b.reject++
+		l.mu.Unlock()
+		return ErrRateLimit
+	}
+	b.tokens--
+	b.queued++
+	l.mu.Unlock()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		l.mu.Lock()
+		b.queued--
+		l.mu.Unlock()
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		b.queued--
+		b.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
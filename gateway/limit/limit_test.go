@@ -0,0 +1,182 @@
+package limit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/markgenuine/ever-client-go/domain"
+	clientgw "github.com/markgenuine/ever-client-go/gateway/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// holdTransport answers calls of net.hold when hold is closed, other calls at once. Subscriptions are never
+// finished.
+type holdTransport struct {
+	hold chan struct{}
+}
+
+func (h *holdTransport) CreateContext([]byte) ([]byte, error) {
+	return []byte(`{"result":1}`), nil
+}
+
+func (h *holdTransport) DestroyContext(uint32) {}
+
+func (h *holdTransport) Request(_ uint32, method string, _ []byte, handler clientgw.ResponseHandler) error {
+	go func() {
+		switch method {
+		case "net.hold":
+			<-h.hold
+			handler([]byte(`{}`), domain.ResponseSuccess, true)
+		case "net.subscribe_collection":
+			handler([]byte(`{"handle":1}`), domain.ResponseSuccess, false)
+		default:
+			handler([]byte(`{}`), domain.ResponseSuccess, true)
+		}
+	}()
+
+	return nil
+}
+
+func newClient(t *testing.T, limiter *Limiter) (domain.ClientGateway, *holdTransport) {
+	transport := &holdTransport{hold: make(chan struct{})}
+	client, err := clientgw.NewClientGateway(domain.NewDefaultConfig("", nil, ""),
+		clientgw.WithTransport(transport), clientgw.WithInterceptors(limiter.Interceptor))
+	assert.NoError(t, err)
+
+	return client, transport
+}
+
+// waitStats waits until stats of limit name are equal to want.
+func waitStats(t *testing.T, limiter *Limiter, name string, want Stats) {
+	for i := 0; i < 200 && limiter.Stats()[name] != want; i++ {
+		time.Sleep(5 * time.Millisecond)
+	}
+	assert.Equal(t, want, limiter.Stats()[name])
+}
+
+func TestLimiter(t *testing.T) {
+	t.Run("Queue", func(t *testing.T) {
+		limiter, err := NewLimiter(WithConcurrency("net.", 2))
+		assert.NoError(t, err)
+		client, transport := newClient(t, limiter)
+		defer client.Destroy()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := client.GetResponse("net.hold", nil)
+				assert.NoError(t, err)
+			}()
+		}
+		waitStats(t, limiter, "net.", Stats{InFlight: 2, Queued: 1})
+
+		_, err = client.GetResponse("crypto.generate_random_bytes", nil)
+		assert.NoError(t, err)
+
+		close(transport.hold)
+		wg.Wait()
+		assert.Equal(t, Stats{}, limiter.Stats()["net."])
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		limiter, err := NewLimiter(WithConcurrency("net.", 1))
+		assert.NoError(t, err)
+		client, transport := newClient(t, limiter)
+		defer client.Destroy()
+		defer close(transport.hold)
+
+		go func() {
+			_, _ = client.GetResponse("net.hold", nil)
+		}()
+		waitStats(t, limiter, "net.", Stats{InFlight: 1})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err = client.GetResponseContext(ctx, "net.query", nil)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Equal(t, Stats{InFlight: 1}, limiter.Stats()["net."])
+	})
+
+	t.Run("FailFast", func(t *testing.T) {
+		limiter, err := NewLimiter(WithConcurrency("net.", 1), WithConcurrency("net.query", 2), WithFailFast())
+		assert.NoError(t, err)
+		client, transport := newClient(t, limiter)
+		defer client.Destroy()
+		defer close(transport.hold)
+
+		go func() {
+			_, _ = client.GetResponse("net.hold", nil)
+		}()
+		waitStats(t, limiter, "net.", Stats{InFlight: 1})
+
+		_, err = client.GetResponse("net.wait_for_collection", nil)
+		assert.True(t, errors.Is(err, ErrConcurrencyLimit))
+		assert.Equal(t, Stats{InFlight: 1, Rejected: 1}, limiter.Stats()["net."])
+
+		_, err = client.GetResponse("net.query", nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Subscription", func(t *testing.T) {
+		limiter, err := NewLimiter(WithConcurrency("net.", 1), WithFailFast())
+		assert.NoError(t, err)
+		client, _ := newClient(t, limiter)
+		defer client.Destroy()
+
+		stream, err := client.Request("net.subscribe_collection", nil)
+		assert.NoError(t, err)
+		assert.NoError(t, stream.Result(nil))
+		waitStats(t, limiter, "net.", Stats{})
+
+		_, err = client.GetResponse("net.query", nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Rate", func(t *testing.T) {
+		limiter, err := NewLimiter(WithRate("net.query_collection", 20, 2))
+		assert.NoError(t, err)
+		client, _ := newClient(t, limiter)
+		defer client.Destroy()
+
+		started := time.Now()
+		for i := 0; i < 4; i++ {
+			_, err := client.GetResponse("net.query_collection", nil)
+			assert.NoError(t, err)
+		}
+		assert.True(t, time.Since(started) >= 90*time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = client.GetResponseContext(ctx, "net.query_collection", nil)
+		assert.True(t, errors.Is(err, ErrRateLimit))
+		assert.Equal(t, Stats{Rejected: 1}, limiter.Stats()["rate net.query_collection"])
+
+		_, err = client.GetResponse("net.query", nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("RateFailFast", func(t *testing.T) {
+		limiter, err := NewLimiter(WithRate("net.", 1, 1), WithFailFast())
+		assert.NoError(t, err)
+		client, _ := newClient(t, limiter)
+		defer client.Destroy()
+
+		_, err = client.GetResponse("net.query", nil)
+		assert.NoError(t, err)
+		_, err = client.GetResponse("net.query", nil)
+		assert.True(t, errors.Is(err, ErrRateLimit))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, opt := range []Option{WithConcurrency("net.", 0), WithRate("net.", 0, 1), WithRate("net.", -1, 1)} {
+			limiter, err := NewLimiter(WithConcurrency("crypto.", 1), opt)
+			assert.Nil(t, limiter)
+			assert.True(t, errors.Is(err, ErrInvalidLimit))
+		}
+	})
+}
@@ -0,0 +1,278 @@
+// Package metrics counts calls of the core library and renders them in the text format of Prometheus without
+// its client library. Collector is installed as interceptor and served as http.Handler:
+//
+//	collector := metrics.NewCollector()
+//	ever, err := goever.NewEverWithConfig(config, clientgw.WithInterceptors(collector.Interceptor))
+//	http.Handle("/metrics", collector)
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/markgenuine/ever-client-go/domain"
+	clientgw "github.com/markgenuine/ever-client-go/gateway/client"
+)
+
+// DefaultBuckets - upper bounds of latency histogram in seconds.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// subscribers - functions whose streams are open subscriptions after the result.
+var subscribers = map[string]bool{
+	"net.subscribe_collection": true,
+	"net.subscribe":            true,
+}
+
+type (
+	// histogram - latency of one function, counts are cumulative only when rendered.
+	histogram struct {
+		counts []uint64
+		sum    float64
+		count  uint64
+	}
+
+	// errorKey - function and code of error.
+	errorKey struct {
+		method string
+		code   int
+	}
+
+	// Collector - metrics of calls passed through Interceptor.
+	Collector struct {
+		mu            sync.Mutex
+		namespace     string
+		buckets       []float64
+		requests      map[string]uint64
+		errors        map[errorKey]uint64
+		latency       map[string]*histogram
+		inFlight      map[string]int64
+		subscriptions int64
+		events        map[string]uint64
+	}
+
+	// Option configures Collector.
+	Option func(*Collector)
+)
+
+// WithNamespace sets prefix of metric names, it is "ever" by default.
+func WithNamespace(namespace string) Option {
+	return func(c *Collector) {
+		c.namespace = namespace
+	}
+}
+
+// WithBuckets sets upper bounds of latency histogram in seconds, in ascending order.
+func WithBuckets(buckets ...float64) Option {
+	return func(c *Collector) {
+		c.buckets = append([]float64(nil), buckets...)
+	}
+}
+
+// NewCollector returns collector without calls.
+func NewCollector(opts ...Option) *Collector {
+	c := &Collector{
+		namespace: "ever",
+		buckets:   DefaultBuckets,
+		requests:  make(map[string]uint64),
+		errors:    make(map[errorKey]uint64),
+		latency:   make(map[string]*histogram),
+		inFlight:  make(map[string]int64),
+		events:    make(map[string]uint64),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Interceptor - clientgw.Interceptor which records the call. Latency is the time until the result, events of
+// processing are counted by their type, subscriptions are open from their result to the end of their stream.
+func (c *Collector) Interceptor(ctx context.Context, method string, params []byte, invoker clientgw.Invoker) (<-chan *domain.ClientResponse, error) {
+	started := time.Now()
+	c.mu.Lock()
+	c.requests[method]++
+	c.inFlight[method]++
+	c.mu.Unlock()
+
+	responses, err := invoker(ctx, method, params)
+	if err != nil {
+		c.finish(method, started, err)
+		return nil, err
+	}
+
+	var (
+		finished   bool
+		subscribed bool
+	)
+	return clientgw.TapResponses(ctx, responses, func(r *domain.ClientResponse) {
+		switch r.Code {
+		case domain.ResponseSuccess, domain.ResponseError:
+			if !finished {
+				finished = true
+				c.finish(method, started, r.Error)
+			}
+			if r.Code == domain.ResponseSuccess && subscribers[method] && !subscribed {
+				subscribed = true
+				c.mu.Lock()
+				c.subscriptions++
+				c.mu.Unlock()
+			}
+		case domain.ResponseCustom:
+			if strings.HasPrefix(method, "processing.") {
+				c.processingEvent(r.Data)
+			}
+		}
+	}, func(err error) {
+		if !finished {
+			c.finish(method, started, err)
+		}
+		if subscribed {
+			c.mu.Lock()
+			c.subscriptions--
+			c.mu.Unlock()
+		}
+	}), nil
+}
+
+// finish records the result of the call, err may be nil.
+func (c *Collector) finish(method string, started time.Time, err error) {
+	seconds := time.Since(started).Seconds()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inFlight[method]--
+	if err != nil {
+		c.errors[errorKey{method: method, code: domain.ErrorCode(err)}]++
+	}
+
+	h, isFound := c.latency[method]
+	if !isFound {
+		h = &histogram{counts: make([]uint64, len(c.buckets))}
+		c.latency[method] = h
+	}
+	for i, bound := range c.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// processingEvent counts event of processing by its type.
+func (c *Collector) processingEvent(data []byte) {
+	event := &struct {
+		Type string `json:"type"`
+	}{}
+	if err := json.Unmarshal(data, event); err != nil || event.Type == "" {
+		return
+	}
+
+	c.mu.Lock()
+	c.events[event.Type]++
+	c.mu.Unlock()
+}
+
+// ServeHTTP renders metrics in the text format of Prometheus.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = c.WriteText(w)
+}
+
+// WriteText writes metrics in the text format of Prometheus to w, series are sorted by labels.
+func (c *Collector) WriteText(w io.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b := &strings.Builder{}
+	c.header(b, "requests_total", "counter", "Calls of functions of the core library.")
+	for _, method := range sortedKeys(c.requests) {
+		fmt.Fprintf(b, "%s_requests_total{method=%s} %d\n", c.namespace, quote(method), c.requests[method])
+	}
+
+	c.header(b, "errors_total", "counter", "Calls which failed, by code of error, 0 is an error of the binding.")
+	keys := make([]errorKey, 0, len(c.errors))
+	for key := range c.errors {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].code < keys[j].code
+	})
+	for _, key := range keys {
+		fmt.Fprintf(b, "%s_errors_total{method=%s,code=\"%d\"} %d\n", c.namespace, quote(key.method), key.code, c.errors[key])
+	}
+
+	c.header(b, "request_duration_seconds", "histogram", "Time from the call to its result.")
+	for _, method := range sortedKeys(c.latency) {
+		h := c.latency[method]
+		var cumulative uint64
+		for i, bound := range c.buckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(b, "%s_request_duration_seconds_bucket{method=%s,le=\"%s\"} %d\n",
+				c.namespace, quote(method), strconv.FormatFloat(bound, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(b, "%s_request_duration_seconds_bucket{method=%s,le=\"+Inf\"} %d\n", c.namespace, quote(method), h.count)
+		fmt.Fprintf(b, "%s_request_duration_seconds_sum{method=%s} %s\n",
+			c.namespace, quote(method), strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(b, "%s_request_duration_seconds_count{method=%s} %d\n", c.namespace, quote(method), h.count)
+	}
+
+	c.header(b, "requests_in_flight", "gauge", "Calls which wait for their result.")
+	for _, method := range sortedKeys(c.inFlight) {
+		fmt.Fprintf(b, "%s_requests_in_flight{method=%s} %d\n", c.namespace, quote(method), c.inFlight[method])
+	}
+
+	c.header(b, "subscriptions_open", "gauge", "Subscriptions which aren't finished.")
+	fmt.Fprintf(b, "%s_subscriptions_open %d\n", c.namespace, c.subscriptions)
+
+	c.header(b, "processing_events_total", "counter", "Events of message processing by type.")
+	for _, event := range sortedKeys(c.events) {
+		fmt.Fprintf(b, "%s_processing_events_total{type=%s} %d\n", c.namespace, quote(event), c.events[event])
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (c *Collector) header(b *strings.Builder, name, kind, help string) {
+	fmt.Fprintf(b, "# HELP %s_%s %s\n# TYPE %s_%s %s\n", c.namespace, name, help, c.namespace, name, kind)
+}
+
+// quote returns label value in quotes with backslash, quote and new line escaped.
+func quote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value) + `"`
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch m := m.(type) {
+	case map[string]uint64:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string]int64:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string]*histogram:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
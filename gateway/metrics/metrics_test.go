@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/markgenuine/ever-client-go/domain"
+	clientgw "github.com/markgenuine/ever-client-go/gateway/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// eventsTransport - core library with a failing query, message processing with events and subscriptions which
+// last until their call is cancelled.
+type eventsTransport struct{}
+
+func (eventsTransport) CreateContext([]byte) ([]byte, error) {
+	return []byte(`{"result":1}`), nil
+}
+
+func (eventsTransport) DestroyContext(uint32) {}
+
+func (eventsTransport) Request(_ uint32, method string, _ []byte, handler clientgw.ResponseHandler) error {
+	go func() {
+		switch method {
+		case "net.query":
+			handler([]byte(`{"code":617,"message":"Graphql connection error"}`), domain.ResponseError, true)
+		case "processing.process_message":
+			handler([]byte(`{"type":"WillSend","shard_block_id":"","message_id":"","message":""}`), domain.ResponseCustom, false)
+			handler([]byte(`{"type":"DidSend","shard_block_id":"","message_id":"","message":""}`), domain.ResponseCustom, false)
+			handler([]byte(`{"type":"MessageExpired","message_id":"","message":"","error":{}}`), domain.ResponseCustom, false)
+			handler([]byte(`{"code":507,"message":"Message expired"}`), domain.ResponseError, true)
+		case "net.subscribe_collection":
+			handler([]byte(`{"handle":1}`), domain.ResponseSuccess, false)
+		default:
+			handler([]byte(`{}`), domain.ResponseSuccess, true)
+		}
+	}()
+
+	return nil
+}
+
+func TestCollector(t *testing.T) {
+	collector := NewCollector(WithBuckets(0.1, 1))
+	client, err := clientgw.NewClientGateway(domain.NewDefaultConfig("", nil, ""),
+		clientgw.WithTransport(eventsTransport{}), clientgw.WithInterceptors(collector.Interceptor))
+	assert.NoError(t, err)
+	defer client.Destroy()
+
+	for i := 0; i < 2; i++ {
+		_, err = client.GetResponse("crypto.generate_random_bytes", nil)
+		assert.NoError(t, err)
+	}
+	_, err = client.GetResponse("net.query", nil)
+	assert.Error(t, err)
+	_, err = client.GetResponse("processing.process_message", nil)
+	assert.Error(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.RequestContext(ctx, "net.subscribe_collection", nil)
+	assert.NoError(t, err)
+	assert.NoError(t, stream.Result(nil))
+
+	render := func() string {
+		recorder := httptest.NewRecorder()
+		collector.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+		assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", recorder.Header().Get("Content-Type"))
+		body, _ := ioutil.ReadAll(recorder.Body)
+		return string(body)
+	}
+	text := render()
+	for _, line := range []string{
+		"# TYPE ever_requests_total counter",
+		`ever_requests_total{method="crypto.generate_random_bytes"} 2`,
+		`ever_requests_total{method="net.query"} 1`,
+		`ever_errors_total{method="net.query",code="617"} 1`,
+		`ever_errors_total{method="processing.process_message",code="507"} 1`,
+		"# TYPE ever_request_duration_seconds histogram",
+		`ever_request_duration_seconds_bucket{method="crypto.generate_random_bytes",le="0.1"} 2`,
+		`ever_request_duration_seconds_bucket{method="crypto.generate_random_bytes",le="1"} 2`,
+		`ever_request_duration_seconds_bucket{method="crypto.generate_random_bytes",le="+Inf"} 2`,
+		`ever_request_duration_seconds_count{method="crypto.generate_random_bytes"} 2`,
+		`ever_requests_in_flight{method="net.query"} 0`,
+		"ever_subscriptions_open 1",
+		`ever_processing_events_total{type="DidSend"} 1`,
+		`ever_processing_events_total{type="MessageExpired"} 1`,
+		`ever_processing_events_total{type="WillSend"} 1`,
+	} {
+		assert.Contains(t, text, line+"\n")
+	}
+
+	cancel()
+	for i := 0; i < 100 && !strings.Contains(render(), "ever_subscriptions_open 0\n"); i++ {
+		time.Sleep(5 * time.Millisecond)
+	}
+	assert.Contains(t, render(), "ever_subscriptions_open 0\n")
+
+	t.Run("Quote", func(t *testing.T) {
+		assert.Equal(t, `"a\\b\"c\nd"`, quote("a\\b\"c\nd"))
+	})
+
+	t.Run("Namespace", func(t *testing.T) {
+		b := &strings.Builder{}
+		assert.NoError(t, NewCollector(WithNamespace("svc_sdk")).WriteText(b))
+		assert.Contains(t, b.String(), "svc_sdk_subscriptions_open 0\n")
+	})
+}
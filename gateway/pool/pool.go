@@ -0,0 +1,208 @@
+// Package pool spreads calls over several core contexts created from one config, so local work of the core
+// library (tvm.run_executor, abi.encode_message, boc parsing) runs in parallel. Every call goes to the context
+// with the least calls in flight, calls with a handle go to the context which opened it, see client.Affinity.
+package pool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/markgenuine/ever-client-go/domain"
+	clientgw "github.com/markgenuine/ever-client-go/gateway/client"
+)
+
+// ErrSize - pool is created with less than one context.
+var ErrSize = errors.New("pool: size must be positive")
+
+type (
+	// Gateway - domain.ClientGateway over a pool of contexts.
+	Gateway struct {
+		*clientgw.MultiContext
+		clients  []domain.ClientGateway
+		inFlight []int64
+		next     uint32
+
+		clientOpts []clientgw.Option
+		appOptions []domain.AppObjectOption
+		affinity   *clientgw.Affinity
+	}
+
+	// Option configures Gateway.
+	Option func(*Gateway)
+)
+
+// WithClientOptions sets options of the client gateway of every context.
+func WithClientOptions(opts ...clientgw.Option) Option {
+	return func(g *Gateway) {
+		g.clientOpts = append(g.clientOpts, opts...)
+	}
+}
+
+// WithAppObjects sets options of the registry of app objects of the pool, e.g. domain.WithAppRequestTimeout.
+func WithAppObjects(opts ...domain.AppObjectOption) Option {
+	return func(g *Gateway) {
+		g.appOptions = append(g.appOptions, opts...)
+	}
+}
+
+// NewGateway creates size contexts with config.
+func NewGateway(config domain.ClientConfig, size int, opts ...Option) (*Gateway, error) {
+	if size < 1 {
+		return nil, ErrSize
+	}
+
+	g := &Gateway{
+		inFlight: make([]int64, size),
+		affinity: clientgw.NewAffinity(),
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	g.MultiContext = clientgw.NewMultiContext(g, g.affinity, g.contexts, g.appOptions...)
+
+	for i := 0; i < size; i++ {
+		client, err := clientgw.NewClientGateway(config, g.clientOpts...)
+		if err != nil {
+			g.Destroy()
+			return nil, fmt.Errorf("pool: context %d: %w", i, err)
+		}
+		g.clients = append(g.clients, client)
+	}
+
+	return g, nil
+}
+
+// Size returns count of contexts of the pool.
+func (g *Gateway) Size() int {
+	return len(g.clients)
+}
+
+// InFlight returns count of calls in flight by context.
+func (g *Gateway) InFlight() []int64 {
+	inFlight := make([]int64, len(g.inFlight))
+	for i := range g.inFlight {
+		inFlight[i] = atomic.LoadInt64(&g.inFlight[i])
+	}
+
+	return inFlight
+}
+
+// pick returns context of method and params with handles of that context: owner of the handle or the least
+// loaded context. Search starts from the next context every time, so idle contexts share calls evenly.
+func (g *Gateway) pick(method string, params []byte) (int, []byte) {
+	if owner, sent, isOwned := g.affinity.Owner(method, params); isOwned {
+		return owner, sent
+	}
+
+	size := len(g.clients)
+	start := int(atomic.AddUint32(&g.next, 1) % uint32(size))
+	best, bestLoad := start, atomic.LoadInt64(&g.inFlight[start])
+	for i := 1; i < size && bestLoad > 0; i++ {
+		index := (start + i) % size
+		if load := atomic.LoadInt64(&g.inFlight[index]); load < bestLoad {
+			best, bestLoad = index, load
+		}
+	}
+
+	return best, params
+}
+
+// GetResponseContext calls method in the context picked for it.
+func (g *Gateway) GetResponseContext(ctx context.Context, method string, paramIn interface{}) ([]byte, error) {
+	params, err := clientgw.MarshalParams(paramIn)
+	if err != nil {
+		return nil, err
+	}
+
+	index, sent := g.pick(method, params)
+	atomic.AddInt64(&g.inFlight[index], 1)
+	data, err := g.clients[index].GetResponseContext(ctx, method, json.RawMessage(sent))
+	atomic.AddInt64(&g.inFlight[index], -1)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.affinity.Track(index, method, params, data), nil
+}
+
+// RequestContext starts method in the context picked for it. The call is in flight until its result, events
+// of subscriptions which follow it don't load the context.
+func (g *Gateway) RequestContext(ctx context.Context, method string, paramIn interface{}) (*domain.Stream, error) {
+	params, err := clientgw.MarshalParams(paramIn)
+	if err != nil {
+		return nil, err
+	}
+
+	index, sent := g.pick(method, params)
+	atomic.AddInt64(&g.inFlight[index], 1)
+	stream, err := g.clients[index].RequestContext(ctx, method, json.RawMessage(sent))
+	if err != nil {
+		atomic.AddInt64(&g.inFlight[index], -1)
+		return nil, err
+	}
+
+	out := make(chan *domain.ClientResponse, 1)
+	go func() {
+		inFlight := true
+		defer func() {
+			if inFlight {
+				atomic.AddInt64(&g.inFlight[index], -1)
+			}
+			close(out)
+		}()
+
+		for {
+			r, err := stream.Next(ctx)
+			if err != nil {
+				return
+			}
+			r = g.affinity.TrackResponse(index, method, params, r)
+			if inFlight && (r.Code == domain.ResponseSuccess || r.Code == domain.ResponseError) {
+				inFlight = false
+				atomic.AddInt64(&g.inFlight[index], -1)
+			}
+			select {
+			case out <- r:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return stream.Derive(out), nil
+}
+
+// ResolveAppRequest resolves app request in the context which sent it.
+func (g *Gateway) ResolveAppRequest(params *domain.ParamsOfResolveAppRequest) error {
+	index, params := g.affinity.ResolveOwner(params)
+	return g.clients[index].ResolveAppRequest(params)
+}
+
+func (g *Gateway) GetAPIReference() (*domain.ResultOfGetAPIReference, error) {
+	return g.clients[0].GetAPIReference()
+}
+
+func (g *Gateway) Version() (*domain.ResultOfVersion, error) {
+	return g.clients[0].Version()
+}
+
+func (g *Gateway) Config() (*domain.ClientConfig, error) {
+	return g.clients[0].Config()
+}
+
+func (g *Gateway) GetBuildInfo() (*domain.ResultOfBuildInfo, error) {
+	return g.clients[0].GetBuildInfo()
+}
+
+// contexts returns gateways of contexts by their index.
+func (g *Gateway) contexts() map[int]domain.ClientGateway {
+	contexts := make(map[int]domain.ClientGateway, len(g.clients))
+	for i, client := range g.clients {
+		contexts[i] = client
+	}
+
+	return contexts
+}
@@ -0,0 +1,354 @@
+package pool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/markgenuine/ever-client-go/domain"
+	clientgw "github.com/markgenuine/ever-client-go/gateway/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// coreContext - state of one context of coreTransport.
+type coreContext struct {
+	work    sync.Mutex
+	next    int
+	handles map[string]bool
+	resolve chan json.RawMessage
+}
+
+// coreTransport - core library where every context runs local functions one at a time and numbers its handles
+// from 1, like SDK does. Calls with handles of another context fail.
+type coreTransport struct {
+	mu       sync.Mutex
+	work     time.Duration
+	contexts map[uint32]*coreContext
+	calls    []string
+}
+
+func newCoreTransport(work time.Duration) *coreTransport {
+	return &coreTransport{work: work, contexts: make(map[uint32]*coreContext)}
+}
+
+func (c *coreTransport) CreateContext([]byte) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	context := uint32(len(c.contexts) + 1)
+	c.contexts[context] = &coreContext{handles: make(map[string]bool), resolve: make(chan json.RawMessage, 1)}
+
+	return []byte(fmt.Sprintf(`{"result":%d}`, context)), nil
+}
+
+func (c *coreTransport) DestroyContext(uint32) {}
+
+func (c *coreTransport) Request(context uint32, method string, paramsJSON []byte, handler clientgw.ResponseHandler) error {
+	c.mu.Lock()
+	core := c.contexts[context]
+	c.calls = append(c.calls, fmt.Sprintf("%d %s %s", context, method, paramsJSON))
+	c.mu.Unlock()
+
+	params := map[string]interface{}{}
+	_ = json.Unmarshal(paramsJSON, &params)
+	go func() {
+		switch method {
+		case "tvm.run_executor", "abi.encode_message":
+			if signer, ok := params["signer"].(map[string]interface{}); ok && !c.has(core, "box", signer["handle"]) {
+				handler([]byte(`{"code":121,"message":"Signing box is not registered"}`), domain.ResponseError, true)
+				return
+			}
+			core.work.Lock()
+			time.Sleep(c.work)
+			core.work.Unlock()
+			handler([]byte(`{}`), domain.ResponseSuccess, true)
+		case "crypto.get_signing_box":
+			handler([]byte(fmt.Sprintf(`{"handle":%d}`, c.open(core, "box"))), domain.ResponseSuccess, true)
+		case "net.create_block_iterator":
+			handler([]byte(fmt.Sprintf(`{"handle":%d}`, c.open(core, "iterator"))), domain.ResponseSuccess, true)
+		case "net.subscribe_collection":
+			handler([]byte(fmt.Sprintf(`{"handle":%d}`, c.open(core, "subscription"))), domain.ResponseSuccess, false)
+			handler([]byte(`{"result":{}}`), domain.ResponseCustom, true)
+		case "crypto.signing_box_sign":
+			c.use(core, "box", params["signing_box"], `{"signature":"00"}`, handler)
+		case "crypto.remove_signing_box":
+			c.use(core, "box", params["handle"], `{}`, handler)
+			c.close(core, "box", params["handle"])
+		case "net.iterator_next":
+			c.use(core, "iterator", params["handle"], `{"items":[]}`, handler)
+		case "net.unsubscribe":
+			c.use(core, "subscription", params["handle"], `{}`, handler)
+		case "boc.cache_set":
+			handler([]byte(fmt.Sprintf(`{"boc_ref":"*%d"}`, c.open(core, "boc"))), domain.ResponseSuccess, true)
+		case "boc.cache_get":
+			ref := strings.TrimPrefix(fmt.Sprint(params["boc_ref"]), "*")
+			c.use(core, "boc", json.Number(ref), `{"boc":"te6"}`, handler)
+		case "debot.fetch":
+			handler([]byte(`{"app_request_id":1,"request_data":{}}`), domain.ResponseAppRequest, false)
+			handler(<-core.resolve, domain.ResponseSuccess, true)
+		case "client.resolve_app_request":
+			core.resolve <- json.RawMessage(`{}`)
+			handler([]byte(`{}`), domain.ResponseSuccess, true)
+		default:
+			handler([]byte(`{}`), domain.ResponseSuccess, true)
+		}
+	}()
+
+	return nil
+}
+
+// open opens handle of kind in core and returns its number.
+func (c *coreTransport) open(core *coreContext, kind string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	core.next++
+	core.handles[fmt.Sprintf("%s %d", kind, core.next)] = true
+
+	return core.next
+}
+
+func (c *coreTransport) close(core *coreContext, kind string, handle interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(core.handles, fmt.Sprintf("%s %v", kind, handle))
+}
+
+func (c *coreTransport) has(core *coreContext, kind string, handle interface{}) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return core.handles[fmt.Sprintf("%s %v", kind, handle)]
+}
+
+// use answers result when handle of kind is open in core.
+func (c *coreTransport) use(core *coreContext, kind string, handle interface{}, result string, handler clientgw.ResponseHandler) {
+	if !c.has(core, kind, handle) {
+		handler([]byte(fmt.Sprintf(`{"code":1,"message":"%s %v is not found"}`, kind, handle)), domain.ResponseError, true)
+		return
+	}
+	handler([]byte(result), domain.ResponseSuccess, true)
+}
+
+// contextsOf returns contexts of calls of method.
+func (c *coreTransport) contextsOf(method string) []uint32 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var contexts []uint32
+	for _, call := range c.calls {
+		var (
+			context uint32
+			m       string
+		)
+		if _, err := fmt.Sscanf(call, "%d %s", &context, &m); err == nil && m == method {
+			contexts = append(contexts, context)
+		}
+	}
+
+	return contexts
+}
+
+func newPool(t testing.TB, size int, work time.Duration) (*Gateway, *coreTransport) {
+	transport := newCoreTransport(work)
+	g, err := NewGateway(domain.NewDefaultConfig("", nil, ""), size, WithClientOptions(clientgw.WithTransport(transport)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return g, transport
+}
+
+// runExecutor calls tvm.run_executor n times at once.
+func runExecutor(g *Gateway, n int) error {
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			errs <- g.GetResult("tvm.run_executor", map[string]string{"message": "te6"}, &struct{}{})
+		}()
+	}
+
+	var firstErr error
+	for i := 0; i < n; i++ {
+		if err := <-errs; err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func TestPool(t *testing.T) {
+	handle := func(payload []byte) json.RawMessage {
+		result := &struct {
+			Handle json.RawMessage `json:"handle"`
+		}{}
+		_ = json.Unmarshal(payload, result)
+		return result.Handle
+	}
+
+	t.Run("LeastInFlight", func(t *testing.T) {
+		g, transport := newPool(t, 4, 20*time.Millisecond)
+		defer g.Destroy()
+
+		assert.NoError(t, runExecutor(g, 8))
+		calls := map[uint32]int{}
+		for _, context := range transport.contextsOf("tvm.run_executor") {
+			calls[context]++
+		}
+		assert.Len(t, calls, 4)
+		assert.Equal(t, []int64{0, 0, 0, 0}, g.InFlight())
+	})
+
+	t.Run("SigningBox", func(t *testing.T) {
+		g, transport := newPool(t, 3, 0)
+		defer g.Destroy()
+
+		var boxes []json.RawMessage
+		for i := 0; i < 3; i++ {
+			data, err := g.GetResponse("crypto.get_signing_box", map[string]string{"public": "00", "secret": "00"})
+			assert.NoError(t, err)
+			boxes = append(boxes, handle(data))
+		}
+		assert.Equal(t, []json.RawMessage{json.RawMessage(`1`), json.RawMessage(`2`), json.RawMessage(`3`)}, boxes)
+		assert.ElementsMatch(t, []uint32{1, 2, 3}, transport.contextsOf("crypto.get_signing_box"))
+		var open []json.RawMessage
+		for _, h := range g.OpenHandles() {
+			open = append(open, h.Handle)
+		}
+		assert.ElementsMatch(t, boxes, open)
+
+		for _, box := range boxes {
+			_, err := g.GetResponse("crypto.signing_box_sign", map[string]interface{}{"signing_box": box, "unsigned": ""})
+			assert.NoError(t, err)
+			signer := map[string]interface{}{"type": "SigningBox", "handle": box}
+			_, err = g.GetResponse("abi.encode_message", map[string]interface{}{"signer": signer})
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, transport.contextsOf("crypto.get_signing_box"), transport.contextsOf("crypto.signing_box_sign"))
+		assert.Equal(t, transport.contextsOf("crypto.get_signing_box"), transport.contextsOf("abi.encode_message"))
+
+		for _, box := range boxes {
+			_, err := g.GetResponse("crypto.remove_signing_box", map[string]interface{}{"handle": box})
+			assert.NoError(t, err)
+		}
+		_, err := g.GetResponse("crypto.signing_box_sign", map[string]interface{}{"signing_box": boxes[0]})
+		assert.Error(t, err)
+	})
+
+	t.Run("IteratorAndSubscription", func(t *testing.T) {
+		g, transport := newPool(t, 2, 0)
+		defer g.Destroy()
+
+		iterator, err := g.GetResponse("net.create_block_iterator", map[string]interface{}{})
+		assert.NoError(t, err)
+		stream, err := g.Request("net.subscribe_collection", map[string]string{"collection": "blocks"})
+		assert.NoError(t, err)
+		subscription := &domain.ResultOfSubscribeCollection{}
+		assert.NoError(t, stream.Result(subscription))
+		for range stream.Events() {
+		}
+
+		for i := 0; i < 3; i++ {
+			_, err = g.GetResponse("net.iterator_next", map[string]json.RawMessage{"handle": handle(iterator)})
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, g.GetResult("net.unsubscribe", subscription, &struct{}{}))
+
+		iteratorContext := transport.contextsOf("net.create_block_iterator")[0]
+		assert.Equal(t, []uint32{iteratorContext, iteratorContext, iteratorContext}, transport.contextsOf("net.iterator_next"))
+		assert.Equal(t, transport.contextsOf("net.subscribe_collection"), transport.contextsOf("net.unsubscribe"))
+		assert.NotEqual(t, transport.contextsOf("net.subscribe_collection")[0], iteratorContext)
+	})
+
+	t.Run("BocPin", func(t *testing.T) {
+		g, transport := newPool(t, 2, 0)
+		defer g.Destroy()
+
+		var refs []string
+		for _, pin := range []string{"a", "b"} {
+			result := &domain.ResultOfBocCacheSet{}
+			params := map[string]interface{}{"boc": "te6", "cache_type": map[string]string{"type": "Pinned", "pin": pin}}
+			assert.NoError(t, g.GetResult("boc.cache_set", params, result))
+			refs = append(refs, result.BocRef)
+
+			_, err := g.GetResponse("boc.cache_get", map[string]string{"boc_ref": result.BocRef})
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, []string{"*1", "*1"}, refs)
+		assert.Equal(t, transport.contextsOf("boc.cache_set"), transport.contextsOf("boc.cache_get"))
+		assert.ElementsMatch(t, []uint32{1, 2}, transport.contextsOf("boc.cache_get"))
+	})
+
+	t.Run("AppRequest", func(t *testing.T) {
+		g, transport := newPool(t, 2, 0)
+		defer g.Destroy()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		var requests []*domain.ParamsOfAppRequest
+		var streams []*domain.Stream
+		for i := 0; i < 2; i++ {
+			stream, err := g.RequestContext(ctx, "debot.fetch", map[string]string{"address": "0:00"})
+			assert.NoError(t, err)
+			request := &domain.ParamsOfAppRequest{}
+			if response, err := stream.Next(ctx); assert.NoError(t, err) {
+				assert.Equal(t, domain.ResponseAppRequest, response.Code)
+				assert.NoError(t, json.Unmarshal(response.Data, request))
+			}
+			requests = append(requests, request)
+			streams = append(streams, stream)
+		}
+		assert.NotEqual(t, requests[0].AppRequestID, requests[1].AppRequestID)
+
+		for i, request := range requests {
+			assert.NoError(t, g.ResolveAppRequest(&domain.ParamsOfResolveAppRequest{AppRequestID: request.AppRequestID}))
+			_, err := streams[i].Next(ctx)
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, transport.contextsOf("debot.fetch"), transport.contextsOf("client.resolve_app_request"))
+	})
+
+	t.Run("Stats", func(t *testing.T) {
+		transport := newCoreTransport(50 * time.Millisecond)
+		g, err := NewGateway(domain.NewDefaultConfig("", nil, ""), 2, WithClientOptions(clientgw.WithTransport(transport),
+			clientgw.WithRequestTimeout("tvm", 5*time.Millisecond)))
+		assert.NoError(t, err)
+		defer g.Destroy()
+
+		assert.True(t, errors.Is(runExecutor(g, 2), domain.ErrRequestTimeout))
+		var tracker domain.RequestTracker = g
+		assert.Equal(t, domain.RequestStats{Expired: 2}, tracker.RequestStats())
+		var drops domain.DropTracker = g
+		assert.Equal(t, map[string]uint64{}, drops.DroppedResponses())
+	})
+
+	t.Run("Size", func(t *testing.T) {
+		_, err := NewGateway(domain.NewDefaultConfig("", nil, ""), 0)
+		assert.True(t, errors.Is(err, ErrSize))
+	})
+}
+
+// BenchmarkPool measures routing of the pool over the fake core library whose contexts run calls one at a time,
+// it says nothing about throughput of libton_client.
+func BenchmarkPool(b *testing.B) {
+	for _, size := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("Contexts%d", size), func(b *testing.B) {
+			g, _ := newPool(b, size, time.Millisecond)
+			defer g.Destroy()
+
+			b.SetParallelism(8)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if err := g.GetResult("tvm.run_executor", map[string]string{"message": "te6"}, &struct{}{}); err != nil {
+						b.Error(err)
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,376 @@
+// Package reload swaps the core context under use cases when config of the client changes. New calls go to the
+// context of the new config, calls with handles of the previous context go there until the handles are released,
+// subscriptions are made again in the new context and the previous context is destroyed once it is idle.
+package reload
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/markgenuine/ever-client-go/domain"
+	clientgw "github.com/markgenuine/ever-client-go/gateway/client"
+)
+
+// resubscribed - functions of subscriptions which are made again in the new context.
+var resubscribed = map[string]bool{
+	"net.subscribe_collection": true,
+	"net.subscribe":            true,
+}
+
+type (
+	// Factory creates gateway for config, e.g. clientgw.NewClientGateway with options of the client.
+	Factory func(config domain.ClientConfig) (domain.ClientGateway, error)
+
+	// generation - context of one config, inFlight is guarded by Gateway.mu.
+	generation struct {
+		id       int
+		client   domain.ClientGateway
+		inFlight int
+		retired  bool
+	}
+
+	// subscription - stream of subscription, stream is replaced when the subscription is made again.
+	subscription struct {
+		ctx    context.Context
+		method string
+		params []byte
+		result []byte
+
+		mu     sync.Mutex
+		stream *domain.Stream
+		moving chan struct{}
+		done   bool
+	}
+
+	// Gateway - domain.ClientGateway which swaps its context on Reconfigure.
+	Gateway struct {
+		*clientgw.MultiContext
+		mu            sync.Mutex
+		swap          sync.Mutex
+		factory       Factory
+		current       *generation
+		generations   map[int]*generation
+		subscriptions map[*subscription]struct{}
+		affinity      *clientgw.Affinity
+
+		appOptions []domain.AppObjectOption
+	}
+
+	// Option configures Gateway.
+	Option func(*Gateway)
+)
+
+// WithAppObjects sets options of the registry of app objects of the gateway, e.g. domain.WithAppRequestTimeout.
+func WithAppObjects(opts ...domain.AppObjectOption) Option {
+	return func(g *Gateway) {
+		g.appOptions = append(g.appOptions, opts...)
+	}
+}
+
+// NewGateway creates gateway with the context of config made by factory.
+func NewGateway(config domain.ClientConfig, factory Factory, opts ...Option) (*Gateway, error) {
+	client, err := factory(config)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &Gateway{
+		factory:       factory,
+		current:       &generation{id: 1, client: client},
+		generations:   make(map[int]*generation),
+		subscriptions: make(map[*subscription]struct{}),
+		affinity:      clientgw.NewAffinity(),
+	}
+	g.generations[g.current.id] = g.current
+	for _, opt := range opts {
+		opt(g)
+	}
+	g.MultiContext = clientgw.NewMultiContext(g, g.affinity, g.contexts, g.appOptions...)
+
+	return g, nil
+}
+
+// Reconfigure creates context with config and sends new calls there. Subscriptions are made again in the new
+// context and stopped in the previous one, the previous context is destroyed when its calls are finished and its
+// handles are released. The first subscription which isn't moved is returned as error, it stays in the context
+// where it was made.
+func (g *Gateway) Reconfigure(config domain.ClientConfig) error {
+	g.swap.Lock()
+	defer g.swap.Unlock()
+
+	client, err := g.factory(config)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	previous := g.current
+	g.current = &generation{id: previous.id + 1, client: client}
+	g.generations[g.current.id] = g.current
+	previous.retired = true
+	current := g.current
+	subscriptions := make([]*subscription, 0, len(g.subscriptions))
+	for s := range g.subscriptions {
+		subscriptions = append(subscriptions, s)
+	}
+	g.mu.Unlock()
+
+	var firstErr error
+	for _, s := range subscriptions {
+		if err := g.resubscribe(s, current); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("reload: %s: %w", s.method, err)
+		}
+	}
+	g.destroyIdle()
+
+	return firstErr
+}
+
+// Current returns gateway of the context which receives new calls.
+func (g *Gateway) Current() domain.ClientGateway {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.current.client
+}
+
+// resubscribe makes subscription s in context current and stops it in the context which owns its handle.
+func (g *Gateway) resubscribe(s *subscription, current *generation) error {
+	s.mu.Lock()
+	if s.done {
+		s.mu.Unlock()
+		return nil
+	}
+	moving := make(chan struct{})
+	s.moving = moving
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.moving = nil
+		s.mu.Unlock()
+		close(moving)
+	}()
+
+	stream, err := current.client.RequestContext(s.ctx, s.method, json.RawMessage(s.params))
+	if err != nil {
+		return err
+	}
+	var result json.RawMessage
+	if err := stream.Result(&result); err != nil {
+		return err
+	}
+
+	owner, unsubscribe, _ := g.affinity.Owner("net.unsubscribe", s.result)
+	g.affinity.Move(s.method, s.result, result, current.id)
+	s.mu.Lock()
+	s.stream = stream
+	s.mu.Unlock()
+
+	if previous := g.acquire(owner, true); previous != nil {
+		_, err = previous.client.GetResponseContext(s.ctx, "net.unsubscribe", json.RawMessage(unsubscribe))
+		g.release(previous)
+	}
+
+	return err
+}
+
+// next returns stream which replaces finished stream of s, nil means the subscription is finished.
+func (s *subscription) next(finished *domain.Stream) *domain.Stream {
+	s.mu.Lock()
+	moving := s.moving
+	s.mu.Unlock()
+	if moving != nil {
+		<-moving
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stream != finished {
+		return s.stream
+	}
+	s.done = true
+
+	return nil
+}
+
+// acquire returns context of the call and counts the call in flight there: owner of its handle or the current
+// context. It returns nil when the owner is already destroyed.
+func (g *Gateway) acquire(owner int, isOwned bool) *generation {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	gen := g.current
+	if isOwned {
+		gen = g.generations[owner]
+	}
+	if gen != nil {
+		gen.inFlight++
+	}
+
+	return gen
+}
+
+// release finishes the call in gen and destroys gen when it is retired and idle.
+func (g *Gateway) release(gen *generation) {
+	g.mu.Lock()
+	gen.inFlight--
+	retired := gen.retired
+	g.mu.Unlock()
+	if retired {
+		g.destroyIdle()
+	}
+}
+
+// destroyIdle destroys retired contexts without calls in flight and open handles.
+func (g *Gateway) destroyIdle() {
+	var idle []*generation
+	g.mu.Lock()
+	for id, gen := range g.generations {
+		if gen.retired && gen.inFlight == 0 && !g.affinity.Owns(id) {
+			delete(g.generations, id)
+			idle = append(idle, gen)
+		}
+	}
+	g.mu.Unlock()
+
+	for _, gen := range idle {
+		gen.client.Destroy()
+	}
+}
+
+// GetResponseContext calls method in the context which owns its handle or in the current one.
+func (g *Gateway) GetResponseContext(ctx context.Context, method string, paramIn interface{}) ([]byte, error) {
+	params, err := clientgw.MarshalParams(paramIn)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, sent, isOwned := g.affinity.Owner(method, params)
+	gen := g.acquire(owner, isOwned)
+	if gen == nil {
+		return nil, fmt.Errorf("reload: %s: %w", method, domain.ErrClientClosed)
+	}
+	defer g.release(gen)
+	data, err := gen.client.GetResponseContext(ctx, method, json.RawMessage(sent))
+	if err != nil {
+		return nil, err
+	}
+
+	return g.affinity.Track(gen.id, method, params, data), nil
+}
+
+// RequestContext starts method in the context which owns its handle or in the current one. Subscriptions
+// continue in the new context after Reconfigure, other streams finish where they were started.
+func (g *Gateway) RequestContext(ctx context.Context, method string, paramIn interface{}) (*domain.Stream, error) {
+	params, err := clientgw.MarshalParams(paramIn)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, sent, isOwned := g.affinity.Owner(method, params)
+	gen := g.acquire(owner, isOwned)
+	if gen == nil {
+		return nil, fmt.Errorf("reload: %s: %w", method, domain.ErrClientClosed)
+	}
+	stream, err := gen.client.RequestContext(ctx, method, json.RawMessage(sent))
+	if err != nil {
+		g.release(gen)
+		return nil, err
+	}
+
+	out := make(chan *domain.ClientResponse, 1)
+	go g.forward(ctx, method, params, gen, stream, out)
+
+	return stream.Derive(out), nil
+}
+
+// forward passes responses of stream started in gen to out. The call is in flight in gen until its result,
+// subscriptions are followed to the contexts where they are made again.
+func (g *Gateway) forward(ctx context.Context, method string, params []byte, gen *generation, stream *domain.Stream, out chan<- *domain.ClientResponse) {
+	var s *subscription
+	owner := gen.id
+	defer func() {
+		if gen != nil {
+			g.release(gen)
+		}
+		if s != nil {
+			g.mu.Lock()
+			delete(g.subscriptions, s)
+			g.mu.Unlock()
+		}
+		close(out)
+	}()
+
+	for {
+		r, err := stream.Next(ctx)
+		if err != nil && s != nil && ctx.Err() == nil {
+			if stream = s.next(stream); stream != nil {
+				continue
+			}
+		}
+		if err != nil {
+			return
+		}
+
+		r = g.affinity.TrackResponse(owner, method, params, r)
+		if r.Code == domain.ResponseSuccess && resubscribed[method] {
+			s = &subscription{ctx: ctx, method: method, params: params, result: r.Data, stream: stream}
+			g.mu.Lock()
+			g.subscriptions[s] = struct{}{}
+			g.mu.Unlock()
+		}
+		if gen != nil && (r.Code == domain.ResponseSuccess || r.Code == domain.ResponseError) {
+			g.release(gen)
+			gen = nil
+		}
+		select {
+		case out <- r:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ResolveAppRequest resolves app request in the context which sent it.
+func (g *Gateway) ResolveAppRequest(params *domain.ParamsOfResolveAppRequest) error {
+	owner, params := g.affinity.ResolveOwner(params)
+	gen := g.acquire(owner, owner != 0)
+	if gen == nil {
+		return fmt.Errorf("reload: client.resolve_app_request: %w", domain.ErrClientClosed)
+	}
+	defer g.release(gen)
+
+	return gen.client.ResolveAppRequest(params)
+}
+
+func (g *Gateway) GetAPIReference() (*domain.ResultOfGetAPIReference, error) {
+	return g.Current().GetAPIReference()
+}
+
+func (g *Gateway) Version() (*domain.ResultOfVersion, error) {
+	return g.Current().Version()
+}
+
+// Config returns config of the current context.
+func (g *Gateway) Config() (*domain.ClientConfig, error) {
+	return g.Current().Config()
+}
+
+func (g *Gateway) GetBuildInfo() (*domain.ResultOfBuildInfo, error) {
+	return g.Current().GetBuildInfo()
+}
+
+// contexts returns gateways of contexts which aren't destroyed by their generation.
+func (g *Gateway) contexts() map[int]domain.ClientGateway {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	contexts := make(map[int]domain.ClientGateway, len(g.generations))
+	for id, gen := range g.generations {
+		contexts[id] = gen.client
+	}
+
+	return contexts
+}
@@ -0,0 +1,271 @@
+package reload
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/markgenuine/ever-client-go/domain"
+	clientgw "github.com/markgenuine/ever-client-go/gateway/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// netContext - context of netTransport, subscriptions are open until net.unsubscribe.
+type netContext struct {
+	config        []byte
+	endpoint      string
+	next          int
+	iterators     map[string]bool
+	subscriptions map[string]clientgw.ResponseHandler
+}
+
+// netTransport - core library whose contexts answer net.query with their endpoint and number handles from 1.
+// Calls of slow.call wait for unblock.
+type netTransport struct {
+	mu        sync.Mutex
+	contexts  map[uint32]*netContext
+	calls     []string
+	destroyed []uint32
+	unblock   chan struct{}
+}
+
+func newNetTransport() *netTransport {
+	return &netTransport{contexts: make(map[uint32]*netContext), unblock: make(chan struct{})}
+}
+
+func (n *netTransport) CreateContext(config []byte) ([]byte, error) {
+	clientConfig := domain.ClientConfig{}
+	if err := json.Unmarshal(config, &clientConfig); err != nil {
+		return nil, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	context := uint32(len(n.contexts) + 1)
+	n.contexts[context] = &netContext{
+		config:        config,
+		endpoint:      clientConfig.Network.Endpoints[0],
+		iterators:     make(map[string]bool),
+		subscriptions: make(map[string]clientgw.ResponseHandler),
+	}
+
+	return []byte(fmt.Sprintf(`{"result":%d}`, context)), nil
+}
+
+func (n *netTransport) DestroyContext(context uint32) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.destroyed = append(n.destroyed, context)
+}
+
+func (n *netTransport) Request(context uint32, method string, paramsJSON []byte, handler clientgw.ResponseHandler) error {
+	params := &struct {
+		Handle json.RawMessage `json:"handle"`
+	}{}
+	_ = json.Unmarshal(paramsJSON, params)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	core := n.contexts[context]
+	n.calls = append(n.calls, fmt.Sprintf("%d %s %s", context, method, paramsJSON))
+
+	switch method {
+	case "net.query":
+		go handler([]byte(fmt.Sprintf(`{"result":%q}`, core.endpoint)), domain.ResponseSuccess, true)
+	case "client.config":
+		go handler(core.config, domain.ResponseSuccess, true)
+	case "slow.call":
+		go func() {
+			<-n.unblock
+			handler([]byte(`{}`), domain.ResponseSuccess, true)
+		}()
+	case "net.create_block_iterator", "net.subscribe_collection":
+		core.next++
+		handle := fmt.Sprint(core.next)
+		if method == "net.subscribe_collection" {
+			core.subscriptions[handle] = handler
+		} else {
+			core.iterators[handle] = true
+		}
+		handler([]byte(fmt.Sprintf(`{"handle":%s}`, handle)), domain.ResponseSuccess, method == "net.create_block_iterator")
+	case "net.iterator_next", "net.remove_iterator":
+		if !core.iterators[string(params.Handle)] {
+			go handler([]byte(`{"code":1,"message":"iterator is not found"}`), domain.ResponseError, true)
+			break
+		}
+		if method == "net.remove_iterator" {
+			delete(core.iterators, string(params.Handle))
+		}
+		go handler([]byte(`{}`), domain.ResponseSuccess, true)
+	case "net.unsubscribe":
+		subscription, isFound := core.subscriptions[string(params.Handle)]
+		if !isFound {
+			go handler([]byte(`{"code":1,"message":"subscription is not found"}`), domain.ResponseError, true)
+			break
+		}
+		delete(core.subscriptions, string(params.Handle))
+		subscription(nil, domain.ResponseNop, true)
+		go handler([]byte(`{}`), domain.ResponseSuccess, true)
+	default:
+		go handler([]byte(`{}`), domain.ResponseSuccess, true)
+	}
+
+	return nil
+}
+
+// emit sends event to subscriptions of all contexts.
+func (n *netTransport) emit(event string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, core := range n.contexts {
+		for _, subscription := range core.subscriptions {
+			subscription([]byte(fmt.Sprintf(`{"result":%q}`, event)), domain.ResponseCustom, false)
+		}
+	}
+}
+
+func (n *netTransport) callsOf(method string) []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var calls []string
+	for _, call := range n.calls {
+		var (
+			context uint32
+			m       string
+		)
+		if _, err := fmt.Sscanf(call, "%d %s", &context, &m); err == nil && m == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+func (n *netTransport) destroyedContexts() []uint32 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return append([]uint32(nil), n.destroyed...)
+}
+
+func TestReload(t *testing.T) {
+	config := func(endpoint string) domain.ClientConfig {
+		return domain.NewDefaultConfig("", []string{endpoint}, "")
+	}
+	newGateway := func(t *testing.T) (*Gateway, *netTransport) {
+		transport := newNetTransport()
+		g, err := NewGateway(config("https://a/graphql"), func(config domain.ClientConfig) (domain.ClientGateway, error) {
+			return clientgw.NewClientGateway(config, clientgw.WithTransport(transport))
+		})
+		assert.NoError(t, err)
+		return g, transport
+	}
+	query := func(g *Gateway) string {
+		result := &domain.ResultOfQuery{}
+		_ = g.GetResult("net.query", &domain.ParamsOfQuery{Query: "{info{version}}"}, result)
+		var endpoint string
+		_ = json.Unmarshal(result.Result, &endpoint)
+		return endpoint
+	}
+
+	t.Run("NewCalls", func(t *testing.T) {
+		g, transport := newGateway(t)
+		defer g.Destroy()
+
+		assert.Equal(t, "https://a/graphql", query(g))
+		assert.NoError(t, g.Reconfigure(config("https://b/graphql")))
+		assert.Equal(t, "https://b/graphql", query(g))
+		assert.Equal(t, []uint32{1}, transport.destroyedContexts())
+
+		config, err := g.Config()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"https://b/graphql"}, config.Network.Endpoints)
+	})
+
+	t.Run("InFlight", func(t *testing.T) {
+		g, transport := newGateway(t)
+		defer g.Destroy()
+
+		done := make(chan error)
+		go func() {
+			_, err := g.GetResponse("slow.call", nil)
+			done <- err
+		}()
+		for len(transport.callsOf("slow.call")) == 0 {
+			time.Sleep(time.Millisecond)
+		}
+
+		assert.NoError(t, g.Reconfigure(config("https://b/graphql")))
+		assert.Empty(t, transport.destroyedContexts())
+		close(transport.unblock)
+		assert.NoError(t, <-done)
+		assert.Equal(t, []uint32{1}, transport.destroyedContexts())
+	})
+
+	t.Run("Handles", func(t *testing.T) {
+		g, transport := newGateway(t)
+		defer g.Destroy()
+
+		iterator, err := g.GetResponse("net.create_block_iterator", map[string]interface{}{})
+		assert.NoError(t, err)
+		assert.NoError(t, g.Reconfigure(config("https://b/graphql")))
+		_, err = g.GetResponse("net.create_block_iterator", map[string]interface{}{})
+		assert.NoError(t, err)
+
+		_, err = g.GetResponse("net.iterator_next", json.RawMessage(iterator))
+		assert.NoError(t, err)
+		assert.Empty(t, transport.destroyedContexts())
+		_, err = g.GetResponse("net.remove_iterator", json.RawMessage(iterator))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{`1 net.iterator_next {"handle":1}`}, transport.callsOf("net.iterator_next"))
+		assert.Equal(t, []uint32{1}, transport.destroyedContexts())
+	})
+
+	t.Run("Subscription", func(t *testing.T) {
+		g, transport := newGateway(t)
+		defer g.Destroy()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		stream, err := g.RequestContext(ctx, "net.subscribe_collection", &domain.ParamsOfSubscribeCollection{Collection: "blocks"})
+		assert.NoError(t, err)
+		subscription := &domain.ResultOfSubscribeCollection{}
+		assert.NoError(t, stream.Result(subscription))
+		events := stream.Events()
+
+		transport.emit("before")
+		assert.JSONEq(t, `{"result":"before"}`, string(<-events))
+
+		assert.NoError(t, g.Reconfigure(config("https://b/graphql")))
+		assert.Equal(t, []string{
+			`2 net.subscribe_collection {"collection":"blocks","result":""}`,
+		}, transport.callsOf("net.subscribe_collection")[1:])
+		assert.Equal(t, []string{`1 net.unsubscribe {"handle":1}`}, transport.callsOf("net.unsubscribe"))
+		assert.Equal(t, []uint32{1}, transport.destroyedContexts())
+
+		transport.emit("after")
+		assert.JSONEq(t, `{"result":"after"}`, string(<-events))
+
+		assert.NoError(t, g.GetResult("net.unsubscribe", subscription, &struct{}{}))
+		assert.Equal(t, `2 net.unsubscribe {"handle":1}`, transport.callsOf("net.unsubscribe")[1])
+		for range events {
+		}
+	})
+
+	t.Run("FactoryError", func(t *testing.T) {
+		g, _ := newGateway(t)
+		defer g.Destroy()
+
+		errFactory := errors.New("factory failed")
+		g.factory = func(domain.ClientConfig) (domain.ClientGateway, error) {
+			return nil, errFactory
+		}
+		assert.True(t, errors.Is(g.Reconfigure(config("https://b/graphql")), errFactory))
+		assert.Equal(t, "https://a/graphql", query(g))
+	})
+}
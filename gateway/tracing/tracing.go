@@ -0,0 +1,107 @@
+// Package tracing opens a span for every call of the core library. Events of message processing (WillFetchFirstBlock,
+// WillSend, DidSend, WillFetchNextBlock, REMP statuses, ...) become events of the span of processing.process_message,
+// processing.send_message or processing.wait_for_transaction. The parent of the span is taken by Tracer from the
+// context of the caller, so it's enough to call the Context variants of use cases:
+//
+//	ever, err := goever.NewEverWithConfig(config, clientgw.WithInterceptors(tracing.Interceptor(tracer)))
+//	result, err := ever.Processing.ProcessMessageContext(ctx, params, nil)
+//
+// Tracer is small enough to be adapted to OpenTelemetry or another library without depending on it here, code of
+// the error which ended span is returned by domain.ErrorCode.
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/markgenuine/ever-client-go/domain"
+	clientgw "github.com/markgenuine/ever-client-go/gateway/client"
+)
+
+type (
+	// Attribute - named value of span or event, e.g. method, message_id or shard_block_id.
+	Attribute struct {
+		Key   string
+		Value interface{}
+	}
+
+	// Tracer starts spans, the parent of span is found in ctx. The returned context carries the new span.
+	Tracer interface {
+		Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+	}
+
+	// Span - a call of the core library.
+	Span interface {
+		// AddEvent records event of the call, e.g. an event of message processing.
+		AddEvent(name string, attrs ...Attribute)
+		// End finishes span, err is the error of the call or nil.
+		End(err error)
+	}
+
+	// processingEvent - fields of events of message processing which are recorded in spans.
+	processingEvent struct {
+		Type         string `json:"type"`
+		MessageID    string `json:"message_id"`
+		ShardBlockID string `json:"shard_block_id"`
+		Error        *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+)
+
+// Interceptor returns clientgw.Interceptor which opens span named by the function for every call and passes its
+// context down the chain. The span ends with the result of the call or with the end of its stream, events of
+// message processing are added to it before.
+func Interceptor(tracer Tracer) clientgw.Interceptor {
+	return func(ctx context.Context, method string, params []byte, invoker clientgw.Invoker) (<-chan *domain.ClientResponse, error) {
+		ctx, span := tracer.Start(ctx, method, Attribute{"method", method})
+		responses, err := invoker(ctx, method, params)
+		if err != nil {
+			span.End(err)
+			return nil, err
+		}
+
+		ended := false
+		return clientgw.TapResponses(ctx, responses, func(r *domain.ClientResponse) {
+			if ended {
+				return
+			}
+			switch r.Code {
+			case domain.ResponseSuccess, domain.ResponseError:
+				ended = true
+				span.End(r.Error)
+			case domain.ResponseCustom:
+				if strings.HasPrefix(method, "processing.") {
+					addProcessingEvent(span, r.Data)
+				}
+			}
+		}, func(err error) {
+			if !ended {
+				span.End(err)
+			}
+		}), nil
+	}
+}
+
+// addProcessingEvent adds event of message processing to span with its message and shard block, failed events
+// also carry code and message of their error.
+func addProcessingEvent(span Span, data []byte) {
+	event := &processingEvent{}
+	if err := json.Unmarshal(data, event); err != nil || event.Type == "" {
+		return
+	}
+
+	var attrs []Attribute
+	if event.MessageID != "" {
+		attrs = append(attrs, Attribute{"message_id", event.MessageID})
+	}
+	if event.ShardBlockID != "" {
+		attrs = append(attrs, Attribute{"shard_block_id", event.ShardBlockID})
+	}
+	if event.Error != nil {
+		attrs = append(attrs, Attribute{"code", event.Error.Code}, Attribute{"error", event.Error.Message})
+	}
+	span.AddEvent(event.Type, attrs...)
+}
@@ -0,0 +1,144 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/markgenuine/ever-client-go/domain"
+	clientgw "github.com/markgenuine/ever-client-go/gateway/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// processingTransport - core library whose processing sends events before the result, net.query fails.
+type processingTransport struct{}
+
+func (processingTransport) CreateContext([]byte) ([]byte, error) {
+	return []byte(`{"result":1}`), nil
+}
+
+func (processingTransport) DestroyContext(uint32) {}
+
+func (processingTransport) Request(_ uint32, method string, _ []byte, handler clientgw.ResponseHandler) error {
+	go func() {
+		switch method {
+		case "processing.process_message":
+			handler([]byte(`{"type":"WillFetchFirstBlock","message_id":"m1","message":""}`), domain.ResponseCustom, false)
+			handler([]byte(`{"type":"WillSend","shard_block_id":"b1","message_id":"m1","message":""}`), domain.ResponseCustom, false)
+			handler([]byte(`{"type":"DidSend","shard_block_id":"b1","message_id":"m1","message":""}`), domain.ResponseCustom, false)
+			handler([]byte(`{"type":"RempSentToValidators","message_id":"m1","timestamp":1,"json":{}}`), domain.ResponseCustom, false)
+			handler([]byte(`{"type":"FetchNextBlockFailed","shard_block_id":"b1","message_id":"m1","message":"","error":{"code":617,"message":"Graphql connection error"}}`), domain.ResponseCustom, false)
+			handler([]byte(`{"type":"WillFetchNextBlock","shard_block_id":"b2","message_id":"m1","message":""}`), domain.ResponseCustom, false)
+			handler([]byte(`{"transaction":{},"out_messages":[],"decoded":null,"fees":{}}`), domain.ResponseSuccess, true)
+		case "net.query":
+			handler([]byte(`{"code":617,"message":"Graphql connection error"}`), domain.ResponseError, true)
+		default:
+			handler([]byte(`{}`), domain.ResponseSuccess, true)
+		}
+	}()
+
+	return nil
+}
+
+type (
+	parentKey struct{}
+
+	event struct {
+		name  string
+		attrs []Attribute
+	}
+
+	// recordSpan - span kept by recordTracer.
+	recordSpan struct {
+		name   string
+		parent string
+		events []event
+		ended  int
+		err    error
+	}
+
+	// recordTracer keeps spans, the parent of span is the name stored in context.
+	recordTracer struct {
+		sync.Mutex
+		spans []*recordSpan
+	}
+)
+
+func (r *recordTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	r.Lock()
+	defer r.Unlock()
+
+	parent, _ := ctx.Value(parentKey{}).(string)
+	span := &recordSpan{name: name, parent: parent}
+	r.spans = append(r.spans, span)
+
+	return context.WithValue(ctx, parentKey{}, name), &tracedSpan{tracer: r, span: span}
+}
+
+// tracedSpan records events of span under the lock of its tracer.
+type tracedSpan struct {
+	tracer *recordTracer
+	span   *recordSpan
+}
+
+func (t *tracedSpan) AddEvent(name string, attrs ...Attribute) {
+	t.tracer.Lock()
+	defer t.tracer.Unlock()
+	t.span.events = append(t.span.events, event{name: name, attrs: attrs})
+}
+
+func (t *tracedSpan) End(err error) {
+	t.tracer.Lock()
+	defer t.tracer.Unlock()
+	t.span.ended++
+	t.span.err = err
+}
+
+func TestInterceptor(t *testing.T) {
+	tracer := &recordTracer{}
+	var innerParent string
+	inner := func(ctx context.Context, method string, params []byte, invoker clientgw.Invoker) (<-chan *domain.ClientResponse, error) {
+		innerParent, _ = ctx.Value(parentKey{}).(string)
+		return invoker(ctx, method, params)
+	}
+	client, err := clientgw.NewClientGateway(domain.NewDefaultConfig("", nil, ""),
+		clientgw.WithTransport(processingTransport{}), clientgw.WithInterceptors(Interceptor(tracer), inner))
+	assert.NoError(t, err)
+	defer client.Destroy()
+
+	ctx := context.WithValue(context.Background(), parentKey{}, "deposit")
+	stream, err := client.RequestContext(ctx, "processing.process_message", nil)
+	assert.NoError(t, err)
+	assert.NoError(t, domain.HandleEventsContext(ctx, stream, func(event *domain.ProcessingEvent) {}, &domain.ResultOfProcessMessage{}))
+	assert.Equal(t, "processing.process_message", innerParent)
+
+	_, err = client.GetResponseContext(ctx, "net.query", nil)
+	assert.Error(t, err)
+
+	tracer.Lock()
+	defer tracer.Unlock()
+	if !assert.Len(t, tracer.spans, 2) {
+		return
+	}
+
+	processing := tracer.spans[0]
+	assert.Equal(t, "processing.process_message", processing.name)
+	assert.Equal(t, "deposit", processing.parent)
+	assert.Equal(t, 1, processing.ended)
+	assert.NoError(t, processing.err)
+	var names []string
+	for _, e := range processing.events {
+		names = append(names, e.name)
+	}
+	assert.Equal(t, []string{"WillFetchFirstBlock", "WillSend", "DidSend", "RempSentToValidators", "FetchNextBlockFailed", "WillFetchNextBlock"}, names)
+	assert.Equal(t, []Attribute{{"message_id", "m1"}}, processing.events[0].attrs)
+	assert.Equal(t, []Attribute{{"message_id", "m1"}, {"shard_block_id", "b1"}}, processing.events[1].attrs)
+	assert.Equal(t, []Attribute{{"message_id", "m1"}, {"shard_block_id", "b1"}, {"code", 617}, {"error", "Graphql connection error"}},
+		processing.events[4].attrs)
+
+	query := tracer.spans[1]
+	assert.Equal(t, "net.query", query.name)
+	assert.Equal(t, "deposit", query.parent)
+	assert.Equal(t, 1, query.ended)
+	assert.Equal(t, 617, domain.ErrorCode(query.err))
+}
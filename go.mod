@@ -2,4 +2,8 @@ module github.com/markgenuine/ever-client-go
 
 go 1.14
 
-require github.com/stretchr/testify v1.7.0
+require (
+	github.com/BurntSushi/toml v0.4.1
+	github.com/stretchr/testify v1.7.0
+	gopkg.in/yaml.v3 v3.0.1
+)
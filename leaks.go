@@ -0,0 +1,22 @@
+package goever
+
+import "time"
+
+// TB - part of testing.TB used by CheckHandleLeaks.
+type TB interface {
+	Helper()
+	Cleanup(func())
+	Errorf(format string, args ...interface{})
+}
+
+// CheckHandleLeaks fails the test when ever has open SDK handles at the end of it. Handles released by
+// Ever.Close or by deferred calls of the test aren't reported.
+func CheckHandleLeaks(t TB, ever *Ever) {
+	t.Helper()
+	t.Cleanup(func() {
+		for _, handle := range ever.OpenHandles() {
+			t.Errorf("%s %s opened by %s at %s is not released, age %s",
+				handle.Kind, handle.Handle, handle.Method, handle.CallSite, handle.Age().Round(time.Millisecond))
+		}
+	})
+}
@@ -0,0 +1,89 @@
+// Libmanager installs libton_client of the SDK version into the user cache and prints environment to use it.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/markgenuine/ever-client-go/gateway/client"
+	"github.com/markgenuine/ever-client-go/gateway/libmanager"
+)
+
+func main() {
+	version := flag.String("version", client.VersionLibSDK, "version of SDK")
+	target := flag.String("target", libmanager.HostTarget().String(), "platform in form GOOS/GOARCH or \"all\"")
+	mirror := flag.String("mirror", "", "directory with artifacts for offline install")
+	baseURL := flag.String("base-url", libmanager.DefaultBaseURL, "server with SDK binaries")
+	cacheDir := flag.String("cache", "", "directory of libraries, the user cache by default")
+	manifest := flag.String("manifest", "", "JSON manifest with checksums, libmanager.Pinned by default")
+	pin := flag.String("pin", "", "download artifacts and write their checksums to the manifest file instead of install")
+	flag.Parse()
+
+	targets, err := parseTargets(*target)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	opts := []libmanager.Option{libmanager.WithBaseURL(*baseURL)}
+	if *mirror != "" {
+		opts = append(opts, libmanager.WithMirror(*mirror))
+	}
+	if *cacheDir != "" {
+		opts = append(opts, libmanager.WithCacheDir(*cacheDir))
+	}
+	if *manifest != "" {
+		pinned, err := libmanager.LoadManifest(*manifest)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, libmanager.WithManifest(pinned))
+	}
+	manager := libmanager.NewManager(opts...)
+
+	ctx := context.Background()
+	if *pin != "" {
+		pinned, err := manager.Pin(ctx, *version, targets...)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := pinned.Save(*pin); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	for _, t := range targets {
+		path, err := manager.Install(ctx, *version, t)
+		if errors.Is(err, libmanager.ErrNotPinned) {
+			log.Fatalf("%s: pin it with -pin manifest.json, review the file and install with -manifest manifest.json", err)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("# %s\n", t)
+		for _, env := range libmanager.Env(path, t.GOOS) {
+			fmt.Println(env)
+		}
+	}
+}
+
+func parseTargets(value string) ([]libmanager.Target, error) {
+	if value == "all" {
+		return libmanager.Targets(), nil
+	}
+
+	var targets []libmanager.Target
+	for _, part := range strings.Split(value, ",") {
+		t, err := libmanager.ParseTarget(part)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, t)
+	}
+
+	return targets, nil
+}
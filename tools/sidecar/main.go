@@ -0,0 +1,28 @@
+//go:build cgo && !nocgo
+// +build cgo,!nocgo
+
+// Sidecar serves libton_client to bindings built with the nocgo tag, see client.NewRemoteTransport.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/markgenuine/ever-client-go/domain"
+	"github.com/markgenuine/ever-client-go/gateway/client"
+)
+
+func main() {
+	listen := flag.String("listen", "127.0.0.1:8090", "address to listen")
+	library := flag.String("library", "", "path of libton_client, see client.LocateLibrary")
+	flag.Parse()
+
+	transport, err := client.NewDefaultTransport(domain.ClientConfig{LibraryPath: *library})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("ever sidecar listens on %s", *listen)
+	log.Fatal(http.ListenAndServe(*listen, client.NewRemoteHandler(transport)))
+}
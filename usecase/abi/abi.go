@@ -1,6 +1,8 @@
 package abi
 
 import (
+	"context"
+
 	"github.com/markgenuine/ever-client-go/domain"
 )
 
@@ -21,68 +23,113 @@ func NewAbi(
 
 // EncodeMessageBody - Encode message body according to ABI function call.
 func (a *abi) EncodeMessageBody(pOEMB *domain.ParamsOfEncodeMessageBody) (*domain.ResultOfEncodeMessageBody, error) {
+	return a.EncodeMessageBodyContext(context.Background(), pOEMB)
+}
+
+// EncodeMessageBodyContext - EncodeMessageBody with ctx to cancel waiting for the result.
+func (a *abi) EncodeMessageBodyContext(ctx context.Context, pOEMB *domain.ParamsOfEncodeMessageBody) (*domain.ResultOfEncodeMessageBody, error) {
 	result := new(domain.ResultOfEncodeMessageBody)
-	err := a.client.GetResult("abi.encode_message_body", pOEMB, result)
+	err := a.client.GetResultContext(ctx, "abi.encode_message_body", pOEMB, result)
 	return result, err
 }
 
 // AttachSignatureToMessageBody - method attach_signature_to_message_body
 func (a *abi) AttachSignatureToMessageBody(pOASTMB *domain.ParamsOfAttachSignatureToMessageBody) (*domain.ResultOfAttachSignatureToMessageBody, error) {
+	return a.AttachSignatureToMessageBodyContext(context.Background(), pOASTMB)
+}
+
+// AttachSignatureToMessageBodyContext - AttachSignatureToMessageBody with ctx to cancel waiting for the result.
+func (a *abi) AttachSignatureToMessageBodyContext(ctx context.Context, pOASTMB *domain.ParamsOfAttachSignatureToMessageBody) (*domain.ResultOfAttachSignatureToMessageBody, error) {
 	result := new(domain.ResultOfAttachSignatureToMessageBody)
-	err := a.client.GetResult("abi.attach_signature_to_message_body", pOASTMB, result)
+	err := a.client.GetResultContext(ctx, "abi.attach_signature_to_message_body", pOASTMB, result)
 	return result, err
 }
 
 // EncodeMessage - Encodes an ABI-compatible message.
 // Allows to encode deploy and function call messages, both signed and unsigned.
 func (a *abi) EncodeMessage(pOEM *domain.ParamsOfEncodeMessage) (*domain.ResultOfEncodeMessage, error) {
+	return a.EncodeMessageContext(context.Background(), pOEM)
+}
+
+// EncodeMessageContext - EncodeMessage with ctx to cancel waiting for the result.
+func (a *abi) EncodeMessageContext(ctx context.Context, pOEM *domain.ParamsOfEncodeMessage) (*domain.ResultOfEncodeMessage, error) {
 	result := new(domain.ResultOfEncodeMessage)
-	err := a.client.GetResult("abi.encode_message", pOEM, result)
+	err := a.client.GetResultContext(ctx, "abi.encode_message", pOEM, result)
 	return result, err
 }
 
 // EncodeInternalMessage - Encodes an internal ABI-compatible message
 // Allows to encode deploy and function call messages.
 func (a *abi) EncodeInternalMessage(pOEIM *domain.ParamsOfEncodeInternalMessage) (*domain.ResultOfEncodeInternalMessage, error) {
+	return a.EncodeInternalMessageContext(context.Background(), pOEIM)
+}
+
+// EncodeInternalMessageContext - EncodeInternalMessage with ctx to cancel waiting for the result.
+func (a *abi) EncodeInternalMessageContext(ctx context.Context, pOEIM *domain.ParamsOfEncodeInternalMessage) (*domain.ResultOfEncodeInternalMessage, error) {
 	result := new(domain.ResultOfEncodeInternalMessage)
-	err := a.client.GetResult("abi.encode_internal_message", pOEIM, result)
+	err := a.client.GetResultContext(ctx, "abi.encode_internal_message", pOEIM, result)
 	return result, err
 }
 
 // AttachSignature - сombines hex-encoded signature with base64-encoded unsigned_message.
 // Returns signed message encoded in base64.
 func (a *abi) AttachSignature(pOAS *domain.ParamsOfAttachSignature) (*domain.ResultOfAttachSignature, error) {
+	return a.AttachSignatureContext(context.Background(), pOAS)
+}
+
+// AttachSignatureContext - AttachSignature with ctx to cancel waiting for the result.
+func (a *abi) AttachSignatureContext(ctx context.Context, pOAS *domain.ParamsOfAttachSignature) (*domain.ResultOfAttachSignature, error) {
 	result := new(domain.ResultOfAttachSignature)
-	err := a.client.GetResult("abi.attach_signature", pOAS, result)
+	err := a.client.GetResultContext(ctx, "abi.attach_signature", pOAS, result)
 	return result, err
 }
 
 // DecodeMessage Decodes message body using provided message BOC and ABI.
 func (a *abi) DecodeMessage(pODM *domain.ParamsOfDecodeMessage) (*domain.DecodedMessageBody, error) {
+	return a.DecodeMessageContext(context.Background(), pODM)
+}
+
+// DecodeMessageContext - DecodeMessage with ctx to cancel waiting for the result.
+func (a *abi) DecodeMessageContext(ctx context.Context, pODM *domain.ParamsOfDecodeMessage) (*domain.DecodedMessageBody, error) {
 	result := new(domain.DecodedMessageBody)
-	err := a.client.GetResult("abi.decode_message", pODM, result)
+	err := a.client.GetResultContext(ctx, "abi.decode_message", pODM, result)
 	return result, err
 }
 
 // DecodeMessageBody Decodes message body using provided body BOC and ABI.
 func (a *abi) DecodeMessageBody(pODMB *domain.ParamsOfDecodeMessageBody) (*domain.DecodedMessageBody, error) {
+	return a.DecodeMessageBodyContext(context.Background(), pODMB)
+}
+
+// DecodeMessageBodyContext - DecodeMessageBody with ctx to cancel waiting for the result.
+func (a *abi) DecodeMessageBodyContext(ctx context.Context, pODMB *domain.ParamsOfDecodeMessageBody) (*domain.DecodedMessageBody, error) {
 	result := new(domain.DecodedMessageBody)
-	err := a.client.GetResult("abi.decode_message_body", pODMB, result)
+	err := a.client.GetResultContext(ctx, "abi.decode_message_body", pODMB, result)
 	return result, err
 }
 
 // EncodeAccount Creates account state BOC.
 func (a *abi) EncodeAccount(pOEA *domain.ParamsOfEncodeAccount) (*domain.ResultOfEncodeAccount, error) {
+	return a.EncodeAccountContext(context.Background(), pOEA)
+}
+
+// EncodeAccountContext - EncodeAccount with ctx to cancel waiting for the result.
+func (a *abi) EncodeAccountContext(ctx context.Context, pOEA *domain.ParamsOfEncodeAccount) (*domain.ResultOfEncodeAccount, error) {
 	result := new(domain.ResultOfEncodeAccount)
-	err := a.client.GetResult("abi.encode_account", pOEA, result)
+	err := a.client.GetResultContext(ctx, "abi.encode_account", pOEA, result)
 	return result, err
 }
 
 // DecodeAccountData - Decodes account data using provided data BOC and ABI.
 // Note: this feature requires ABI 2.1 or higher.
 func (a *abi) DecodeAccountData(pODAD *domain.ParamsOfDecodeAccountData) (*domain.ResultOfDecodeData, error) {
+	return a.DecodeAccountDataContext(context.Background(), pODAD)
+}
+
+// DecodeAccountDataContext - DecodeAccountData with ctx to cancel waiting for the result.
+func (a *abi) DecodeAccountDataContext(ctx context.Context, pODAD *domain.ParamsOfDecodeAccountData) (*domain.ResultOfDecodeData, error) {
 	result := new(domain.ResultOfDecodeData)
-	err := a.client.GetResult("abi.decode_account_data", pODAD, result)
+	err := a.client.GetResultContext(ctx, "abi.decode_account_data", pODAD, result)
 	return result, err
 }
 
@@ -90,8 +137,13 @@ func (a *abi) DecodeAccountData(pODAD *domain.ParamsOfDecodeAccountData) (*domai
 // This operation is applicable only for initial account data (before deploy). If the contract is already deployed, its data doesn't contain
 // this data section any more.
 func (a *abi) UpdateInitialData(pOUID *domain.ParamsOfUpdateInitialData) (*domain.ResultOfUpdateInitialData, error) {
+	return a.UpdateInitialDataContext(context.Background(), pOUID)
+}
+
+// UpdateInitialDataContext - UpdateInitialData with ctx to cancel waiting for the result.
+func (a *abi) UpdateInitialDataContext(ctx context.Context, pOUID *domain.ParamsOfUpdateInitialData) (*domain.ResultOfUpdateInitialData, error) {
 	result := new(domain.ResultOfUpdateInitialData)
-	err := a.client.GetResult("abi.update_initial_data", pOUID, result)
+	err := a.client.GetResultContext(ctx, "abi.update_initial_data", pOUID, result)
 	return result, err
 }
 
@@ -99,8 +151,13 @@ func (a *abi) UpdateInitialData(pOUID *domain.ParamsOfUpdateInitialData) (*domai
 // a data BOC that can be passed to encode_tvc function afterwards.
 // This function is analogue of tvm.buildDataInit function in Solidity
 func (a *abi) EncodeInitialData(pOEID *domain.ParamsOfEncodeInitialData) (*domain.ResultOfEncodeInitialData, error) {
+	return a.EncodeInitialDataContext(context.Background(), pOEID)
+}
+
+// EncodeInitialDataContext - EncodeInitialData with ctx to cancel waiting for the result.
+func (a *abi) EncodeInitialDataContext(ctx context.Context, pOEID *domain.ParamsOfEncodeInitialData) (*domain.ResultOfEncodeInitialData, error) {
 	result := new(domain.ResultOfEncodeInitialData)
-	err := a.client.GetResult("abi.encode_initial_data", pOEID, result)
+	err := a.client.GetResultContext(ctx, "abi.encode_initial_data", pOEID, result)
 	return result, err
 }
 
@@ -108,8 +165,13 @@ func (a *abi) EncodeInitialData(pOEID *domain.ParamsOfEncodeInitialData) (*domai
 // This operation is applicable only for initial account data (before deploy). If the contract is already deployed, its data doesn't
 // contain this data section any more.
 func (a *abi) DecodeInitialData(pODID *domain.ParamsOfDecodeInitialData) (*domain.ResultOfDecodeInitialData, error) {
+	return a.DecodeInitialDataContext(context.Background(), pODID)
+}
+
+// DecodeInitialDataContext - DecodeInitialData with ctx to cancel waiting for the result.
+func (a *abi) DecodeInitialDataContext(ctx context.Context, pODID *domain.ParamsOfDecodeInitialData) (*domain.ResultOfDecodeInitialData, error) {
 	result := new(domain.ResultOfDecodeInitialData)
-	err := a.client.GetResult("abi.decode_initial_data", pODID, result)
+	err := a.client.GetResultContext(ctx, "abi.decode_initial_data", pODID, result)
 	return result, err
 }
 
@@ -127,28 +189,48 @@ func (a *abi) DecodeInitialData(pODID *domain.ParamsOfDecodeInitialData) (*domai
 // fields up to fork condition, check the parsed data manually, expand the parsing schema and then decode the whole BOC
 // with the full schema.
 func (a *abi) DecodeBoc(boc *domain.ParamsOfDecodeBoc) (*domain.ResultOfDecodeBoc, error) {
+	return a.DecodeBocContext(context.Background(), boc)
+}
+
+// DecodeBocContext - DecodeBoc with ctx to cancel waiting for the result.
+func (a *abi) DecodeBocContext(ctx context.Context, boc *domain.ParamsOfDecodeBoc) (*domain.ResultOfDecodeBoc, error) {
 	result := new(domain.ResultOfDecodeBoc)
-	err := a.client.GetResult("abi.decode_boc", boc, result)
+	err := a.client.GetResultContext(ctx, "abi.decode_boc", boc, result)
 	return result, err
 }
 
 // EncodeBoc - Encodes given parameters in JSON into a BOC using param types from ABI.
 func (a *abi) EncodeBoc(boc *domain.ParamsOfAbiEncodeBoc) (*domain.ResultOfAbiEncodeBoc, error) {
+	return a.EncodeBocContext(context.Background(), boc)
+}
+
+// EncodeBocContext - EncodeBoc with ctx to cancel waiting for the result.
+func (a *abi) EncodeBocContext(ctx context.Context, boc *domain.ParamsOfAbiEncodeBoc) (*domain.ResultOfAbiEncodeBoc, error) {
 	result := new(domain.ResultOfAbiEncodeBoc)
-	err := a.client.GetResult("abi.encode_boc", boc, result)
+	err := a.client.GetResultContext(ctx, "abi.encode_boc", boc, result)
 	return result, err
 }
 
 // CalcFunctionID - Calculates contract function ID by contract ABI
 func (a *abi) CalcFunctionID(functionID *domain.ParamsOfCalcFunctionId) (*domain.ResultOfCalcFunctionId, error) {
+	return a.CalcFunctionIDContext(context.Background(), functionID)
+}
+
+// CalcFunctionIDContext - CalcFunctionID with ctx to cancel waiting for the result.
+func (a *abi) CalcFunctionIDContext(ctx context.Context, functionID *domain.ParamsOfCalcFunctionId) (*domain.ResultOfCalcFunctionId, error) {
 	result := new(domain.ResultOfCalcFunctionId)
-	err := a.client.GetResult("abi.calc_function_id", functionID, result)
+	err := a.client.GetResultContext(ctx, "abi.calc_function_id", functionID, result)
 	return result, err
 }
 
 // GetSignatureData -Extracts signature from message body and calculates hash to verify the signature
 func (a *abi) GetSignatureData(data *domain.ParamsOfGetSignatureData) (*domain.ResultOfGetSignatureData, error) {
+	return a.GetSignatureDataContext(context.Background(), data)
+}
+
+// GetSignatureDataContext - GetSignatureData with ctx to cancel waiting for the result.
+func (a *abi) GetSignatureDataContext(ctx context.Context, data *domain.ParamsOfGetSignatureData) (*domain.ResultOfGetSignatureData, error) {
 	result := new(domain.ResultOfGetSignatureData)
-	err := a.client.GetResult("abi.get_signature_data", data, result)
+	err := a.client.GetResultContext(ctx, "abi.get_signature_data", data, result)
 	return result, err
 }
@@ -0,0 +1,23 @@
+package abi
+
+import "github.com/markgenuine/ever-client-go/domain"
+
+// Errors of abi module returned by SDK, use errors.Is to check them.
+var (
+	ErrRequiredAddressMissingForEncodeMessage    = domain.NewClientError(domain.AbiErrorCode, "RequiredAddressMissingForEncodeMessage")
+	ErrRequiredCallSetMissingForEncodeMessage    = domain.NewClientError(domain.AbiErrorCode, "RequiredCallSetMissingForEncodeMessage")
+	ErrInvalidJson                               = domain.NewClientError(domain.AbiErrorCode, "InvalidJson")
+	ErrInvalidMessage                            = domain.NewClientError(domain.AbiErrorCode, "InvalidMessage")
+	ErrEncodeDeployMessageFailed                 = domain.NewClientError(domain.AbiErrorCode, "EncodeDeployMessageFailed")
+	ErrEncodeRunMessageFailed                    = domain.NewClientError(domain.AbiErrorCode, "EncodeRunMessageFailed")
+	ErrAttachSignatureFailed                     = domain.NewClientError(domain.AbiErrorCode, "AttachSignatureFailed")
+	ErrInvalidTvcImage                           = domain.NewClientError(domain.AbiErrorCode, "InvalidTvcImage")
+	ErrRequiredPublicKeyMissingForFunctionHeader = domain.NewClientError(domain.AbiErrorCode, "RequiredPublicKeyMissingForFunctionHeader")
+	ErrInvalidSigner                             = domain.NewClientError(domain.AbiErrorCode, "InvalidSigner")
+	ErrInvalidAbi                                = domain.NewClientError(domain.AbiErrorCode, "InvalidAbi")
+	ErrInvalidFunctionId                         = domain.NewClientError(domain.AbiErrorCode, "InvalidFunctionId")
+	ErrInvalidData                               = domain.NewClientError(domain.AbiErrorCode, "InvalidData")
+	ErrEncodeInitialDataFailed                   = domain.NewClientError(domain.AbiErrorCode, "EncodeInitialDataFailed")
+	ErrInvalidFunctionName                       = domain.NewClientError(domain.AbiErrorCode, "InvalidFunctionName")
+	ErrPubKeyNotSupported                        = domain.NewClientError(domain.AbiErrorCode, "PubKeyNotSupported")
+)
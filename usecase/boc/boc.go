@@ -1,6 +1,10 @@
 package boc
 
-import "github.com/markgenuine/ever-client-go/domain"
+import (
+	"context"
+
+	"github.com/markgenuine/ever-client-go/domain"
+)
 
 type boc struct {
 	config domain.ClientConfig
@@ -19,90 +23,150 @@ func NewBoc(
 
 // DecodeTvc - Decodes contract's initial state into code, data, libraries and special options.
 func (b *boc) DecodeTvc(pODT *domain.ParamsOfDecodeTvc) (*domain.ResultOfDecodeTvc, error) {
+	return b.DecodeTvcContext(context.Background(), pODT)
+}
+
+// DecodeTvcContext - DecodeTvc with ctx to cancel waiting for the result.
+func (b *boc) DecodeTvcContext(ctx context.Context, pODT *domain.ParamsOfDecodeTvc) (*domain.ResultOfDecodeTvc, error) {
 	result := new(domain.ResultOfDecodeTvc)
-	err := b.client.GetResult("boc.decode_tvc", pODT, result)
+	err := b.client.GetResultContext(ctx, "boc.decode_tvc", pODT, result)
 	return result, err
 }
 
 // ParseMessage - Parses message boc into a JSON.
 // JSON structure is compatible with GraphQL API message object
 func (b *boc) ParseMessage(pOP *domain.ParamsOfParse) (*domain.ResultOfParse, error) {
+	return b.ParseMessageContext(context.Background(), pOP)
+}
+
+// ParseMessageContext - ParseMessage with ctx to cancel waiting for the result.
+func (b *boc) ParseMessageContext(ctx context.Context, pOP *domain.ParamsOfParse) (*domain.ResultOfParse, error) {
 	result := new(domain.ResultOfParse)
-	err := b.client.GetResult("boc.parse_message", pOP, result)
+	err := b.client.GetResultContext(ctx, "boc.parse_message", pOP, result)
 	return result, err
 }
 
 // ParseTransaction - Parses transaction boc into a JSON.
 // JSON structure is compatible with GraphQL API transaction object
 func (b *boc) ParseTransaction(pOP *domain.ParamsOfParse) (*domain.ResultOfParse, error) {
+	return b.ParseTransactionContext(context.Background(), pOP)
+}
+
+// ParseTransactionContext - ParseTransaction with ctx to cancel waiting for the result.
+func (b *boc) ParseTransactionContext(ctx context.Context, pOP *domain.ParamsOfParse) (*domain.ResultOfParse, error) {
 	result := new(domain.ResultOfParse)
-	err := b.client.GetResult("boc.parse_transaction", pOP, result)
+	err := b.client.GetResultContext(ctx, "boc.parse_transaction", pOP, result)
 	return result, err
 }
 
 // ParseAccount - Parses account boc into a JSON.
 // JSON structure is compatible with GraphQL API transaction object
 func (b *boc) ParseAccount(pOP *domain.ParamsOfParse) (*domain.ResultOfParse, error) {
+	return b.ParseAccountContext(context.Background(), pOP)
+}
+
+// ParseAccountContext - ParseAccount with ctx to cancel waiting for the result.
+func (b *boc) ParseAccountContext(ctx context.Context, pOP *domain.ParamsOfParse) (*domain.ResultOfParse, error) {
 	result := new(domain.ResultOfParse)
-	err := b.client.GetResult("boc.parse_account", pOP, result)
+	err := b.client.GetResultContext(ctx, "boc.parse_account", pOP, result)
 	return result, err
 }
 
 // ParseBlock - Parses block boc into a JSON.
 // JSON structure is compatible with GraphQL API transaction object
 func (b *boc) ParseBlock(pOP *domain.ParamsOfParse) (*domain.ResultOfParse, error) {
+	return b.ParseBlockContext(context.Background(), pOP)
+}
+
+// ParseBlockContext - ParseBlock with ctx to cancel waiting for the result.
+func (b *boc) ParseBlockContext(ctx context.Context, pOP *domain.ParamsOfParse) (*domain.ResultOfParse, error) {
 	result := new(domain.ResultOfParse)
-	err := b.client.GetResult("boc.parse_block", pOP, result)
+	err := b.client.GetResultContext(ctx, "boc.parse_block", pOP, result)
 	return result, err
 }
 
 // ParseShardstate - Parses shardstate boc into a JSON.
 // JSON structure is compatible with GraphQL API transaction object
 func (b *boc) ParseShardstate(pOPS *domain.ParamsOfParseShardstate) (*domain.ResultOfParse, error) {
+	return b.ParseShardstateContext(context.Background(), pOPS)
+}
+
+// ParseShardstateContext - ParseShardstate with ctx to cancel waiting for the result.
+func (b *boc) ParseShardstateContext(ctx context.Context, pOPS *domain.ParamsOfParseShardstate) (*domain.ResultOfParse, error) {
 	result := new(domain.ResultOfParse)
-	err := b.client.GetResult("boc.parse_shardstate", pOPS, result)
+	err := b.client.GetResultContext(ctx, "boc.parse_shardstate", pOPS, result)
 	return result, err
 }
 
 // GetBlockhainConfig - Extract blockchain configuration from key block and also from zerostate.
 func (b *boc) GetBlockhainConfig(pOGBC *domain.ParamsOfGetBlockchainConfig) (*domain.ResultOfGetBlockchainConfig, error) {
+	return b.GetBlockhainConfigContext(context.Background(), pOGBC)
+}
+
+// GetBlockhainConfigContext - GetBlockhainConfig with ctx to cancel waiting for the result.
+func (b *boc) GetBlockhainConfigContext(ctx context.Context, pOGBC *domain.ParamsOfGetBlockchainConfig) (*domain.ResultOfGetBlockchainConfig, error) {
 	result := new(domain.ResultOfGetBlockchainConfig)
-	err := b.client.GetResult("boc.get_blockchain_config", pOGBC, result)
+	err := b.client.GetResultContext(ctx, "boc.get_blockchain_config", pOGBC, result)
 	return result, err
 }
 
 // GetBocHash - Calculates BOC root hash.
 func (b *boc) GetBocHash(pOGBH *domain.ParamsOfGetBocHash) (*domain.ResultOfGetBocHash, error) {
+	return b.GetBocHashContext(context.Background(), pOGBH)
+}
+
+// GetBocHashContext - GetBocHash with ctx to cancel waiting for the result.
+func (b *boc) GetBocHashContext(ctx context.Context, pOGBH *domain.ParamsOfGetBocHash) (*domain.ResultOfGetBocHash, error) {
 	result := new(domain.ResultOfGetBocHash)
-	err := b.client.GetResult("boc.get_boc_hash", pOGBH, result)
+	err := b.client.GetResultContext(ctx, "boc.get_boc_hash", pOGBH, result)
 	return result, err
 }
 
 // GetBocDepth - Calculates BOC depth.
 func (b *boc) GetBocDepth(pOGBD *domain.ParamsOfGetBocDepth) (*domain.ResultOfGetBocDepth, error) {
+	return b.GetBocDepthContext(context.Background(), pOGBD)
+}
+
+// GetBocDepthContext - GetBocDepth with ctx to cancel waiting for the result.
+func (b *boc) GetBocDepthContext(ctx context.Context, pOGBD *domain.ParamsOfGetBocDepth) (*domain.ResultOfGetBocDepth, error) {
 	result := new(domain.ResultOfGetBocDepth)
-	err := b.client.GetResult("boc.get_boc_depth", pOGBD, result)
+	err := b.client.GetResultContext(ctx, "boc.get_boc_depth", pOGBD, result)
 	return result, err
 }
 
 // GetCodeFromTvc - Extracts code from TVC contract image.
 func (b *boc) GetCodeFromTvc(pOGCFT *domain.ParamsOfGetCodeFromTvc) (*domain.ResultOfGetCodeFromTvc, error) {
+	return b.GetCodeFromTvcContext(context.Background(), pOGCFT)
+}
+
+// GetCodeFromTvcContext - GetCodeFromTvc with ctx to cancel waiting for the result.
+func (b *boc) GetCodeFromTvcContext(ctx context.Context, pOGCFT *domain.ParamsOfGetCodeFromTvc) (*domain.ResultOfGetCodeFromTvc, error) {
 	result := new(domain.ResultOfGetCodeFromTvc)
-	err := b.client.GetResult("boc.get_code_from_tvc", pOGCFT, result)
+	err := b.client.GetResultContext(ctx, "boc.get_code_from_tvc", pOGCFT, result)
 	return result, err
 }
 
 // CacheGet - Get BOC from cache.
 func (b *boc) CacheGet(pOBCG *domain.ParamsOfBocCacheGet) (*domain.ResultOfBocCacheGet, error) {
+	return b.CacheGetContext(context.Background(), pOBCG)
+}
+
+// CacheGetContext - CacheGet with ctx to cancel waiting for the result.
+func (b *boc) CacheGetContext(ctx context.Context, pOBCG *domain.ParamsOfBocCacheGet) (*domain.ResultOfBocCacheGet, error) {
 	result := new(domain.ResultOfBocCacheGet)
-	err := b.client.GetResult("boc.cache_get", pOBCG, result)
+	err := b.client.GetResultContext(ctx, "boc.cache_get", pOBCG, result)
 	return result, err
 }
 
 // CacheSet - Save BOC into cache or increase pin counter for existing pinned BOC.
 func (b *boc) CacheSet(pOBCS *domain.ParamsOfBocCacheSet) (*domain.ResultOfBocCacheSet, error) {
+	return b.CacheSetContext(context.Background(), pOBCS)
+}
+
+// CacheSetContext - CacheSet with ctx to cancel waiting for the result.
+func (b *boc) CacheSetContext(ctx context.Context, pOBCS *domain.ParamsOfBocCacheSet) (*domain.ResultOfBocCacheSet, error) {
 	result := new(domain.ResultOfBocCacheSet)
-	err := b.client.GetResult("boc.cache_set", pOBCS, result)
+	err := b.client.GetResultContext(ctx, "boc.cache_set", pOBCS, result)
 	return result, err
 }
 
@@ -111,58 +175,98 @@ func (b *boc) CacheSet(pOBCS *domain.ParamsOfBocCacheSet) (*domain.ResultOfBocCa
 // the `cache_set`. BOCs which have only 1 pin and its reference counter
 // become 0 will be removed from cache
 func (b *boc) CacheUnpin(pOBCU *domain.ParamsOfBocCacheUnpin) error {
-	_, err := b.client.GetResponse("boc.cache_unpin", pOBCU)
+	return b.CacheUnpinContext(context.Background(), pOBCU)
+}
+
+// CacheUnpinContext - CacheUnpin with ctx to cancel waiting for the result.
+func (b *boc) CacheUnpinContext(ctx context.Context, pOBCU *domain.ParamsOfBocCacheUnpin) error {
+	_, err := b.client.GetResponseContext(ctx, "boc.cache_unpin", pOBCU)
 	return err
 }
 
 // EncodeBoc - Encodes bag of cells (BOC) with builder operations. This method provides the same functionality
 // as Solidity TvmBuilder. Resulting BOC of this method can be passed into Solidity and C++ contracts as TvmCell type.
 func (b *boc) EncodeBoc(pOEB *domain.ParamsOfEncodeBoc) (*domain.ResultOfEncodeBoc, error) {
+	return b.EncodeBocContext(context.Background(), pOEB)
+}
+
+// EncodeBocContext - EncodeBoc with ctx to cancel waiting for the result.
+func (b *boc) EncodeBocContext(ctx context.Context, pOEB *domain.ParamsOfEncodeBoc) (*domain.ResultOfEncodeBoc, error) {
 	result := new(domain.ResultOfEncodeBoc)
-	err := b.client.GetResult("boc.encode_boc", pOEB, result)
+	err := b.client.GetResultContext(ctx, "boc.encode_boc", pOEB, result)
 	return result, err
 }
 
 // GetCodeSalt - Returns the contract code's salt if it is present.
 func (b *boc) GetCodeSalt(pOGCS *domain.ParamsOfGetCodeSalt) (*domain.ResultOfGetCodeSalt, error) {
+	return b.GetCodeSaltContext(context.Background(), pOGCS)
+}
+
+// GetCodeSaltContext - GetCodeSalt with ctx to cancel waiting for the result.
+func (b *boc) GetCodeSaltContext(ctx context.Context, pOGCS *domain.ParamsOfGetCodeSalt) (*domain.ResultOfGetCodeSalt, error) {
 	result := new(domain.ResultOfGetCodeSalt)
-	err := b.client.GetResult("boc.get_code_salt", pOGCS, result)
+	err := b.client.GetResultContext(ctx, "boc.get_code_salt", pOGCS, result)
 	return result, err
 }
 
 // SetCodeSalt - Sets new salt to contract code.
 // Returns the new contract code with salt.
 func (b *boc) SetCodeSalt(pOSCS *domain.ParamsOfSetCodeSalt) (*domain.ResultOfSetCodeSalt, error) {
+	return b.SetCodeSaltContext(context.Background(), pOSCS)
+}
+
+// SetCodeSaltContext - SetCodeSalt with ctx to cancel waiting for the result.
+func (b *boc) SetCodeSaltContext(ctx context.Context, pOSCS *domain.ParamsOfSetCodeSalt) (*domain.ResultOfSetCodeSalt, error) {
 	result := new(domain.ResultOfSetCodeSalt)
-	err := b.client.GetResult("boc.set_code_salt", pOSCS, result)
+	err := b.client.GetResultContext(ctx, "boc.set_code_salt", pOSCS, result)
 	return result, err
 }
 
 // DecodeStateInit - Decodes tvc into code, data, libraries and special options.
 func (b *boc) DecodeStateInit(pODT *domain.ParamsOfDecodeStateInit) (*domain.ResultOfDecodeStateInit, error) {
+	return b.DecodeStateInitContext(context.Background(), pODT)
+}
+
+// DecodeStateInitContext - DecodeStateInit with ctx to cancel waiting for the result.
+func (b *boc) DecodeStateInitContext(ctx context.Context, pODT *domain.ParamsOfDecodeStateInit) (*domain.ResultOfDecodeStateInit, error) {
 	result := new(domain.ResultOfDecodeStateInit)
-	err := b.client.GetResult("boc.decode_state_init", pODT, result)
+	err := b.client.GetResultContext(ctx, "boc.decode_state_init", pODT, result)
 	return result, err
 }
 
 // EncodeStateInit - Encodes initial contract state from code, data, libraries ans special options (see input params).
 func (b *boc) EncodeStateInit(pOET *domain.ParamsOfEncodeStateInit) (*domain.ResultOfEncodeStateInit, error) {
+	return b.EncodeStateInitContext(context.Background(), pOET)
+}
+
+// EncodeStateInitContext - EncodeStateInit with ctx to cancel waiting for the result.
+func (b *boc) EncodeStateInitContext(ctx context.Context, pOET *domain.ParamsOfEncodeStateInit) (*domain.ResultOfEncodeStateInit, error) {
 	result := new(domain.ResultOfEncodeStateInit)
-	err := b.client.GetResult("boc.encode_state_init", pOET, result)
+	err := b.client.GetResultContext(ctx, "boc.encode_state_init", pOET, result)
 	return result, err
 }
 
 // EncodeExternalInMessage - Encodes a message.
 // Allows to encode any external inbound message.
 func (b *boc) EncodeExternalInMessage(pOEEIM *domain.ParamsOfEncodeExternalInMessage) (*domain.ResultOfEncodeExternalInMessage, error) {
+	return b.EncodeExternalInMessageContext(context.Background(), pOEEIM)
+}
+
+// EncodeExternalInMessageContext - EncodeExternalInMessage with ctx to cancel waiting for the result.
+func (b *boc) EncodeExternalInMessageContext(ctx context.Context, pOEEIM *domain.ParamsOfEncodeExternalInMessage) (*domain.ResultOfEncodeExternalInMessage, error) {
 	result := new(domain.ResultOfEncodeExternalInMessage)
-	err := b.client.GetResult("boc.encode_external_in_message", pOEEIM, result)
+	err := b.client.GetResultContext(ctx, "boc.encode_external_in_message", pOEEIM, result)
 	return result, err
 }
 
 // GetCompilerVersion - Returns the compiler version used to compile the code.
 func (b *boc) GetCompilerVersion(pOGCV *domain.ParamsOfGetCompilerVersion) (*domain.ResultOfGetCompilerVersion, error) {
+	return b.GetCompilerVersionContext(context.Background(), pOGCV)
+}
+
+// GetCompilerVersionContext - GetCompilerVersion with ctx to cancel waiting for the result.
+func (b *boc) GetCompilerVersionContext(ctx context.Context, pOGCV *domain.ParamsOfGetCompilerVersion) (*domain.ResultOfGetCompilerVersion, error) {
 	result := new(domain.ResultOfGetCompilerVersion)
-	err := b.client.GetResult("boc.get_compiler_version", pOGCV, result)
+	err := b.client.GetResultContext(ctx, "boc.get_compiler_version", pOGCV, result)
 	return result, err
 }
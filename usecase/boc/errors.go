@@ -0,0 +1,14 @@
+package boc
+
+import "github.com/markgenuine/ever-client-go/domain"
+
+// Errors of boc module returned by SDK, use errors.Is to check them.
+var (
+	ErrInvalidBoc            = domain.NewClientError(domain.BocErrorCode, "InvalidBoc")
+	ErrSerializationError    = domain.NewClientError(domain.BocErrorCode, "SerializationError")
+	ErrInappropriateBlock    = domain.NewClientError(domain.BocErrorCode, "InappropriateBlock")
+	ErrMissingSourceBoc      = domain.NewClientError(domain.BocErrorCode, "MissingSourceBoc")
+	ErrInsufficientCacheSize = domain.NewClientError(domain.BocErrorCode, "InsufficientCacheSize")
+	ErrBocRefNotFound        = domain.NewClientError(domain.BocErrorCode, "BocRefNotFound")
+	ErrInvalidBocRef         = domain.NewClientError(domain.BocErrorCode, "InvalidBocRef")
+)
@@ -1,9 +1,8 @@
 package crypto
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
 	"github.com/markgenuine/ever-client-go/domain"
 )
 
@@ -22,92 +21,157 @@ func NewCrypto(config domain.ClientConfig, client domain.ClientGateway) domain.C
 // Factorize - Performs prime factorization – decomposition of a composite number into a product
 // of smaller prime integers (factors).
 func (c *crypto) Factorize(poF *domain.ParamsOfFactorize) (*domain.ResultOfFactorize, error) {
+	return c.FactorizeContext(context.Background(), poF)
+}
+
+// FactorizeContext - Factorize with ctx to cancel waiting for the result.
+func (c *crypto) FactorizeContext(ctx context.Context, poF *domain.ParamsOfFactorize) (*domain.ResultOfFactorize, error) {
 	result := new(domain.ResultOfFactorize)
-	err := c.client.GetResult("crypto.factorize", poF, result)
+	err := c.client.GetResultContext(ctx, "crypto.factorize", poF, result)
 	return result, err
 }
 
 // ModularPower - Performs modular exponentiation for big integers (base^exponent mod modulus).
 func (c *crypto) ModularPower(pOMP *domain.ParamsOfModularPower) (*domain.ResultOfModularPower, error) {
+	return c.ModularPowerContext(context.Background(), pOMP)
+}
+
+// ModularPowerContext - ModularPower with ctx to cancel waiting for the result.
+func (c *crypto) ModularPowerContext(ctx context.Context, pOMP *domain.ParamsOfModularPower) (*domain.ResultOfModularPower, error) {
 	result := new(domain.ResultOfModularPower)
-	err := c.client.GetResult("crypto.modular_power", pOMP, result)
+	err := c.client.GetResultContext(ctx, "crypto.modular_power", pOMP, result)
 	return result, err
 }
 
 // TonCrc16 - Calculates CRC16 using TON algorithm.
 func (c *crypto) TonCrc16(pOTC *domain.ParamsOfTonCrc16) (*domain.ResultOfTonCrc16, error) {
+	return c.TonCrc16Context(context.Background(), pOTC)
+}
+
+// TonCrc16Context - TonCrc16 with ctx to cancel waiting for the result.
+func (c *crypto) TonCrc16Context(ctx context.Context, pOTC *domain.ParamsOfTonCrc16) (*domain.ResultOfTonCrc16, error) {
 	result := new(domain.ResultOfTonCrc16)
-	err := c.client.GetResult("crypto.ton_crc16", pOTC, result)
+	err := c.client.GetResultContext(ctx, "crypto.ton_crc16", pOTC, result)
 	return result, err
 }
 
 // GenerateRandomBytes - Generates random byte array of the specified length and returns it in base64 format.
 func (c *crypto) GenerateRandomBytes(pOGRB *domain.ParamsOfGenerateRandomBytes) (*domain.ResultOfGenerateRandomBytes, error) {
+	return c.GenerateRandomBytesContext(context.Background(), pOGRB)
+}
+
+// GenerateRandomBytesContext - GenerateRandomBytes with ctx to cancel waiting for the result.
+func (c *crypto) GenerateRandomBytesContext(ctx context.Context, pOGRB *domain.ParamsOfGenerateRandomBytes) (*domain.ResultOfGenerateRandomBytes, error) {
 	result := new(domain.ResultOfGenerateRandomBytes)
-	err := c.client.GetResult("crypto.generate_random_bytes", pOGRB, result)
+	err := c.client.GetResultContext(ctx, "crypto.generate_random_bytes", pOGRB, result)
 	return result, err
 }
 
 // ConvertPublicKeyString - Converts public key to ton safe_format.
 func (c *crypto) ConvertPublicKeyString(pOCPTTSF *domain.ParamsOfConvertPublicKeyToTonSafeFormat) (*domain.ResultOfConvertPublicKeyToTonSafeFormat, error) {
+	return c.ConvertPublicKeyStringContext(context.Background(), pOCPTTSF)
+}
+
+// ConvertPublicKeyStringContext - ConvertPublicKeyString with ctx to cancel waiting for the result.
+func (c *crypto) ConvertPublicKeyStringContext(ctx context.Context, pOCPTTSF *domain.ParamsOfConvertPublicKeyToTonSafeFormat) (*domain.ResultOfConvertPublicKeyToTonSafeFormat, error) {
 	result := new(domain.ResultOfConvertPublicKeyToTonSafeFormat)
-	err := c.client.GetResult("crypto.convert_public_key_to_ton_safe_format", pOCPTTSF, result)
+	err := c.client.GetResultContext(ctx, "crypto.convert_public_key_to_ton_safe_format", pOCPTTSF, result)
 	return result, err
 }
 
 // GenerateRandomSignKeys - Generates random ed25519 key pair.
 func (c *crypto) GenerateRandomSignKeys() (*domain.KeyPair, error) {
+	return c.GenerateRandomSignKeysContext(context.Background())
+}
+
+// GenerateRandomSignKeysContext - GenerateRandomSignKeys with ctx to cancel waiting for the result.
+func (c *crypto) GenerateRandomSignKeysContext(ctx context.Context) (*domain.KeyPair, error) {
 	result := new(domain.KeyPair)
-	err := c.client.GetResult("crypto.generate_random_sign_keys", "{}", result)
+	err := c.client.GetResultContext(ctx, "crypto.generate_random_sign_keys", "{}", result)
 	return result, err
 }
 
 // Sign - Signs a data using the provided keys.
 func (c *crypto) Sign(pOS *domain.ParamsOfSign) (*domain.ResultOfSign, error) {
+	return c.SignContext(context.Background(), pOS)
+}
+
+// SignContext - Sign with ctx to cancel waiting for the result.
+func (c *crypto) SignContext(ctx context.Context, pOS *domain.ParamsOfSign) (*domain.ResultOfSign, error) {
 	result := new(domain.ResultOfSign)
-	err := c.client.GetResult("crypto.sign", pOS, result)
+	err := c.client.GetResultContext(ctx, "crypto.sign", pOS, result)
 	return result, err
 }
 
 // VerifySignature - Verifies signed data using the provided public key. Raises error if verification is failed.
 func (c *crypto) VerifySignature(pOVS *domain.ParamsOfVerifySignature) (*domain.ResultOfVerifySignature, error) {
+	return c.VerifySignatureContext(context.Background(), pOVS)
+}
+
+// VerifySignatureContext - VerifySignature with ctx to cancel waiting for the result.
+func (c *crypto) VerifySignatureContext(ctx context.Context, pOVS *domain.ParamsOfVerifySignature) (*domain.ResultOfVerifySignature, error) {
 	result := new(domain.ResultOfVerifySignature)
-	err := c.client.GetResult("crypto.verify_signature", pOVS, result)
+	err := c.client.GetResultContext(ctx, "crypto.verify_signature", pOVS, result)
 	return result, err
 }
 
 // Sha256 - Calculates SHA256 hash of the specified data.
 func (c *crypto) Sha256(pOH *domain.ParamsOfHash) (*domain.ResultOfHash, error) {
+	return c.Sha256Context(context.Background(), pOH)
+}
+
+// Sha256Context - Sha256 with ctx to cancel waiting for the result.
+func (c *crypto) Sha256Context(ctx context.Context, pOH *domain.ParamsOfHash) (*domain.ResultOfHash, error) {
 	result := new(domain.ResultOfHash)
-	err := c.client.GetResult("crypto.sha256", pOH, result)
+	err := c.client.GetResultContext(ctx, "crypto.sha256", pOH, result)
 	return result, err
 }
 
 // Sha512 - Calculates SHA512 hash of the specified data.
 func (c *crypto) Sha512(pOH *domain.ParamsOfHash) (*domain.ResultOfHash, error) {
+	return c.Sha512Context(context.Background(), pOH)
+}
+
+// Sha512Context - Sha512 with ctx to cancel waiting for the result.
+func (c *crypto) Sha512Context(ctx context.Context, pOH *domain.ParamsOfHash) (*domain.ResultOfHash, error) {
 	result := new(domain.ResultOfHash)
-	err := c.client.GetResult("crypto.sha512", pOH, result)
+	err := c.client.GetResultContext(ctx, "crypto.sha512", pOH, result)
 	return result, err
 }
 
 // Scrypt - Derives key from password and key using scrypt algorithm.
 func (c *crypto) Scrypt(sD *domain.ParamsOfScrypt) (*domain.ResultOfScrypt, error) {
+	return c.ScryptContext(context.Background(), sD)
+}
+
+// ScryptContext - Scrypt with ctx to cancel waiting for the result.
+func (c *crypto) ScryptContext(ctx context.Context, sD *domain.ParamsOfScrypt) (*domain.ResultOfScrypt, error) {
 	result := new(domain.ResultOfScrypt)
-	err := c.client.GetResult("crypto.scrypt", sD, result)
+	err := c.client.GetResultContext(ctx, "crypto.scrypt", sD, result)
 	return result, err
 }
 
 // NaclSignKeypairFromSecretKey - Generates a key pair for signing from the secret key.
 func (c *crypto) NaclSignKeypairFromSecretKey(pONSKPFC *domain.ParamsOfNaclSignKeyPairFromSecret) (*domain.KeyPair, error) {
+	return c.NaclSignKeypairFromSecretKeyContext(context.Background(), pONSKPFC)
+}
+
+// NaclSignKeypairFromSecretKeyContext - NaclSignKeypairFromSecretKey with ctx to cancel waiting for the result.
+func (c *crypto) NaclSignKeypairFromSecretKeyContext(ctx context.Context, pONSKPFC *domain.ParamsOfNaclSignKeyPairFromSecret) (*domain.KeyPair, error) {
 	result := new(domain.KeyPair)
-	err := c.client.GetResult("crypto.nacl_sign_keypair_from_secret_key", pONSKPFC, result)
+	err := c.client.GetResultContext(ctx, "crypto.nacl_sign_keypair_from_secret_key", pONSKPFC, result)
 	return result, err
 }
 
 // NaclSign - Signs data using the signer's secret key.
 func (c *crypto) NaclSign(pONS *domain.ParamsOfNaclSign) (*domain.ResultOfNaclSign, error) {
+	return c.NaclSignContext(context.Background(), pONS)
+}
+
+// NaclSignContext - NaclSign with ctx to cancel waiting for the result.
+func (c *crypto) NaclSignContext(ctx context.Context, pONS *domain.ParamsOfNaclSign) (*domain.ResultOfNaclSign, error) {
 	result := new(domain.ResultOfNaclSign)
-	err := c.client.GetResult("crypto.nacl_sign", pONS, result)
+	err := c.client.GetResultContext(ctx, "crypto.nacl_sign", pONS, result)
 	return result, err
 }
 
@@ -115,146 +179,246 @@ func (c *crypto) NaclSign(pONS *domain.ParamsOfNaclSign) (*domain.ResultOfNaclSi
 // Verifies the signature in signed using the signer's public key public and returns the message unsigned.
 // If the signature fails verification, crypto_sign_open raises an exception.
 func (c *crypto) NaclSignOpen(pONSO *domain.ParamsOfNaclSignOpen) (*domain.ResultOfNaclSignOpen, error) {
+	return c.NaclSignOpenContext(context.Background(), pONSO)
+}
+
+// NaclSignOpenContext - NaclSignOpen with ctx to cancel waiting for the result.
+func (c *crypto) NaclSignOpenContext(ctx context.Context, pONSO *domain.ParamsOfNaclSignOpen) (*domain.ResultOfNaclSignOpen, error) {
 	result := new(domain.ResultOfNaclSignOpen)
-	err := c.client.GetResult("crypto.nacl_sign_open", pONSO, result)
+	err := c.client.GetResultContext(ctx, "crypto.nacl_sign_open", pONSO, result)
 	return result, err
 }
 
 // NaclSignDetached - Signs the message using the secret key and returns a signature.
 // Signs the message unsigned using the secret key secret and returns a signature signature.
 func (c *crypto) NaclSignDetached(pONS *domain.ParamsOfNaclSign) (*domain.ResultOfNaclSignDetached, error) {
+	return c.NaclSignDetachedContext(context.Background(), pONS)
+}
+
+// NaclSignDetachedContext - NaclSignDetached with ctx to cancel waiting for the result.
+func (c *crypto) NaclSignDetachedContext(ctx context.Context, pONS *domain.ParamsOfNaclSign) (*domain.ResultOfNaclSignDetached, error) {
 	result := new(domain.ResultOfNaclSignDetached)
-	err := c.client.GetResult("crypto.nacl_sign_detached", pONS, result)
+	err := c.client.GetResultContext(ctx, "crypto.nacl_sign_detached", pONS, result)
 	return result, err
 }
 
 // NaclSignDetachedVerify - Verifies the signature with public key and unsigned data.
 func (c *crypto) NaclSignDetachedVerify(pONSDV *domain.ParamsOfNaclSignDetachedVerify) (*domain.ResultOfNaclSignDetachedVerify, error) {
+	return c.NaclSignDetachedVerifyContext(context.Background(), pONSDV)
+}
+
+// NaclSignDetachedVerifyContext - NaclSignDetachedVerify with ctx to cancel waiting for the result.
+func (c *crypto) NaclSignDetachedVerifyContext(ctx context.Context, pONSDV *domain.ParamsOfNaclSignDetachedVerify) (*domain.ResultOfNaclSignDetachedVerify, error) {
 	result := new(domain.ResultOfNaclSignDetachedVerify)
-	err := c.client.GetResult("crypto.nacl_sign_detached_verify", pONSDV, result)
+	err := c.client.GetResultContext(ctx, "crypto.nacl_sign_detached_verify", pONSDV, result)
 	return result, err
 }
 
 // NaclBoxKeypair - Generates a random NaCl key pair.
 func (c *crypto) NaclBoxKeypair() (*domain.KeyPair, error) {
+	return c.NaclBoxKeypairContext(context.Background())
+}
+
+// NaclBoxKeypairContext - NaclBoxKeypair with ctx to cancel waiting for the result.
+func (c *crypto) NaclBoxKeypairContext(ctx context.Context) (*domain.KeyPair, error) {
 	result := new(domain.KeyPair)
-	err := c.client.GetResult("crypto.nacl_box_keypair", "{}", result)
+	err := c.client.GetResultContext(ctx, "crypto.nacl_box_keypair", "{}", result)
 	return result, err
 }
 
 // NaclBoxKeypairFromSecretKey - Generates key pair from a secret key.
 func (c *crypto) NaclBoxKeypairFromSecretKey(pONKPFS *domain.ParamsOfNaclBoxKeyPairFromSecret) (*domain.KeyPair, error) {
+	return c.NaclBoxKeypairFromSecretKeyContext(context.Background(), pONKPFS)
+}
+
+// NaclBoxKeypairFromSecretKeyContext - NaclBoxKeypairFromSecretKey with ctx to cancel waiting for the result.
+func (c *crypto) NaclBoxKeypairFromSecretKeyContext(ctx context.Context, pONKPFS *domain.ParamsOfNaclBoxKeyPairFromSecret) (*domain.KeyPair, error) {
 	result := new(domain.KeyPair)
-	err := c.client.GetResult("crypto.nacl_box_keypair_from_secret_key", pONKPFS, result)
+	err := c.client.GetResultContext(ctx, "crypto.nacl_box_keypair_from_secret_key", pONKPFS, result)
 	return result, err
 }
 
 // NaclBox - Public key authenticated encryption. Encrypt and authenticate a message using
 // the senders secret key, the receivers public key, and a nonce.
 func (c *crypto) NaclBox(pONB *domain.ParamsOfNaclBox) (*domain.ResultOfNaclBox, error) {
+	return c.NaclBoxContext(context.Background(), pONB)
+}
+
+// NaclBoxContext - NaclBox with ctx to cancel waiting for the result.
+func (c *crypto) NaclBoxContext(ctx context.Context, pONB *domain.ParamsOfNaclBox) (*domain.ResultOfNaclBox, error) {
 	result := new(domain.ResultOfNaclBox)
-	err := c.client.GetResult("crypto.nacl_box", pONB, result)
+	err := c.client.GetResultContext(ctx, "crypto.nacl_box", pONB, result)
 	return result, err
 }
 
 // NaclBoxOpen - Decrypt and verify the cipher text using the recievers secret key, the senders public
 // key, and the nonce.
 func (c *crypto) NaclBoxOpen(pONBO *domain.ParamsOfNaclBoxOpen) (*domain.ResultOfNaclBoxOpen, error) {
+	return c.NaclBoxOpenContext(context.Background(), pONBO)
+}
+
+// NaclBoxOpenContext - NaclBoxOpen with ctx to cancel waiting for the result.
+func (c *crypto) NaclBoxOpenContext(ctx context.Context, pONBO *domain.ParamsOfNaclBoxOpen) (*domain.ResultOfNaclBoxOpen, error) {
 	result := new(domain.ResultOfNaclBoxOpen)
-	err := c.client.GetResult("crypto.nacl_box_open", pONBO, result)
+	err := c.client.GetResultContext(ctx, "crypto.nacl_box_open", pONBO, result)
 	return result, err
 }
 
 // NaclSecretBox - Encrypt and authenticate message using nonce and secret key.
 func (c *crypto) NaclSecretBox(pONSB *domain.ParamsOfNaclSecretBox) (*domain.ResultOfNaclBox, error) {
+	return c.NaclSecretBoxContext(context.Background(), pONSB)
+}
+
+// NaclSecretBoxContext - NaclSecretBox with ctx to cancel waiting for the result.
+func (c *crypto) NaclSecretBoxContext(ctx context.Context, pONSB *domain.ParamsOfNaclSecretBox) (*domain.ResultOfNaclBox, error) {
 	result := new(domain.ResultOfNaclBox)
-	err := c.client.GetResult("crypto.nacl_secret_box", pONSB, result)
+	err := c.client.GetResultContext(ctx, "crypto.nacl_secret_box", pONSB, result)
 	return result, err
 }
 
 // NaclSecretBoxOpen - Decrypts and verifies cipher text using nonce and secret key.
 func (c *crypto) NaclSecretBoxOpen(pONSBO *domain.ParamsOfNaclSecretBoxOpen) (*domain.ResultOfNaclBoxOpen, error) {
+	return c.NaclSecretBoxOpenContext(context.Background(), pONSBO)
+}
+
+// NaclSecretBoxOpenContext - NaclSecretBoxOpen with ctx to cancel waiting for the result.
+func (c *crypto) NaclSecretBoxOpenContext(ctx context.Context, pONSBO *domain.ParamsOfNaclSecretBoxOpen) (*domain.ResultOfNaclBoxOpen, error) {
 	result := new(domain.ResultOfNaclBoxOpen)
-	err := c.client.GetResult("crypto.nacl_secret_box_open", pONSBO, result)
+	err := c.client.GetResultContext(ctx, "crypto.nacl_secret_box_open", pONSBO, result)
 	return result, err
 }
 
 // MnemonicWords - Prints the list of words from the specified dictionary.
 func (c *crypto) MnemonicWords(pOMW *domain.ParamsOfMnemonicWords) (*domain.ResultOfMnemonicWords, error) {
+	return c.MnemonicWordsContext(context.Background(), pOMW)
+}
+
+// MnemonicWordsContext - MnemonicWords with ctx to cancel waiting for the result.
+func (c *crypto) MnemonicWordsContext(ctx context.Context, pOMW *domain.ParamsOfMnemonicWords) (*domain.ResultOfMnemonicWords, error) {
 	result := new(domain.ResultOfMnemonicWords)
-	err := c.client.GetResult("crypto.mnemonic_words", pOMW, result)
+	err := c.client.GetResultContext(ctx, "crypto.mnemonic_words", pOMW, result)
 	return result, err
 }
 
 // MnemonicFromRandom - Generates a random mnemonic from the specified dictionary and word count.
 func (c *crypto) MnemonicFromRandom(pOMFR *domain.ParamsOfMnemonicFromRandom) (*domain.ResultOfMnemonicFromRandom, error) {
+	return c.MnemonicFromRandomContext(context.Background(), pOMFR)
+}
+
+// MnemonicFromRandomContext - MnemonicFromRandom with ctx to cancel waiting for the result.
+func (c *crypto) MnemonicFromRandomContext(ctx context.Context, pOMFR *domain.ParamsOfMnemonicFromRandom) (*domain.ResultOfMnemonicFromRandom, error) {
 	result := new(domain.ResultOfMnemonicFromRandom)
-	err := c.client.GetResult("crypto.mnemonic_from_random", pOMFR, result)
+	err := c.client.GetResultContext(ctx, "crypto.mnemonic_from_random", pOMFR, result)
 	return result, err
 }
 
 // MnemonicFromEntropy - Generates mnemonic from pre-generated entropy.
 func (c *crypto) MnemonicFromEntropy(pOMFE *domain.ParamsOfMnemonicFromEntropy) (*domain.ResultOfMnemonicFromEntropy, error) {
+	return c.MnemonicFromEntropyContext(context.Background(), pOMFE)
+}
+
+// MnemonicFromEntropyContext - MnemonicFromEntropy with ctx to cancel waiting for the result.
+func (c *crypto) MnemonicFromEntropyContext(ctx context.Context, pOMFE *domain.ParamsOfMnemonicFromEntropy) (*domain.ResultOfMnemonicFromEntropy, error) {
 	result := new(domain.ResultOfMnemonicFromEntropy)
-	err := c.client.GetResult("crypto.mnemonic_from_entropy", pOMFE, result)
+	err := c.client.GetResultContext(ctx, "crypto.mnemonic_from_entropy", pOMFE, result)
 	return result, err
 }
 
 // MnemonicVerify - The phrase supplied will be checked for word length and validated according to the
 // checksum specified in BIP0039.
 func (c *crypto) MnemonicVerify(pOMV *domain.ParamsOfMnemonicVerify) (*domain.ResultOfMnemonicVerify, error) {
+	return c.MnemonicVerifyContext(context.Background(), pOMV)
+}
+
+// MnemonicVerifyContext - MnemonicVerify with ctx to cancel waiting for the result.
+func (c *crypto) MnemonicVerifyContext(ctx context.Context, pOMV *domain.ParamsOfMnemonicVerify) (*domain.ResultOfMnemonicVerify, error) {
 	result := new(domain.ResultOfMnemonicVerify)
-	err := c.client.GetResult("crypto.mnemonic_verify", pOMV, result)
+	err := c.client.GetResultContext(ctx, "crypto.mnemonic_verify", pOMV, result)
 	return result, err
 }
 
 // MnemonicDeriveSignKeys - Validates the seed phrase, generates master key and then derives the key pair from
 // the master key and the specified path.
 func (c *crypto) MnemonicDeriveSignKeys(pOMDSK *domain.ParamsOfMnemonicDeriveSignKeys) (*domain.KeyPair, error) {
+	return c.MnemonicDeriveSignKeysContext(context.Background(), pOMDSK)
+}
+
+// MnemonicDeriveSignKeysContext - MnemonicDeriveSignKeys with ctx to cancel waiting for the result.
+func (c *crypto) MnemonicDeriveSignKeysContext(ctx context.Context, pOMDSK *domain.ParamsOfMnemonicDeriveSignKeys) (*domain.KeyPair, error) {
 	result := new(domain.KeyPair)
-	err := c.client.GetResult("crypto.mnemonic_derive_sign_keys", pOMDSK, result)
+	err := c.client.GetResultContext(ctx, "crypto.mnemonic_derive_sign_keys", pOMDSK, result)
 	return result, err
 }
 
 // HDKeyXprvFromMnemonic - Generates an extended master private key that will be the root for all the derived keys.
 func (c *crypto) HDKeyXprvFromMnemonic(pOHKXFM *domain.ParamsOfHDKeyXPrvFromMnemonic) (*domain.ResultOfHDKeyXPrvFromMnemonic, error) {
+	return c.HDKeyXprvFromMnemonicContext(context.Background(), pOHKXFM)
+}
+
+// HDKeyXprvFromMnemonicContext - HDKeyXprvFromMnemonic with ctx to cancel waiting for the result.
+func (c *crypto) HDKeyXprvFromMnemonicContext(ctx context.Context, pOHKXFM *domain.ParamsOfHDKeyXPrvFromMnemonic) (*domain.ResultOfHDKeyXPrvFromMnemonic, error) {
 	result := new(domain.ResultOfHDKeyXPrvFromMnemonic)
-	err := c.client.GetResult("crypto.hdkey_xprv_from_mnemonic", pOHKXFM, result)
+	err := c.client.GetResultContext(ctx, "crypto.hdkey_xprv_from_mnemonic", pOHKXFM, result)
 	return result, err
 }
 
 // HDKeyDeriveFromXprv - Returns extended private key derived from the specified extended private key and child index.
 func (c *crypto) HDKeyDeriveFromXprv(hdP *domain.ParamsOfHDKeyDeriveFromXPrv) (*domain.ResultOfHDKeyDeriveFromXPrv, error) {
+	return c.HDKeyDeriveFromXprvContext(context.Background(), hdP)
+}
+
+// HDKeyDeriveFromXprvContext - HDKeyDeriveFromXprv with ctx to cancel waiting for the result.
+func (c *crypto) HDKeyDeriveFromXprvContext(ctx context.Context, hdP *domain.ParamsOfHDKeyDeriveFromXPrv) (*domain.ResultOfHDKeyDeriveFromXPrv, error) {
 	result := new(domain.ResultOfHDKeyDeriveFromXPrv)
-	err := c.client.GetResult("crypto.hdkey_derive_from_xprv", hdP, result)
+	err := c.client.GetResultContext(ctx, "crypto.hdkey_derive_from_xprv", hdP, result)
 	return result, err
 }
 
 // HDKeyDeriveFromXprvPath - Derives the extended private key from the specified key and path.
 func (c *crypto) HDKeyDeriveFromXprvPath(hdPD *domain.ParamsOfHDKeyDeriveFromXPrvPath) (*domain.ResultOfHDKeyDeriveFromXPrvPath, error) {
+	return c.HDKeyDeriveFromXprvPathContext(context.Background(), hdPD)
+}
+
+// HDKeyDeriveFromXprvPathContext - HDKeyDeriveFromXprvPath with ctx to cancel waiting for the result.
+func (c *crypto) HDKeyDeriveFromXprvPathContext(ctx context.Context, hdPD *domain.ParamsOfHDKeyDeriveFromXPrvPath) (*domain.ResultOfHDKeyDeriveFromXPrvPath, error) {
 	result := new(domain.ResultOfHDKeyDeriveFromXPrvPath)
-	err := c.client.GetResult("crypto.hdkey_derive_from_xprv_path", hdPD, result)
+	err := c.client.GetResultContext(ctx, "crypto.hdkey_derive_from_xprv_path", hdPD, result)
 	return result, err
 }
 
 // HDKeySecretFromXprv - Extracts the private key from the serialized extended private key.
 func (c *crypto) HDKeySecretFromXprv(pOHKSFXP *domain.ParamsOfHDKeySecretFromXPrv) (*domain.ResultOfHDKeySecretFromXPrv, error) {
+	return c.HDKeySecretFromXprvContext(context.Background(), pOHKSFXP)
+}
+
+// HDKeySecretFromXprvContext - HDKeySecretFromXprv with ctx to cancel waiting for the result.
+func (c *crypto) HDKeySecretFromXprvContext(ctx context.Context, pOHKSFXP *domain.ParamsOfHDKeySecretFromXPrv) (*domain.ResultOfHDKeySecretFromXPrv, error) {
 	result := new(domain.ResultOfHDKeySecretFromXPrv)
-	err := c.client.GetResult("crypto.hdkey_secret_from_xprv", pOHKSFXP, result)
+	err := c.client.GetResultContext(ctx, "crypto.hdkey_secret_from_xprv", pOHKSFXP, result)
 	return result, err
 }
 
 // HDKeyPublicFromXprv - Extracts the public key from the serialized extended private key.
 func (c *crypto) HDKeyPublicFromXprv(pOHKPFXP *domain.ParamsOfHDKeyPublicFromXPrv) (*domain.ResultOfHDKeyPublicFromXPrv, error) {
+	return c.HDKeyPublicFromXprvContext(context.Background(), pOHKPFXP)
+}
+
+// HDKeyPublicFromXprvContext - HDKeyPublicFromXprv with ctx to cancel waiting for the result.
+func (c *crypto) HDKeyPublicFromXprvContext(ctx context.Context, pOHKPFXP *domain.ParamsOfHDKeyPublicFromXPrv) (*domain.ResultOfHDKeyPublicFromXPrv, error) {
 	result := new(domain.ResultOfHDKeyPublicFromXPrv)
-	err := c.client.GetResult("crypto.hdkey_public_from_xprv", pOHKPFXP, result)
+	err := c.client.GetResultContext(ctx, "crypto.hdkey_public_from_xprv", pOHKPFXP, result)
 	return result, err
 }
 
 // Chacha20 - Performs symmetric chacha20 encryption.
 func (c *crypto) Chacha20(pOFCC *domain.ParamsOfChaCha20) (*domain.ResultOfChaCha20, error) {
+	return c.Chacha20Context(context.Background(), pOFCC)
+}
+
+// Chacha20Context - Chacha20 with ctx to cancel waiting for the result.
+func (c *crypto) Chacha20Context(ctx context.Context, pOFCC *domain.ParamsOfChaCha20) (*domain.ResultOfChaCha20, error) {
 	result := new(domain.ResultOfChaCha20)
-	err := c.client.GetResult("crypto.chacha20", pOFCC, result)
+	err := c.client.GetResultContext(ctx, "crypto.chacha20", pOFCC, result)
 	return result, err
 }
 
@@ -267,95 +431,89 @@ func (c *crypto) Chacha20(pOFCC *domain.ParamsOfChaCha20) (*domain.ResultOfChaCh
 // overwritten with zeroes.
 func (c *crypto) CreateCryptoBox(pOCCB *domain.ParamsOfCreateCryptoBox, app domain.AppPasswordProvider) (*domain.RegisteredCryptoBox, error) {
 	result := new(domain.RegisteredCryptoBox)
-	responses, err := c.client.Request("crypto.create_crypto_box", pOCCB)
+	stream, err := c.client.Request("crypto.create_crypto_box", pOCCB)
 	if err != nil {
 		return nil, err
 	}
-	response := <-responses
-	if response.Code == 1 {
-		return nil, response.Error
-	}
-
-	if err := json.Unmarshal(response.Data, result); err != nil {
+	if err := stream.Result(result); err != nil {
 		return nil, err
 	}
 
-	go func() {
-		for r := range responses {
-			if r.Code == 3 {
-				c.appRequestCreateCryptoBox(r.Data, app)
-			}
-		}
-	}()
+	go c.client.AppObjects().Serve(context.Background(), stream, passwordProviderHandlers(app))
 
 	return result, nil
 }
 
-func (c *crypto) appRequestCreateCryptoBox(payload []byte, app domain.AppPasswordProvider) {
-	var appRequest domain.ParamsOfAppRequest
-	var appParams domain.ParamsOfAppPasswordProvider
-	err := json.Unmarshal(payload, &appRequest)
-	if err != nil {
-		panic(err)
-	}
-	err = json.Unmarshal(appRequest.RequestData, &appParams)
-	if err != nil {
-		panic(err)
-	}
-	var appResponse interface{}
-	switch value := (appParams.ValueEnumType).(type) {
-	case domain.ParamsOfAppPasswordProviderGetPassword:
-		appResponse, err = app.GetPassword(value)
-	default:
-		err = fmt.Errorf("unsupported type for request %v", appParams.ValueEnumType)
-	}
-
-	appReqResult := &domain.AppRequestResult{}
-	if err != nil {
-		appReqResult.ValueEnumType = domain.AppRequestResultError{Text: err.Error()}
-	} else {
-		marsh, err := json.Marshal(&domain.ResultOfAppPasswordProvider{ValueEnumType: appResponse})
-		if err != nil {
-			panic(err)
-		}
-		appReqResult.ValueEnumType = domain.AppRequestResultOk{Result: marsh}
+// passwordProviderHandlers - handler table of password provider of crypto box.
+func passwordProviderHandlers(app domain.AppPasswordProvider) *domain.AppObjectHandlers {
+	appContext, hasContext := app.(domain.AppPasswordProviderContext)
+	return &domain.AppObjectHandlers{
+		Name: "AppPasswordProvider",
+		Decode: func(data json.RawMessage) (interface{}, error) {
+			params := &domain.ParamsOfAppPasswordProvider{}
+			err := json.Unmarshal(data, params)
+			return params.ValueEnumType, err
+		},
+		Encode: func(value interface{}) (json.RawMessage, error) {
+			return json.Marshal(&domain.ResultOfAppPasswordProvider{ValueEnumType: value})
+		},
+		Requests: map[string]domain.AppHandler{
+			"GetPassword": func(ctx context.Context, params interface{}) (interface{}, error) {
+				if hasContext {
+					return appContext.GetPasswordContext(ctx, params.(domain.ParamsOfAppPasswordProviderGetPassword))
+				}
+				return app.GetPassword(params.(domain.ParamsOfAppPasswordProviderGetPassword))
+			},
+		},
 	}
-	err = c.client.ResolveAppRequest(&domain.ParamsOfResolveAppRequest{
-		AppRequestID: appRequest.AppRequestID,
-		Result:       appReqResult,
-	})
-	if err != nil || errors.Is(err, errors.New("channels is closed")) {
-		return
-	}
-	panic(err)
 }
 
 // RemoveCryptoBox - Removes Crypto Box. Clears all secret data.
 func (c *crypto) RemoveCryptoBox(box *domain.RegisteredCryptoBox) error {
-	_, err := c.client.GetResponse("crypto.remove_crypto_box", box)
+	return c.RemoveCryptoBoxContext(context.Background(), box)
+}
+
+// RemoveCryptoBoxContext - RemoveCryptoBox with ctx to cancel waiting for the result.
+func (c *crypto) RemoveCryptoBoxContext(ctx context.Context, box *domain.RegisteredCryptoBox) error {
+	_, err := c.client.GetResponseContext(ctx, "crypto.remove_crypto_box", box)
 	return err
 }
 
 // GetCryptoBoxInfo - Get Crypto Box Info. Used to get encrypted_secret that should be used for all the cryptobox
 // initializations except the first one.
 func (c *crypto) GetCryptoBoxInfo(box *domain.RegisteredCryptoBox) (*domain.ResultOfGetCryptoBoxInfo, error) {
+	return c.GetCryptoBoxInfoContext(context.Background(), box)
+}
+
+// GetCryptoBoxInfoContext - GetCryptoBoxInfo with ctx to cancel waiting for the result.
+func (c *crypto) GetCryptoBoxInfoContext(ctx context.Context, box *domain.RegisteredCryptoBox) (*domain.ResultOfGetCryptoBoxInfo, error) {
 	result := new(domain.ResultOfGetCryptoBoxInfo)
-	err := c.client.GetResult("crypto.get_crypto_box_info", box, result)
+	err := c.client.GetResultContext(ctx, "crypto.get_crypto_box_info", box, result)
 	return result, err
 }
 
 // GetCryptoBoxSeedPhrase - Get Crypto Box Seed Phrase.
 // Attention! Store this data in your application for a very short period of time and overwrite it with zeroes ASAP.
 func (c *crypto) GetCryptoBoxSeedPhrase(box *domain.RegisteredCryptoBox) (*domain.ResultOfGetCryptoBoxSeedPhrase, error) {
+	return c.GetCryptoBoxSeedPhraseContext(context.Background(), box)
+}
+
+// GetCryptoBoxSeedPhraseContext - GetCryptoBoxSeedPhrase with ctx to cancel waiting for the result.
+func (c *crypto) GetCryptoBoxSeedPhraseContext(ctx context.Context, box *domain.RegisteredCryptoBox) (*domain.ResultOfGetCryptoBoxSeedPhrase, error) {
 	result := new(domain.ResultOfGetCryptoBoxSeedPhrase)
-	err := c.client.GetResult("crypto.get_crypto_box_seed_phrase", box, result)
+	err := c.client.GetResultContext(ctx, "crypto.get_crypto_box_seed_phrase", box, result)
 	return result, err
 }
 
 // GetSigningBoxFromCryptoBox - Get handle of Signing Box derived from Crypto Box.
 func (c *crypto) GetSigningBoxFromCryptoBox(box *domain.ParamsOfGetSigningBoxFromCryptoBox) (*domain.RegisteredSigningBox, error) {
+	return c.GetSigningBoxFromCryptoBoxContext(context.Background(), box)
+}
+
+// GetSigningBoxFromCryptoBoxContext - GetSigningBoxFromCryptoBox with ctx to cancel waiting for the result.
+func (c *crypto) GetSigningBoxFromCryptoBoxContext(ctx context.Context, box *domain.ParamsOfGetSigningBoxFromCryptoBox) (*domain.RegisteredSigningBox, error) {
 	result := new(domain.RegisteredSigningBox)
-	err := c.client.GetResult("crypto.get_signing_box_from_crypto_box", box, result)
+	err := c.client.GetResultContext(ctx, "crypto.get_signing_box_from_crypto_box", box, result)
 	return result, err
 }
 
@@ -364,213 +522,228 @@ func (c *crypto) GetSigningBoxFromCryptoBox(box *domain.ParamsOfGetSigningBoxFro
 // explicitly cleared by clear_crypto_box_secret_cache method. If secret_lifetime is not specified - overwrites
 // encryption secret with zeroes immediately after encryption operation.
 func (c *crypto) GetEncryptionBoxFromCryptoBox(box *domain.ParamsOfGetEncryptionBoxFromCryptoBox) (*domain.RegisteredEncryptionBox, error) {
+	return c.GetEncryptionBoxFromCryptoBoxContext(context.Background(), box)
+}
+
+// GetEncryptionBoxFromCryptoBoxContext - GetEncryptionBoxFromCryptoBox with ctx to cancel waiting for the result.
+func (c *crypto) GetEncryptionBoxFromCryptoBoxContext(ctx context.Context, box *domain.ParamsOfGetEncryptionBoxFromCryptoBox) (*domain.RegisteredEncryptionBox, error) {
 	result := new(domain.RegisteredEncryptionBox)
-	err := c.client.GetResult("crypto.get_encryption_box_from_crypto_box", box, result)
+	err := c.client.GetResultContext(ctx, "crypto.get_encryption_box_from_crypto_box", box, result)
 	return result, err
 }
 
 // ClearCryptoBoxSecretCache - Removes cached secrets (overwrites with zeroes) from all signing and encryption boxes,
 // derived from crypto box.
 func (c *crypto) ClearCryptoBoxSecretCache(box *domain.RegisteredCryptoBox) error {
-	_, err := c.client.GetResponse("crypto.clear_crypto_box_secret_cache", box)
+	return c.ClearCryptoBoxSecretCacheContext(context.Background(), box)
+}
+
+// ClearCryptoBoxSecretCacheContext - ClearCryptoBoxSecretCache with ctx to cancel waiting for the result.
+func (c *crypto) ClearCryptoBoxSecretCacheContext(ctx context.Context, box *domain.RegisteredCryptoBox) error {
+	_, err := c.client.GetResponseContext(ctx, "crypto.clear_crypto_box_secret_cache", box)
 	return err
 }
 
 // RegisterSigningBox - Register an application implemented signing box.
 func (c *crypto) RegisterSigningBox(app domain.AppSigningBox) (*domain.RegisteredSigningBox, error) {
 	result := new(domain.RegisteredSigningBox)
-	responses, err := c.client.Request("crypto.register_signing_box", nil)
+	stream, err := c.client.Request("crypto.register_signing_box", nil)
 	if err != nil {
 		return nil, err
 	}
-	response := <-responses
-	if response.Code == 1 {
-		return nil, response.Error
-	}
-
-	if err := json.Unmarshal(response.Data, result); err != nil {
+	if err := stream.Result(result); err != nil {
 		return nil, err
 	}
 
-	go func() {
-		for r := range responses {
-			if r.Code == 3 {
-				c.appRequestCryptoRegisterSigningBox(r.Data, app)
-			}
-		}
-	}()
+	go c.client.AppObjects().Serve(context.Background(), stream, signingBoxHandlers(app))
 
 	return result, nil
 }
 
-func (c *crypto) appRequestCryptoRegisterSigningBox(payload []byte, app domain.AppSigningBox) {
-	var appRequest domain.ParamsOfAppRequest
-	var appParams domain.ParamsOfAppSigningBox
-	err := json.Unmarshal(payload, &appRequest)
-	if err != nil {
-		panic(err)
+// signingBoxHandlers - handler table of signing box implemented by application.
+func signingBoxHandlers(app domain.AppSigningBox) *domain.AppObjectHandlers {
+	appContext, hasContext := app.(domain.AppSigningBoxContext)
+	return &domain.AppObjectHandlers{
+		Name: "AppSigningBox",
+		Decode: func(data json.RawMessage) (interface{}, error) {
+			params := &domain.ParamsOfAppSigningBox{}
+			err := json.Unmarshal(data, params)
+			return params.ValueEnumType, err
+		},
+		Encode: func(value interface{}) (json.RawMessage, error) {
+			return json.Marshal(&domain.ResultOfAppSigningBox{ValueEnumType: value})
+		},
+		Requests: map[string]domain.AppHandler{
+			"GetPublicKey": func(ctx context.Context, _ interface{}) (interface{}, error) {
+				if hasContext {
+					return appContext.GetPublicKeyContext(ctx)
+				}
+				return app.GetPublicKey()
+			},
+			"Sign": func(ctx context.Context, params interface{}) (interface{}, error) {
+				if hasContext {
+					return appContext.SignContext(ctx, params.(domain.ParamsOfAppSigningBoxSign))
+				}
+				return app.Sign(params.(domain.ParamsOfAppSigningBoxSign))
+			},
+		},
 	}
-	err = json.Unmarshal(appRequest.RequestData, &appParams)
-	if err != nil {
-		panic(err)
-	}
-	var appResponse interface{}
-	switch value := (appParams.ValueEnumType).(type) {
-	case domain.ParamsOfAppSigningBoxGetPublicKey:
-		appResponse, err = app.GetPublicKey()
-	case domain.ParamsOfAppSigningBoxSign:
-		appResponse, err = app.Sign(value)
-	default:
-		err = fmt.Errorf("unsupported type for request %v", appParams.ValueEnumType)
-	}
-
-	appReqResult := &domain.AppRequestResult{}
-	if err != nil {
-		appReqResult.ValueEnumType = domain.AppRequestResultError{Text: err.Error()}
-	} else {
-		marsh, err := json.Marshal(&domain.ResultOfAppSigningBox{ValueEnumType: appResponse})
-		if err != nil {
-			panic(err)
-		}
-		appReqResult.ValueEnumType = domain.AppRequestResultOk{Result: marsh}
-	}
-	err = c.client.ResolveAppRequest(&domain.ParamsOfResolveAppRequest{
-		AppRequestID: appRequest.AppRequestID,
-		Result:       appReqResult,
-	})
-	if err != nil || errors.Is(err, errors.New("channels is closed")) {
-		return
-	}
-	panic(err)
 }
 
 // GetSigningBox - Creates a default signing box implementation.
 func (c *crypto) GetSigningBox(keypair *domain.KeyPair) (*domain.RegisteredSigningBox, error) {
+	return c.GetSigningBoxContext(context.Background(), keypair)
+}
+
+// GetSigningBoxContext - GetSigningBox with ctx to cancel waiting for the result.
+func (c *crypto) GetSigningBoxContext(ctx context.Context, keypair *domain.KeyPair) (*domain.RegisteredSigningBox, error) {
 	result := new(domain.RegisteredSigningBox)
-	err := c.client.GetResult("crypto.get_signing_box", keypair, result)
+	err := c.client.GetResultContext(ctx, "crypto.get_signing_box", keypair, result)
 	return result, err
 }
 
 // SigningBoxGetPublicKey - Returns public key of signing key pair.
 func (c *crypto) SigningBoxGetPublicKey(keypair *domain.RegisteredSigningBox) (*domain.ResultOfSigningBoxGetPublicKey, error) {
+	return c.SigningBoxGetPublicKeyContext(context.Background(), keypair)
+}
+
+// SigningBoxGetPublicKeyContext - SigningBoxGetPublicKey with ctx to cancel waiting for the result.
+func (c *crypto) SigningBoxGetPublicKeyContext(ctx context.Context, keypair *domain.RegisteredSigningBox) (*domain.ResultOfSigningBoxGetPublicKey, error) {
 	result := new(domain.ResultOfSigningBoxGetPublicKey)
-	err := c.client.GetResult("crypto.signing_box_get_public_key", keypair, result)
+	err := c.client.GetResultContext(ctx, "crypto.signing_box_get_public_key", keypair, result)
 	return result, err
 }
 
 // SigningBoxSign - Returns signed user data.
 func (c *crypto) SigningBoxSign(pOSBS *domain.ParamsOfSigningBoxSign) (*domain.ResultOfSigningBoxSign, error) {
+	return c.SigningBoxSignContext(context.Background(), pOSBS)
+}
+
+// SigningBoxSignContext - SigningBoxSign with ctx to cancel waiting for the result.
+func (c *crypto) SigningBoxSignContext(ctx context.Context, pOSBS *domain.ParamsOfSigningBoxSign) (*domain.ResultOfSigningBoxSign, error) {
 	result := new(domain.ResultOfSigningBoxSign)
-	err := c.client.GetResult("crypto.signing_box_sign", pOSBS, result)
+	err := c.client.GetResultContext(ctx, "crypto.signing_box_sign", pOSBS, result)
 	return result, err
 }
 
 // RemoveSigningBox - Removes signing box from SDK.
 func (c *crypto) RemoveSigningBox(rSB *domain.RegisteredSigningBox) error {
-	_, err := c.client.GetResponse("crypto.remove_signing_box", rSB)
+	return c.RemoveSigningBoxContext(context.Background(), rSB)
+}
+
+// RemoveSigningBoxContext - RemoveSigningBox with ctx to cancel waiting for the result.
+func (c *crypto) RemoveSigningBoxContext(ctx context.Context, rSB *domain.RegisteredSigningBox) error {
+	_, err := c.client.GetResponseContext(ctx, "crypto.remove_signing_box", rSB)
 	return err
 }
 
 // RegisterEncryptionBox - Register an application implemented encryption box.
 func (c *crypto) RegisterEncryptionBox(app domain.AppEncryptionBox) (*domain.RegisteredEncryptionBox, error) {
 	result := new(domain.RegisteredEncryptionBox)
-	responses, err := c.client.Request("crypto.register_encryption_box", nil)
+	stream, err := c.client.Request("crypto.register_encryption_box", nil)
 	if err != nil {
 		return nil, err
 	}
-	response := <-responses
-	if response.Code == 1 {
-		return nil, response.Error
-	}
-
-	if err := json.Unmarshal(response.Data, result); err != nil {
+	if err := stream.Result(result); err != nil {
 		return nil, err
 	}
 
-	go func() {
-		for r := range responses {
-			if r.Code == 3 {
-				c.appRequestCryptoRegisterEncryptionBox(r.Data, app)
-			}
-		}
-	}()
+	go c.client.AppObjects().Serve(context.Background(), stream, encryptionBoxHandlers(app))
 
 	return result, nil
 }
 
-func (c *crypto) appRequestCryptoRegisterEncryptionBox(payload []byte, app domain.AppEncryptionBox) {
-	var appRequest domain.ParamsOfAppRequest
-	var appParams domain.ParamsOfAppEncryptionBox
-	err := json.Unmarshal(payload, &appRequest)
-	if err != nil {
-		panic(err)
-	}
-	err = json.Unmarshal(appRequest.RequestData, &appParams)
-	if err != nil {
-		panic(err)
+// encryptionBoxHandlers - handler table of encryption box implemented by application.
+func encryptionBoxHandlers(app domain.AppEncryptionBox) *domain.AppObjectHandlers {
+	appContext, hasContext := app.(domain.AppEncryptionBoxContext)
+	return &domain.AppObjectHandlers{
+		Name: "AppEncryptionBox",
+		Decode: func(data json.RawMessage) (interface{}, error) {
+			params := &domain.ParamsOfAppEncryptionBox{}
+			err := json.Unmarshal(data, params)
+			return params.ValueEnumType, err
+		},
+		Encode: func(value interface{}) (json.RawMessage, error) {
+			return json.Marshal(&domain.ResultOfAppEncryptionBox{ValueEnumType: value})
+		},
+		Requests: map[string]domain.AppHandler{
+			"GetInfo": func(ctx context.Context, _ interface{}) (interface{}, error) {
+				if hasContext {
+					return appContext.GetInfoContext(ctx)
+				}
+				return app.GetInfo()
+			},
+			"Encrypt": func(ctx context.Context, params interface{}) (interface{}, error) {
+				if hasContext {
+					return appContext.EncryptContext(ctx, params.(domain.ParamsOfAppEncryptionBoxEncrypt))
+				}
+				return app.Encrypt(params.(domain.ParamsOfAppEncryptionBoxEncrypt))
+			},
+			"Decrypt": func(ctx context.Context, params interface{}) (interface{}, error) {
+				if hasContext {
+					return appContext.DecryptContext(ctx, params.(domain.ParamsOfAppEncryptionBoxDecrypt))
+				}
+				return app.Decrypt(params.(domain.ParamsOfAppEncryptionBoxDecrypt))
+			},
+		},
 	}
-	var appResponse interface{}
-	switch value := (appParams.ValueEnumType).(type) {
-	case domain.ParamsOfAppEncryptionBoxGetInfo:
-		appResponse, err = app.GetInfo()
-	case domain.ParamsOfAppEncryptionBoxEncrypt:
-		appResponse, err = app.Encrypt(value)
-	case domain.ParamsOfAppEncryptionBoxDecrypt:
-		appResponse, err = app.Decrypt(value)
-	default:
-		err = fmt.Errorf("unsupported type for request %v", appParams.ValueEnumType)
-	}
-
-	appReqResult := &domain.AppRequestResult{}
-	if err != nil {
-		appReqResult.ValueEnumType = domain.AppRequestResultError{Text: err.Error()}
-	} else {
-		marsh, err := json.Marshal(&domain.ResultOfAppEncryptionBox{ValueEnumType: appResponse})
-		if err != nil {
-			panic(err)
-		}
-		appReqResult.ValueEnumType = domain.AppRequestResultOk{Result: marsh}
-	}
-	err = c.client.ResolveAppRequest(&domain.ParamsOfResolveAppRequest{
-		AppRequestID: appRequest.AppRequestID,
-		Result:       appReqResult,
-	})
-	if err == nil || errors.Is(err, errors.New("channels is closed")) {
-		return
-	}
-	panic(err)
 }
 
 // RemoveEncryptionBox - Removes encryption box from SDK.
 func (c *crypto) RemoveEncryptionBox(rEB *domain.RegisteredEncryptionBox) error {
-	_, err := c.client.GetResponse("crypto.remove_encryption_box", rEB)
+	return c.RemoveEncryptionBoxContext(context.Background(), rEB)
+}
+
+// RemoveEncryptionBoxContext - RemoveEncryptionBox with ctx to cancel waiting for the result.
+func (c *crypto) RemoveEncryptionBoxContext(ctx context.Context, rEB *domain.RegisteredEncryptionBox) error {
+	_, err := c.client.GetResponseContext(ctx, "crypto.remove_encryption_box", rEB)
 	return err
 }
 
 // EncryptionBoxGetInfo - Queries info from the given encryption box.
 func (c *crypto) EncryptionBoxGetInfo(pOEBGI *domain.ParamsOfEncryptionBoxGetInfo) (*domain.ResultOfEncryptionBoxGetInfo, error) {
+	return c.EncryptionBoxGetInfoContext(context.Background(), pOEBGI)
+}
+
+// EncryptionBoxGetInfoContext - EncryptionBoxGetInfo with ctx to cancel waiting for the result.
+func (c *crypto) EncryptionBoxGetInfoContext(ctx context.Context, pOEBGI *domain.ParamsOfEncryptionBoxGetInfo) (*domain.ResultOfEncryptionBoxGetInfo, error) {
 	result := new(domain.ResultOfEncryptionBoxGetInfo)
-	err := c.client.GetResult("crypto.encryption_box_get_info", pOEBGI, result)
+	err := c.client.GetResultContext(ctx, "crypto.encryption_box_get_info", pOEBGI, result)
 	return result, err
 }
 
 // EncryptionBoxEncrypt - Encrypts data using given encryption box.
 func (c *crypto) EncryptionBoxEncrypt(pOAEBE *domain.ParamsOfEncryptionBoxEncrypt) (*domain.ResultOfEncryptionBoxEncrypt, error) {
+	return c.EncryptionBoxEncryptContext(context.Background(), pOAEBE)
+}
+
+// EncryptionBoxEncryptContext - EncryptionBoxEncrypt with ctx to cancel waiting for the result.
+func (c *crypto) EncryptionBoxEncryptContext(ctx context.Context, pOAEBE *domain.ParamsOfEncryptionBoxEncrypt) (*domain.ResultOfEncryptionBoxEncrypt, error) {
 	result := new(domain.ResultOfEncryptionBoxEncrypt)
-	err := c.client.GetResult("crypto.encryption_box_get_info", pOAEBE, result)
+	err := c.client.GetResultContext(ctx, "crypto.encryption_box_get_info", pOAEBE, result)
 	return result, err
 }
 
 // EncryptionBoxDecrypt - Decrypts data using given encryption box.
 func (c *crypto) EncryptionBoxDecrypt(pOAEBD *domain.ParamsOfEncryptionBoxDecrypt) (*domain.ResultOfEncryptionBoxDecrypt, error) {
+	return c.EncryptionBoxDecryptContext(context.Background(), pOAEBD)
+}
+
+// EncryptionBoxDecryptContext - EncryptionBoxDecrypt with ctx to cancel waiting for the result.
+func (c *crypto) EncryptionBoxDecryptContext(ctx context.Context, pOAEBD *domain.ParamsOfEncryptionBoxDecrypt) (*domain.ResultOfEncryptionBoxDecrypt, error) {
 	result := new(domain.ResultOfEncryptionBoxDecrypt)
-	err := c.client.GetResult("crypto.encryption_box_decrypt", pOAEBD, result)
+	err := c.client.GetResultContext(ctx, "crypto.encryption_box_decrypt", pOAEBD, result)
 	return result, err
 }
 
 // CreateEncryptionBox -Creates encryption box with specified algorithm.
 func (c *crypto) CreateEncryptionBox(pOCEB *domain.ParamsOfCreateEncryptionBox) (*domain.RegisteredEncryptionBox, error) {
+	return c.CreateEncryptionBoxContext(context.Background(), pOCEB)
+}
+
+// CreateEncryptionBoxContext - CreateEncryptionBox with ctx to cancel waiting for the result.
+func (c *crypto) CreateEncryptionBoxContext(ctx context.Context, pOCEB *domain.ParamsOfCreateEncryptionBox) (*domain.RegisteredEncryptionBox, error) {
 	result := new(domain.RegisteredEncryptionBox)
-	err := c.client.GetResult("crypto.create_encryption_box", pOCEB, result)
+	err := c.client.GetResultContext(ctx, "crypto.create_encryption_box", pOCEB, result)
 	return result, err
 }
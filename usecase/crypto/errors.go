@@ -0,0 +1,39 @@
+package crypto
+
+import "github.com/markgenuine/ever-client-go/domain"
+
+// Errors of crypto module returned by SDK, use errors.Is to check them.
+var (
+	ErrInvalidPublicKey                    = domain.NewClientError(domain.CryptoErrorCode, "InvalidPublicKey")
+	ErrInvalidSecretKey                    = domain.NewClientError(domain.CryptoErrorCode, "InvalidSecretKey")
+	ErrInvalidKey                          = domain.NewClientError(domain.CryptoErrorCode, "InvalidKey")
+	ErrInvalidFactorizeChallenge           = domain.NewClientError(domain.CryptoErrorCode, "InvalidFactorizeChallenge")
+	ErrInvalidBigInt                       = domain.NewClientError(domain.CryptoErrorCode, "InvalidBigInt")
+	ErrScryptFailed                        = domain.NewClientError(domain.CryptoErrorCode, "ScryptFailed")
+	ErrInvalidKeySize                      = domain.NewClientError(domain.CryptoErrorCode, "InvalidKeySize")
+	ErrNaclSecretBoxFailed                 = domain.NewClientError(domain.CryptoErrorCode, "NaclSecretBoxFailed")
+	ErrNaclBoxFailed                       = domain.NewClientError(domain.CryptoErrorCode, "NaclBoxFailed")
+	ErrNaclSignFailed                      = domain.NewClientError(domain.CryptoErrorCode, "NaclSignFailed")
+	ErrBip39InvalidEntropy                 = domain.NewClientError(domain.CryptoErrorCode, "Bip39InvalidEntropy")
+	ErrBip39InvalidPhrase                  = domain.NewClientError(domain.CryptoErrorCode, "Bip39InvalidPhrase")
+	ErrBip32InvalidKey                     = domain.NewClientError(domain.CryptoErrorCode, "Bip32InvalidKey")
+	ErrBip32InvalidDerivePath              = domain.NewClientError(domain.CryptoErrorCode, "Bip32InvalidDerivePath")
+	ErrBip39InvalidDictionary              = domain.NewClientError(domain.CryptoErrorCode, "Bip39InvalidDictionary")
+	ErrBip39InvalidWordCount               = domain.NewClientError(domain.CryptoErrorCode, "Bip39InvalidWordCount")
+	ErrMnemonicGenerationFailed            = domain.NewClientError(domain.CryptoErrorCode, "MnemonicGenerationFailed")
+	ErrMnemonicFromEntropyFailed           = domain.NewClientError(domain.CryptoErrorCode, "MnemonicFromEntropyFailed")
+	ErrSigningBoxNotRegistered             = domain.NewClientError(domain.CryptoErrorCode, "SigningBoxNotRegistered")
+	ErrInvalidSignature                    = domain.NewClientError(domain.CryptoErrorCode, "InvalidSignature")
+	ErrEncryptionBoxNotRegistered          = domain.NewClientError(domain.CryptoErrorCode, "EncryptionBoxNotRegistered")
+	ErrInvalidIvSize                       = domain.NewClientError(domain.CryptoErrorCode, "InvalidIvSize")
+	ErrUnsupportedCipherMode               = domain.NewClientError(domain.CryptoErrorCode, "UnsupportedCipherMode")
+	ErrCannotCreateCipher                  = domain.NewClientError(domain.CryptoErrorCode, "CannotCreateCipher")
+	ErrEncryptDataError                    = domain.NewClientError(domain.CryptoErrorCode, "EncryptDataError")
+	ErrDecryptDataError                    = domain.NewClientError(domain.CryptoErrorCode, "DecryptDataError")
+	ErrIvRequired                          = domain.NewClientError(domain.CryptoErrorCode, "IvRequired")
+	ErrCryptoBoxNotRegistered              = domain.NewClientError(domain.CryptoErrorCode, "CryptoBoxNotRegistered")
+	ErrInvalidCryptoBoxType                = domain.NewClientError(domain.CryptoErrorCode, "InvalidCryptoBoxType")
+	ErrCryptoBoxSecretSerializationError   = domain.NewClientError(domain.CryptoErrorCode, "CryptoBoxSecretSerializationError")
+	ErrCryptoBoxSecretDeserializationError = domain.NewClientError(domain.CryptoErrorCode, "CryptoBoxSecretDeserializationError")
+	ErrInvalidNonceSize                    = domain.NewClientError(domain.CryptoErrorCode, "InvalidNonceSize")
+)
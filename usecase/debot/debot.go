@@ -1,9 +1,8 @@
 package debot
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
 	"github.com/markgenuine/ever-client-go/domain"
 )
 
@@ -23,95 +22,75 @@ func NewDebot(config domain.ClientConfig, client domain.ClientGateway) domain.De
 // Downloads debot smart contract (code and data) from blockchain and creates an instance of Debot Engine for it.
 func (d *debot) Init(pOI *domain.ParamsOfInit, app domain.AppDebotBrowser) (*domain.RegisteredDebot, error) {
 	result := new(domain.RegisteredDebot)
-	responses, err := d.client.Request("debot.init", pOI)
+	stream, err := d.client.Request("debot.init", pOI)
 	if err != nil {
 		return nil, err
 	}
-
-	response := <-responses
-	if response.Code == 1 {
-		return nil, response.Error
-	}
-
-	if err := json.Unmarshal(response.Data, result); err != nil {
+	if err := stream.Result(result); err != nil {
 		return nil, err
 	}
 
-	go func() {
-		for r := range responses {
-			if r.Code == 3 {
-				d.appRequestDebotInit(r.Data, app)
-			}
-			if r.Code == 4 {
-				d.appNotifyDebotInit(r.Data, app)
-			}
-		}
-	}()
+	go d.client.AppObjects().Serve(context.Background(), stream, browserHandlers(app))
 
 	return result, nil
 }
 
-func (d *debot) appRequestDebotInit(payload []byte, app domain.AppDebotBrowser) {
-	var appRequest domain.ParamsOfAppRequest
-	var appParams domain.ParamsOfAppDebotBrowser
-	err := json.Unmarshal(payload, &appRequest)
-	if err != nil {
-		panic(err)
-	}
-	err = json.Unmarshal(appRequest.RequestData, &appParams)
-	if err != nil {
-		panic(err)
-	}
-	var appResponse interface{}
-
-	switch value := (appParams.ValueEnumType).(type) {
-	case domain.ParamsOfAppDebotBrowserInput:
-		appResponse, err = app.Input(value)
-	case domain.ParamsOfAppDebotBrowserGetSigningBox:
-		appResponse, err = app.GetSigningBox(value)
-	case domain.ParamsOfAppDebotBrowserInvokeDebot:
-		appResponse, err = app.InvokeDebot(value)
-	case domain.ParamsOfAppDebotBrowserApprove:
-		appResponse, err = app.Approve(value)
-	default:
-		err = fmt.Errorf("unsupported type for request %v", appParams.ValueEnumType)
-	}
-
-	appRequestResult := &domain.AppRequestResult{}
-	if err != nil {
-		appRequestResult.ValueEnumType = domain.AppRequestResultError{Text: err.Error()}
-	} else {
-		marshal, _ := json.Marshal(&domain.ResultOfAppDebotBrowser{ValueEnumType: appResponse})
-		appRequestResult.ValueEnumType = domain.AppRequestResultOk{Result: marshal}
-	}
-	paramsResolved := &domain.ParamsOfResolveAppRequest{AppRequestID: appRequest.AppRequestID, Result: appRequestResult}
-	err = d.client.ResolveAppRequest(paramsResolved)
-	if err != nil || errors.Is(err, errors.New("channels is closed")) {
-		return
-	}
-	panic(err)
-}
-
-func (d *debot) appNotifyDebotInit(payload []byte, app domain.AppDebotBrowser) {
-	var appParams domain.ParamsOfAppDebotBrowser
-	err := json.Unmarshal(payload, &appParams)
-	if err != nil {
-		panic(err)
-	}
-
-	switch value := (appParams.ValueEnumType).(type) {
-	case domain.ParamsOfAppDebotBrowserLog:
-		_ = app.Log(value)
-	case domain.ParamsOfAppDebotBrowserSwitch:
-		_ = app.Switch(value)
-	case domain.ParamsOfAppDebotBrowserSwitchCompleted:
-		_ = app.SwitchCompleted(value)
-	case domain.ParamsOfAppDebotBrowserShowAction:
-		_ = app.ShowAction(value)
-	case domain.ParamsOfAppDebotBrowserSend:
-		_ = app.Send(value)
-	default:
-		panic(fmt.Errorf("unsupported type for request %v", appParams.ValueEnumType))
+// browserHandlers - handler table of debot browser, requests and notifications are handled in order of the stream.
+func browserHandlers(app domain.AppDebotBrowser) *domain.AppObjectHandlers {
+	appContext, hasContext := app.(domain.AppDebotBrowserContext)
+	return &domain.AppObjectHandlers{
+		Name: "AppDebotBrowser",
+		Decode: func(data json.RawMessage) (interface{}, error) {
+			params := &domain.ParamsOfAppDebotBrowser{}
+			err := json.Unmarshal(data, params)
+			return params.ValueEnumType, err
+		},
+		Encode: func(value interface{}) (json.RawMessage, error) {
+			return json.Marshal(&domain.ResultOfAppDebotBrowser{ValueEnumType: value})
+		},
+		Requests: map[string]domain.AppHandler{
+			"Input": func(ctx context.Context, params interface{}) (interface{}, error) {
+				if hasContext {
+					return appContext.InputContext(ctx, params.(domain.ParamsOfAppDebotBrowserInput))
+				}
+				return app.Input(params.(domain.ParamsOfAppDebotBrowserInput))
+			},
+			"GetSigningBox": func(ctx context.Context, params interface{}) (interface{}, error) {
+				if hasContext {
+					return appContext.GetSigningBoxContext(ctx, params.(domain.ParamsOfAppDebotBrowserGetSigningBox))
+				}
+				return app.GetSigningBox(params.(domain.ParamsOfAppDebotBrowserGetSigningBox))
+			},
+			"InvokeDebot": func(ctx context.Context, params interface{}) (interface{}, error) {
+				if hasContext {
+					return appContext.InvokeDebotContext(ctx, params.(domain.ParamsOfAppDebotBrowserInvokeDebot))
+				}
+				return app.InvokeDebot(params.(domain.ParamsOfAppDebotBrowserInvokeDebot))
+			},
+			"Approve": func(ctx context.Context, params interface{}) (interface{}, error) {
+				if hasContext {
+					return appContext.ApproveContext(ctx, params.(domain.ParamsOfAppDebotBrowserApprove))
+				}
+				return app.Approve(params.(domain.ParamsOfAppDebotBrowserApprove))
+			},
+		},
+		Notifications: map[string]domain.AppHandler{
+			"Log": func(_ context.Context, params interface{}) (interface{}, error) {
+				return nil, app.Log(params.(domain.ParamsOfAppDebotBrowserLog))
+			},
+			"Switch": func(_ context.Context, params interface{}) (interface{}, error) {
+				return nil, app.Switch(params.(domain.ParamsOfAppDebotBrowserSwitch))
+			},
+			"SwitchCompleted": func(_ context.Context, params interface{}) (interface{}, error) {
+				return nil, app.SwitchCompleted(params.(domain.ParamsOfAppDebotBrowserSwitchCompleted))
+			},
+			"ShowAction": func(_ context.Context, params interface{}) (interface{}, error) {
+				return nil, app.ShowAction(params.(domain.ParamsOfAppDebotBrowserShowAction))
+			},
+			"Send": func(_ context.Context, params interface{}) (interface{}, error) {
+				return nil, app.Send(params.(domain.ParamsOfAppDebotBrowserSend))
+			},
+		},
 	}
 }
 
@@ -122,35 +101,60 @@ func (d *debot) appNotifyDebotInit(payload []byte, app domain.AppDebotBrowser) {
 // When the debot starts SDK registers BrowserCallbacks AppObject. Therefore when debote.remove is called the debot is
 // being deleted and the callback is called with finish=true which indicates that it will never be used again.
 func (d *debot) Start(poS *domain.ParamsOfStart) error {
-	_, err := d.client.GetResponse("debot.start", poS)
+	return d.StartContext(context.Background(), poS)
+}
+
+// StartContext - Start with ctx to cancel waiting for the result.
+func (d *debot) StartContext(ctx context.Context, poS *domain.ParamsOfStart) error {
+	_, err := d.client.GetResponseContext(ctx, "debot.start", poS)
 	return err
 }
 
 // Fetch - Fetches DeBot metadata from blockchain.
 // Downloads DeBot from blockchain and creates and fetches its metadata.
 func (d *debot) Fetch(pOF *domain.ParamsOfFetch) (*domain.ResultOfFetch, error) {
+	return d.FetchContext(context.Background(), pOF)
+}
+
+// FetchContext - Fetch with ctx to cancel waiting for the result.
+func (d *debot) FetchContext(ctx context.Context, pOF *domain.ParamsOfFetch) (*domain.ResultOfFetch, error) {
 	result := new(domain.ResultOfFetch)
-	err := d.client.GetResult("debot.fetch", pOF, result)
+	err := d.client.GetResultContext(ctx, "debot.fetch", pOF, result)
 	return result, err
 }
 
 // Execute - Executes debot action.
 // Calls debot engine referenced by debot handle to execute input action. Calls Debot Browser Callbacks if needed.
 func (d *debot) Execute(pOE *domain.ParamsOfExecute) error {
-	_, err := d.client.GetResponse("debot.execute", pOE)
+	return d.ExecuteContext(context.Background(), pOE)
+}
+
+// ExecuteContext - Execute with ctx to cancel waiting for the result.
+func (d *debot) ExecuteContext(ctx context.Context, pOE *domain.ParamsOfExecute) error {
+	_, err := d.client.GetResponseContext(ctx, "debot.execute", pOE)
 	return err
 }
 
 // Send - Sends message to Debot.
 // Used by Debot Browser to send response on Dinterface call or from other Debots.
 func (d *debot) Send(pOS *domain.ParamsOfSend) error {
-	_, err := d.client.GetResponse("debot.send", pOS)
+	return d.SendContext(context.Background(), pOS)
+}
+
+// SendContext - Send with ctx to cancel waiting for the result.
+func (d *debot) SendContext(ctx context.Context, pOS *domain.ParamsOfSend) error {
+	_, err := d.client.GetResponseContext(ctx, "debot.send", pOS)
 	return err
 }
 
 // Remove - Destroys debot handle.
 // Removes handle from Client Context and drops debot engine referenced by that handle.
 func (d *debot) Remove(pOR *domain.ParamsOfRemove) error {
-	_, err := d.client.GetResponse("debot.remove", pOR)
+	return d.RemoveContext(context.Background(), pOR)
+}
+
+// RemoveContext - Remove with ctx to cancel waiting for the result.
+func (d *debot) RemoveContext(ctx context.Context, pOR *domain.ParamsOfRemove) error {
+	_, err := d.client.GetResponseContext(ctx, "debot.remove", pOR)
 	return err
 }
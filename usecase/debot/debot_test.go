@@ -1,19 +1,113 @@
 package debot
 
 import (
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/markgenuine/ever-client-go/domain"
+	"github.com/markgenuine/ever-client-go/gateway/cassette"
+	"github.com/markgenuine/ever-client-go/gateway/client"
+	"github.com/markgenuine/ever-client-go/gateway/clientmock"
+	"github.com/stretchr/testify/assert"
 )
 
+type browser struct {
+	sync.Mutex
+	logs    []string
+	prompts []string
+}
+
+func (b *browser) Log(params domain.ParamsOfAppDebotBrowserLog) error {
+	b.Lock()
+	defer b.Unlock()
+	b.logs = append(b.logs, params.Msg)
+	return nil
+}
+
+func (b *browser) Switch(domain.ParamsOfAppDebotBrowserSwitch) error { return nil }
+
+func (b *browser) SwitchCompleted(domain.ParamsOfAppDebotBrowserSwitchCompleted) error { return nil }
+
+func (b *browser) ShowAction(domain.ParamsOfAppDebotBrowserShowAction) error { return nil }
+
+func (b *browser) Input(params domain.ParamsOfAppDebotBrowserInput) (domain.ResultOfAppDebotBrowserInput, error) {
+	b.Lock()
+	defer b.Unlock()
+	b.prompts = append(b.prompts, params.Prompt)
+	return domain.ResultOfAppDebotBrowserInput{Value: "Bob"}, nil
+}
+
+func (b *browser) GetSigningBox(domain.ParamsOfAppDebotBrowserGetSigningBox) (domain.ResultOfAppDebotBrowserGetSigningBox, error) {
+	return domain.ResultOfAppDebotBrowserGetSigningBox{}, nil
+}
+
+func (b *browser) InvokeDebot(domain.ParamsOfAppDebotBrowserInvokeDebot) (domain.ResultOfAppDebotBrowserInvokeDebot, error) {
+	return domain.ResultOfAppDebotBrowserInvokeDebot{}, nil
+}
+
+func (b *browser) Send(domain.ParamsOfAppDebotBrowserSend) error { return nil }
+
+func (b *browser) Approve(domain.ParamsOfAppDebotBrowserApprove) (domain.ResultOfAppDebotBrowserApprove, error) {
+	return domain.ResultOfAppDebotBrowserApprove{}, nil
+}
+
 func TestDebot(t *testing.T) {
 
-	//config := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), "")
-	//clientConn, err := client.NewClientGateway(config)
-	//assert.Equal(t, nil, err)
-	//defer clientConn.Destroy()
-	//
-	//debotUC := debot{
-	//	config: config,
-	//	client: clientConn,
-	//}
-	//defer debotUC.client.Destroy()
+}
+
+func TestDebotReplay(t *testing.T) {
+	recorded, err := cassette.Load("../samples/cassettes/debot.json")
+	assert.Equal(t, nil, err)
+	player := cassette.NewPlayer(recorded)
+
+	config := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), "")
+	clientConn, err := client.NewClientGateway(config, client.WithTransport(player))
+	assert.Equal(t, nil, err)
+	defer clientConn.Destroy()
+
+	debotUC := NewDebot(config, clientConn)
+	app := &browser{}
+
+	t.Run("TestInitAppObject", func(t *testing.T) {
+		result, err := debotUC.Init(&domain.ParamsOfInit{Address: "0:2222222222222222222222222222222222222222222222222222222222222222"}, app)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 1, result.DebotHandle)
+		assert.Equal(t, "Hello", result.Info.Name)
+
+		// Input is resolved by client.resolve_app_request which is the last interaction of cassette.
+		assert.Eventually(t, func() bool { return len(player.Unplayed()) == 0 }, time.Second, 5*time.Millisecond)
+		app.Lock()
+		defer app.Unlock()
+		assert.Equal(t, []string{"Hello, World!"}, app.logs)
+		assert.Equal(t, []string{"Your name?"}, app.prompts)
+	})
+}
+
+func TestDebotFake(t *testing.T) {
+	config := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), "")
+	clientConn := clientmock.NewClientGateway()
+	defer clientConn.Destroy()
+	debotUC := NewDebot(config, clientConn)
+	app := &browser{}
+
+	t.Run("TestInitAppObject", func(t *testing.T) {
+		clientConn.On("debot.init",
+			clientmock.Result(&domain.RegisteredDebot{DebotHandle: 3, Info: &domain.DebotInfo{Name: "Fake"}}),
+			clientmock.AppNotify(&domain.ParamsOfAppDebotBrowser{ValueEnumType: domain.ParamsOfAppDebotBrowserLog{Msg: "log"}}),
+			clientmock.AppRequest(5, &domain.ParamsOfAppDebotBrowser{ValueEnumType: domain.ParamsOfAppDebotBrowserInput{Prompt: "?"}}),
+		).KeepOpen()
+		clientConn.On("client.resolve_app_request", clientmock.Result(`{}`))
+
+		result, err := debotUC.Init(&domain.ParamsOfInit{Address: "0:1"}, app)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 3, result.DebotHandle)
+
+		assert.Eventually(t, func() bool { return len(clientConn.Requests("client.resolve_app_request")) == 1 }, time.Second, 5*time.Millisecond)
+		assert.JSONEq(t, `{"app_request_id":5,"result":{"type":"Ok","result":{"type":"Input","value":"Bob"}}}`,
+			string(clientConn.Requests("client.resolve_app_request")[0]))
+		app.Lock()
+		defer app.Unlock()
+		assert.Equal(t, []string{"log"}, app.logs)
+	})
 }
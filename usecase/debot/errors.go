@@ -0,0 +1,20 @@
+package debot
+
+import "github.com/markgenuine/ever-client-go/domain"
+
+// Errors of debot module returned by SDK, use errors.Is to check them.
+var (
+	ErrStartFailed           = domain.NewClientError(domain.DebotErrorCode, "DebotStartFailed")
+	ErrFetchFailed           = domain.NewClientError(domain.DebotErrorCode, "DebotFetchFailed")
+	ErrExecutionFailed       = domain.NewClientError(domain.DebotErrorCode, "DebotExecutionFailed")
+	ErrInvalidHandle         = domain.NewClientError(domain.DebotErrorCode, "DebotInvalidHandle")
+	ErrInvalidJsonParams     = domain.NewClientError(domain.DebotErrorCode, "DebotInvalidJsonParams")
+	ErrInvalidFunctionId     = domain.NewClientError(domain.DebotErrorCode, "DebotInvalidFunctionId")
+	ErrInvalidAbi            = domain.NewClientError(domain.DebotErrorCode, "DebotInvalidAbi")
+	ErrGetMethodFailed       = domain.NewClientError(domain.DebotErrorCode, "DebotGetMethodFailed")
+	ErrInvalidMsg            = domain.NewClientError(domain.DebotErrorCode, "DebotInvalidMsg")
+	ErrExternalCallFailed    = domain.NewClientError(domain.DebotErrorCode, "DebotExternalCallFailed")
+	ErrBrowserCallbackFailed = domain.NewClientError(domain.DebotErrorCode, "DebotBrowserCallbackFailed")
+	ErrOperationRejected     = domain.NewClientError(domain.DebotErrorCode, "DebotOperationRejected")
+	ErrNoCode                = domain.NewClientError(domain.DebotErrorCode, "DebotNoCode")
+)
@@ -0,0 +1,25 @@
+package net
+
+import "github.com/markgenuine/ever-client-go/domain"
+
+// Errors of net module returned by SDK, use errors.Is to check them.
+var (
+	ErrQueryFailed                    = domain.NewClientError(domain.NetErrorCode, "QueryFailed")
+	ErrSubscribeFailed                = domain.NewClientError(domain.NetErrorCode, "SubscribeFailed")
+	ErrWaitForFailed                  = domain.NewClientError(domain.NetErrorCode, "WaitForFailed")
+	ErrGetSubscriptionResultFailed    = domain.NewClientError(domain.NetErrorCode, "GetSubscriptionResultFailed")
+	ErrInvalidServerResponse          = domain.NewClientError(domain.NetErrorCode, "InvalidServerResponse")
+	ErrClockOutOfSync                 = domain.NewClientError(domain.NetErrorCode, "ClockOutOfSync")
+	ErrWaitForTimeout                 = domain.NewClientError(domain.NetErrorCode, "WaitForTimeout")
+	ErrGraphqlError                   = domain.NewClientError(domain.NetErrorCode, "GraphqlError")
+	ErrNetworkModuleSuspended         = domain.NewClientError(domain.NetErrorCode, "NetworkModuleSuspended")
+	ErrWebsocketDisconnected          = domain.NewClientError(domain.NetErrorCode, "WebsocketDisconnected")
+	ErrNotSupported                   = domain.NewClientError(domain.NetErrorCode, "NotSupported")
+	ErrNoEndpointsProvided            = domain.NewClientError(domain.NetErrorCode, "NoEndpointsProvided")
+	ErrGraphqlWebsocketInitError      = domain.NewClientError(domain.NetErrorCode, "GraphqlWebsocketInitError")
+	ErrNetworkModuleResumed           = domain.NewClientError(domain.NetErrorCode, "NetworkModuleResumed")
+	ErrUnauthorized                   = domain.NewClientError(domain.NetErrorCode, "Unauthorized")
+	ErrQueryTransactionTreeTimeout    = domain.NewClientError(domain.NetErrorCode, "QueryTransactionTreeTimeout")
+	ErrGraphqlConnectionError         = domain.NewClientError(domain.NetErrorCode, "GraphqlConnectionError")
+	ErrWrongWebsocketProtocolSequence = domain.NewClientError(domain.NetErrorCode, "WrongWebsocketProtocolSequence")
+)
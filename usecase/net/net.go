@@ -1,7 +1,9 @@
 package net
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/markgenuine/ever-client-go/domain"
 )
 
@@ -22,42 +24,72 @@ func NewNet(
 
 // Query - Performs DAppServer GraphQL query.
 func (n *net) Query(pOQ *domain.ParamsOfQuery) (*domain.ResultOfQuery, error) {
+	return n.QueryContext(context.Background(), pOQ)
+}
+
+// QueryContext - Query with ctx to cancel waiting for the result.
+func (n *net) QueryContext(ctx context.Context, pOQ *domain.ParamsOfQuery) (*domain.ResultOfQuery, error) {
 	result := new(domain.ResultOfQuery)
-	err := n.client.GetResult("net.query", pOQ, result)
+	err := n.client.GetResultContext(ctx, "net.query", pOQ, result)
 	return result, err
 }
 
 // BatchQuery - Performs multiple queries per single fetch.
 func (n *net) BatchQuery(pOBQ *domain.ParamsOfBatchQuery) (*domain.ResultOfBatchQuery, error) {
+	return n.BatchQueryContext(context.Background(), pOBQ)
+}
+
+// BatchQueryContext - BatchQuery with ctx to cancel waiting for the result.
+func (n *net) BatchQueryContext(ctx context.Context, pOBQ *domain.ParamsOfBatchQuery) (*domain.ResultOfBatchQuery, error) {
 	result := new(domain.ResultOfBatchQuery)
-	err := n.client.GetResult("net.batch_query", pOBQ, result)
+	err := n.client.GetResultContext(ctx, "net.batch_query", pOBQ, result)
 	return result, err
 }
 
 // QueryCollection - Queries collection data.
 func (n *net) QueryCollection(pOQC *domain.ParamsOfQueryCollection) (*domain.ResultOfQueryCollection, error) {
+	return n.QueryCollectionContext(context.Background(), pOQC)
+}
+
+// QueryCollectionContext - QueryCollection with ctx to cancel waiting for the result.
+func (n *net) QueryCollectionContext(ctx context.Context, pOQC *domain.ParamsOfQueryCollection) (*domain.ResultOfQueryCollection, error) {
 	result := new(domain.ResultOfQueryCollection)
-	err := n.client.GetResult("net.query_collection", pOQC, result)
+	err := n.client.GetResultContext(ctx, "net.query_collection", pOQC, result)
 	return result, err
 }
 
 // AggregateCollection - Aggregates collection data.
 func (n *net) AggregateCollection(pOAC *domain.ParamsOfAggregateCollection) (*domain.ResultOfAggregateCollection, error) {
+	return n.AggregateCollectionContext(context.Background(), pOAC)
+}
+
+// AggregateCollectionContext - AggregateCollection with ctx to cancel waiting for the result.
+func (n *net) AggregateCollectionContext(ctx context.Context, pOAC *domain.ParamsOfAggregateCollection) (*domain.ResultOfAggregateCollection, error) {
 	result := new(domain.ResultOfAggregateCollection)
-	err := n.client.GetResult("net.aggregate_collection", pOAC, result)
+	err := n.client.GetResultContext(ctx, "net.aggregate_collection", pOAC, result)
 	return result, err
 }
 
 // WaitForCollection - Returns an object that fulfills the conditions or waits for its appearance.
 func (n *net) WaitForCollection(pOWFC *domain.ParamsOfWaitForCollection) (*domain.ResultOfWaitForCollection, error) {
+	return n.WaitForCollectionContext(context.Background(), pOWFC)
+}
+
+// WaitForCollectionContext - WaitForCollection with ctx to cancel waiting for the result.
+func (n *net) WaitForCollectionContext(ctx context.Context, pOWFC *domain.ParamsOfWaitForCollection) (*domain.ResultOfWaitForCollection, error) {
 	result := new(domain.ResultOfWaitForCollection)
-	err := n.client.GetResult("net.wait_for_collection", pOWFC, result)
+	err := n.client.GetResultContext(ctx, "net.wait_for_collection", pOWFC, result)
 	return result, err
 }
 
 // Unsubscribe - Cancels a subscription.
 func (n *net) Unsubscribe(rOSC *domain.ResultOfSubscribeCollection) error {
-	_, err := n.client.GetResponse("net.unsubscribe", rOSC)
+	return n.UnsubscribeContext(context.Background(), rOSC)
+}
+
+// UnsubscribeContext - Unsubscribe with ctx to cancel waiting for the result.
+func (n *net) UnsubscribeContext(ctx context.Context, rOSC *domain.ResultOfSubscribeCollection) error {
+	_, err := n.client.GetResponseContext(ctx, "net.unsubscribe", rOSC)
 	return err
 }
 
@@ -67,110 +99,148 @@ func (n *net) Unsubscribe(rOSC *domain.ResultOfSubscribeCollection) error {
 // result fields.
 // The subscription is a persistent communication channel between client and Free TON Network. All changes in the blockchain
 // will be reflected in realtime. Changes means inserts and updates of the blockchain entities.
-func (n *net) SubscribeCollection(pOSC *domain.ParamsOfSubscribeCollection) (<-chan json.RawMessage, *domain.ResultOfSubscribeCollection, error) {
-	result := new(domain.ResultOfSubscribeCollection)
-	responses, err := n.client.Request("net.subscribe_collection", pOSC)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	data := <-responses
-	if data.Error != nil {
-		return nil, nil, data.Error
-	}
-	if err := json.Unmarshal(data.Data, result); err != nil {
-		return nil, nil, err
-	}
-
-	respInBuffer := domain.DynBufferForResponses(responses)
-	chanResult := make(chan json.RawMessage, 1)
-	go func() {
-		var body struct {
-			Result json.RawMessage `json:"result"`
-		}
-		for r := range respInBuffer {
-			if err := json.Unmarshal(r.Data, &body); err != nil {
-				panic(err)
-			}
-			chanResult <- body.Result
-		}
-		close(chanResult)
-	}()
+// Events which can't be parsed are skipped and reported on the error channel, it is closed with the data channel.
+// The error channel is buffered, errors which aren't read in time are dropped.
+func (n *net) SubscribeCollection(pOSC *domain.ParamsOfSubscribeCollection) (<-chan json.RawMessage, <-chan error, *domain.ResultOfSubscribeCollection, error) {
+	return n.SubscribeCollectionContext(context.Background(), pOSC)
+}
 
-	return chanResult, result, nil
+// SubscribeCollectionContext - SubscribeCollection with ctx to stop waiting for the handle and the events. When ctx
+// is done the channels are closed with ctx.Err() on the error channel, the subscription is cancelled by Unsubscribe.
+func (n *net) SubscribeCollectionContext(ctx context.Context, pOSC *domain.ParamsOfSubscribeCollection) (<-chan json.RawMessage, <-chan error, *domain.ResultOfSubscribeCollection, error) {
+	return n.subscribe(ctx, "net.subscribe_collection", pOSC)
 }
 
 // Subscribe - Creates a subscription.
 // The subscription is a persistent communication channel between client and Everscale Network.
-func (n *net) Subscribe(pOS *domain.ParamsOfSubscribe) (<-chan json.RawMessage, *domain.ResultOfSubscribeCollection, error) {
-	result := new(domain.ResultOfSubscribeCollection)
-	responses, err := n.client.Request("net.subscribe", pOS)
+// Events which can't be parsed are skipped and reported on the error channel, see SubscribeCollection.
+func (n *net) Subscribe(pOS *domain.ParamsOfSubscribe) (<-chan json.RawMessage, <-chan error, *domain.ResultOfSubscribeCollection, error) {
+	return n.SubscribeContext(context.Background(), pOS)
+}
+
+// SubscribeContext - Subscribe with ctx to stop waiting for the handle and the events, see SubscribeCollectionContext.
+func (n *net) SubscribeContext(ctx context.Context, pOS *domain.ParamsOfSubscribe) (<-chan json.RawMessage, <-chan error, *domain.ResultOfSubscribeCollection, error) {
+	return n.subscribe(ctx, "net.subscribe", pOS)
+}
+
+func (n *net) subscribe(ctx context.Context, method string, params interface{}) (<-chan json.RawMessage, <-chan error, *domain.ResultOfSubscribeCollection, error) {
+	stream, err := n.client.RequestContext(ctx, method, params)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
-	data := <-responses
-	if data.Error != nil {
-		return nil, nil, data.Error
-	}
-	if err := json.Unmarshal(data.Data, result); err != nil {
-		return nil, nil, err
+	result := new(domain.ResultOfSubscribeCollection)
+	if err := stream.Result(result); err != nil {
+		return nil, nil, nil, err
 	}
+	data, errs := subscriptionData(ctx, stream)
 
-	respInBuffer := domain.DynBufferForResponses(responses)
-	chanResult := make(chan json.RawMessage, 1)
-	go func() {
-		var body struct {
-			Result json.RawMessage `json:"result"`
+	return data, errs, result, nil
+}
+
+// subscriptionData returns data of subscription events and errors of events which can't be parsed, both channels
+// are closed when the subscription ends or ctx is done.
+func subscriptionData(ctx context.Context, stream *domain.Stream) (<-chan json.RawMessage, <-chan error) {
+	data := make(chan json.RawMessage, 1)
+	errs := make(chan error, 1)
+	report := func(err error) {
+		select {
+		case errs <- err:
+		default:
 		}
-		for r := range respInBuffer {
-			if err := json.Unmarshal(r.Data, &body); err != nil {
-				panic(err)
+	}
+	go func() {
+		defer close(errs)
+		defer close(data)
+		for event := range stream.Events() {
+			var body struct {
+				Result json.RawMessage `json:"result"`
+			}
+			if err := json.Unmarshal(event, &body); err != nil {
+				report(fmt.Errorf("subscription event %s: %w", event, err))
+				continue
 			}
-			chanResult <- body.Result
+			select {
+			case data <- body.Result:
+			case <-ctx.Done():
+				report(ctx.Err())
+				return
+			}
+		}
+		if err := stream.Err(); err != nil {
+			report(err)
 		}
-		close(chanResult)
 	}()
 
-	return chanResult, result, nil
+	return data, errs
 }
 
 // Suspend - Suspends network module to stop any network activity.
 func (n *net) Suspend() error {
-	_, err := n.client.GetResponse("net.suspend", nil)
+	return n.SuspendContext(context.Background())
+}
+
+// SuspendContext - Suspend with ctx to cancel waiting for the result.
+func (n *net) SuspendContext(ctx context.Context) error {
+	_, err := n.client.GetResponseContext(ctx, "net.suspend", nil)
 	return err
 }
 
 // Resume - Resumes network module to enable network activity.
 func (n *net) Resume() error {
-	_, err := n.client.GetResponse("net.resume", nil)
+	return n.ResumeContext(context.Background())
+}
+
+// ResumeContext - Resume with ctx to cancel waiting for the result.
+func (n *net) ResumeContext(ctx context.Context) error {
+	_, err := n.client.GetResponseContext(ctx, "net.resume", nil)
 	return err
 }
 
 // FindLastShardBlock - Returns ID of the last block in a specified account shard.
 func (n *net) FindLastShardBlock(pOFLSB *domain.ParamsOfFindLastShardBlock) (*domain.ResultOfFindLastShardBlock, error) {
+	return n.FindLastShardBlockContext(context.Background(), pOFLSB)
+}
+
+// FindLastShardBlockContext - FindLastShardBlock with ctx to cancel waiting for the result.
+func (n *net) FindLastShardBlockContext(ctx context.Context, pOFLSB *domain.ParamsOfFindLastShardBlock) (*domain.ResultOfFindLastShardBlock, error) {
 	result := new(domain.ResultOfFindLastShardBlock)
-	err := n.client.GetResult("net.find_last_shard_block", pOFLSB, result)
+	err := n.client.GetResultContext(ctx, "net.find_last_shard_block", pOFLSB, result)
 	return result, err
 }
 
 // FetchEndpoints - Requests the list of alternative endpoints from server.
 func (n *net) FetchEndpoints() (*domain.EndpointsSet, error) {
+	return n.FetchEndpointsContext(context.Background())
+}
+
+// FetchEndpointsContext - FetchEndpoints with ctx to cancel waiting for the result.
+func (n *net) FetchEndpointsContext(ctx context.Context) (*domain.EndpointsSet, error) {
 	result := new(domain.EndpointsSet)
-	err := n.client.GetResult("net.fetch_endpoints", nil, result)
+	err := n.client.GetResultContext(ctx, "net.fetch_endpoints", nil, result)
 	return result, err
 }
 
 // SetEndpoints - Sets the list of endpoints to use on reinit.
 func (n *net) SetEndpoints(eS *domain.EndpointsSet) error {
-	_, err := n.client.GetResponse("net.set_endpoints", eS)
+	return n.SetEndpointsContext(context.Background(), eS)
+}
+
+// SetEndpointsContext - SetEndpoints with ctx to cancel waiting for the result.
+func (n *net) SetEndpointsContext(ctx context.Context, eS *domain.EndpointsSet) error {
+	_, err := n.client.GetResponseContext(ctx, "net.set_endpoints", eS)
 	return err
 }
 
 // GetEndpoints - Requests the list of alternative endpoints from server.
 func (n *net) GetEndpoints() (*domain.ResultOfGetEndpoints, error) {
+	return n.GetEndpointsContext(context.Background())
+}
+
+// GetEndpointsContext - GetEndpoints with ctx to cancel waiting for the result.
+func (n *net) GetEndpointsContext(ctx context.Context) (*domain.ResultOfGetEndpoints, error) {
 	result := new(domain.ResultOfGetEndpoints)
-	err := n.client.GetResult("net.get_endpoints", nil, result)
+	err := n.client.GetResultContext(ctx, "net.get_endpoints", nil, result)
 	return result, err
 }
 
@@ -180,8 +250,13 @@ func (n *net) GetEndpoints() (*domain.ResultOfGetEndpoints, error) {
 // in the opensource version of DApp Server (and will not be supported) as well as in EVER OS SE
 // (will be supported in SE in future), but is always accessible via EVER OS Devnet/Mainnet Clouds
 func (n *net) QueryCounterparties(pOQC *domain.ParamsOfQueryCounterparties) (*domain.ResultOfQueryCollection, error) {
+	return n.QueryCounterpartiesContext(context.Background(), pOQC)
+}
+
+// QueryCounterpartiesContext - QueryCounterparties with ctx to cancel waiting for the result.
+func (n *net) QueryCounterpartiesContext(ctx context.Context, pOQC *domain.ParamsOfQueryCounterparties) (*domain.ResultOfQueryCollection, error) {
 	result := new(domain.ResultOfQueryCollection)
-	err := n.client.GetResult("net.query_counterparties", pOQC, result)
+	err := n.client.GetResultContext(ctx, "net.query_counterparties", pOQC, result)
 	return result, err
 }
 
@@ -195,8 +270,13 @@ func (n *net) QueryCounterparties(pOQC *domain.ParamsOfQueryCounterparties) (*do
 // application have to continue retrieval
 // for missing messages if it requires.
 func (n *net) QueryTransactionTree(pOQTT *domain.ParamsOfQueryTransactionTree) (*domain.ResultOfQueryTransactionTree, error) {
+	return n.QueryTransactionTreeContext(context.Background(), pOQTT)
+}
+
+// QueryTransactionTreeContext - QueryTransactionTree with ctx to cancel waiting for the result.
+func (n *net) QueryTransactionTreeContext(ctx context.Context, pOQTT *domain.ParamsOfQueryTransactionTree) (*domain.ResultOfQueryTransactionTree, error) {
 	result := new(domain.ResultOfQueryTransactionTree)
-	err := n.client.GetResult("net.query_transaction_tree", pOQTT, result)
+	err := n.client.GetResultContext(ctx, "net.query_transaction_tree", pOQTT, result)
 	return result, err
 }
 
@@ -204,8 +284,13 @@ func (n *net) QueryTransactionTree(pOQTT *domain.ParamsOfQueryTransactionTree) (
 // Block iterator uses robust iteration methods that guaranties that every block in the specified range isn't missed or
 // iterated twice.
 func (n *net) CreateBlockIterator(iterator *domain.ParamsOfCreateBlockIterator) (*domain.RegisteredIterator, error) {
+	return n.CreateBlockIteratorContext(context.Background(), iterator)
+}
+
+// CreateBlockIteratorContext - CreateBlockIterator with ctx to cancel waiting for the result.
+func (n *net) CreateBlockIteratorContext(ctx context.Context, iterator *domain.ParamsOfCreateBlockIterator) (*domain.RegisteredIterator, error) {
 	result := new(domain.RegisteredIterator)
-	err := n.client.GetResult("net.create_block_iterator", iterator, result)
+	err := n.client.GetResultContext(ctx, "net.create_block_iterator", iterator, result)
 	return result, err
 }
 
@@ -213,8 +298,13 @@ func (n *net) CreateBlockIterator(iterator *domain.ParamsOfCreateBlockIterator)
 // The iterator stays exactly at the same position where the resume_state was catched.
 // Application should call the remove_iterator when iterator is no longer required.
 func (n *net) ResumeBlockIterator(iterator *domain.ParamsOfResumeBlockIterator) (*domain.RegisteredIterator, error) {
+	return n.ResumeBlockIteratorContext(context.Background(), iterator)
+}
+
+// ResumeBlockIteratorContext - ResumeBlockIterator with ctx to cancel waiting for the result.
+func (n *net) ResumeBlockIteratorContext(ctx context.Context, iterator *domain.ParamsOfResumeBlockIterator) (*domain.RegisteredIterator, error) {
 	result := new(domain.RegisteredIterator)
-	err := n.client.GetResult("net.resume_block_iterator", iterator, result)
+	err := n.client.GetResultContext(ctx, "net.resume_block_iterator", iterator, result)
 	return result, err
 }
 
@@ -222,8 +312,13 @@ func (n *net) ResumeBlockIterator(iterator *domain.ParamsOfResumeBlockIterator)
 // Transaction iterator uses robust iteration methods that guaranty that every transaction in the specified range isn't
 // missed or iterated twice.
 func (n *net) CreateTransactionIterator(iterator *domain.ParamsOfCreateTransactionIterator) (*domain.RegisteredIterator, error) {
+	return n.CreateTransactionIteratorContext(context.Background(), iterator)
+}
+
+// CreateTransactionIteratorContext - CreateTransactionIterator with ctx to cancel waiting for the result.
+func (n *net) CreateTransactionIteratorContext(ctx context.Context, iterator *domain.ParamsOfCreateTransactionIterator) (*domain.RegisteredIterator, error) {
 	result := new(domain.RegisteredIterator)
-	err := n.client.GetResult("net.create_transaction_iterator", iterator, result)
+	err := n.client.GetResultContext(ctx, "net.create_transaction_iterator", iterator, result)
 	return result, err
 }
 
@@ -233,8 +328,13 @@ func (n *net) CreateTransactionIterator(iterator *domain.ParamsOfCreateTransacti
 // then the application must pass the account filter again in accounts_filter parameter.
 // Application should call the remove_iterator when iterator is no longer required.
 func (n *net) ResumeTransactionIterator(iterator *domain.ParamsOfResumeTransactionIterator) (*domain.RegisteredIterator, error) {
+	return n.ResumeTransactionIteratorContext(context.Background(), iterator)
+}
+
+// ResumeTransactionIteratorContext - ResumeTransactionIterator with ctx to cancel waiting for the result.
+func (n *net) ResumeTransactionIteratorContext(ctx context.Context, iterator *domain.ParamsOfResumeTransactionIterator) (*domain.RegisteredIterator, error) {
 	result := new(domain.RegisteredIterator)
-	err := n.client.GetResult("net.resume_transaction_iterator", iterator, result)
+	err := n.client.GetResultContext(ctx, "net.resume_transaction_iterator", iterator, result)
 	return result, err
 }
 
@@ -248,8 +348,13 @@ func (n *net) ResumeTransactionIterator(iterator *domain.ParamsOfResumeTransacti
 // The structure of the items returned depends on the iterator used. See the description to the appropriated iterator
 // creation function.
 func (n *net) IteratorNext(iterator *domain.ParamsOfIteratorNext) (*domain.ResultOfIteratorNext, error) {
+	return n.IteratorNextContext(context.Background(), iterator)
+}
+
+// IteratorNextContext - IteratorNext with ctx to cancel waiting for the result.
+func (n *net) IteratorNextContext(ctx context.Context, iterator *domain.ParamsOfIteratorNext) (*domain.ResultOfIteratorNext, error) {
 	result := new(domain.ResultOfIteratorNext)
-	err := n.client.GetResult("net.iterator_next", iterator, result)
+	err := n.client.GetResultContext(ctx, "net.iterator_next", iterator, result)
 	return result, err
 }
 
@@ -257,14 +362,24 @@ func (n *net) IteratorNext(iterator *domain.ParamsOfIteratorNext) (*domain.Resul
 // Frees all resources allocated in library to serve iterator.
 // Application always should call the remove_iterator when iterator is no longer required.
 func (n *net) RemoveIterator(iterator *domain.RegisteredIterator) error {
-	_, err := n.client.GetResponse("net.remove_iterator", iterator)
+	return n.RemoveIteratorContext(context.Background(), iterator)
+}
+
+// RemoveIteratorContext - RemoveIterator with ctx to cancel waiting for the result.
+func (n *net) RemoveIteratorContext(ctx context.Context, iterator *domain.RegisteredIterator) error {
+	_, err := n.client.GetResponseContext(ctx, "net.remove_iterator", iterator)
 	return err
 }
 
 // GetSignatureID
 // Returns signature ID for configured network if it should be used in messages signature
 func (n *net) GetSignatureID() (*domain.ResultOfGetSignatureId, error) {
+	return n.GetSignatureIDContext(context.Background())
+}
+
+// GetSignatureIDContext - GetSignatureID with ctx to cancel waiting for the result.
+func (n *net) GetSignatureIDContext(ctx context.Context) (*domain.ResultOfGetSignatureId, error) {
 	result := new(domain.ResultOfGetSignatureId)
-	err := n.client.GetResult("net.get_signature_id", nil, result)
+	err := n.client.GetResultContext(ctx, "net.get_signature_id", nil, result)
 	return result, err
 }
@@ -1,7 +1,17 @@
 package net
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"testing"
+	"time"
+
+	"github.com/markgenuine/ever-client-go/domain"
+	"github.com/markgenuine/ever-client-go/gateway/cassette"
+	"github.com/markgenuine/ever-client-go/gateway/clientmock"
+	"github.com/markgenuine/ever-client-go/util"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestNet(t *testing.T) {
@@ -86,7 +96,7 @@ func TestNet(t *testing.T) {
 	//	}
 	//
 	//	// # Create generator
-	//	generator, handle, err := netUC.SubscribeCollection(queryParams)
+	//	generator, _, handle, err := netUC.SubscribeCollection(queryParams)
 	//	assert.NotNil(t, generator)
 	//	assert.Equal(t, nil, err)
 	//	assert.NotNil(t, handle)
@@ -161,3 +171,127 @@ func TestNet(t *testing.T) {
 	//	assert.Greater(t, resToInt, 0)
 	//})
 }
+
+func TestNetReplay(t *testing.T) {
+	config := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), "")
+	clientConn, err := cassette.NewGateway(config, "../samples/cassettes/net.json")
+	assert.Equal(t, nil, err)
+	defer clientConn.Destroy()
+
+	netUC := NewNet(config, clientConn)
+
+	t.Run("TestQueryCollection", func(t *testing.T) {
+		result, err := netUC.QueryCollection(&domain.ParamsOfQueryCollection{Collection: "accounts", Result: "id balance", Limit: util.IntToPointerInt(2)})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 2, len(result.Result))
+	})
+
+	t.Run("TestWaitForCollectionTimeout", func(t *testing.T) {
+		_, err := netUC.WaitForCollection(&domain.ParamsOfWaitForCollection{
+			Collection: "transactions",
+			Filter:     json.RawMessage(`{"now":{"gt":1650000000}}`),
+			Result:     "id now",
+			Timeout:    util.IntToPointerInt(1000),
+		})
+		assert.True(t, errors.Is(err, ErrWaitForTimeout))
+	})
+
+	t.Run("TestSubscribeCollection", func(t *testing.T) {
+		events, _, handle, err := netUC.SubscribeCollection(&domain.ParamsOfSubscribeCollection{Collection: "blocks", Result: "id seq_no"})
+		assert.Equal(t, nil, err)
+		assert.True(t, handle.Handle > 0)
+
+		type block struct {
+			ID    string `json:"id"`
+			SeqNo int    `json:"seq_no"`
+		}
+		previous := 0
+		for i := 0; i < 2; i++ {
+			var b block
+			assert.Equal(t, nil, json.Unmarshal(<-events, &b))
+			assert.True(t, b.SeqNo > previous)
+			previous = b.SeqNo
+		}
+		assert.Equal(t, nil, netUC.Unsubscribe(handle))
+	})
+}
+
+func TestNetFake(t *testing.T) {
+	config := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), "")
+	clientConn := clientmock.NewClientGateway()
+	netUC := NewNet(config, clientConn)
+
+	t.Run("TestSubscribeCollection", func(t *testing.T) {
+		clientConn.On("net.subscribe_collection",
+			clientmock.Result(&domain.ResultOfSubscribeCollection{Handle: 7}),
+			clientmock.Event(`{"result":{"id":"m1"}}`),
+			clientmock.Event(`{"result":{"id":"m2"}}`),
+		).KeepOpen()
+
+		events, _, handle, err := netUC.SubscribeCollection(&domain.ParamsOfSubscribeCollection{Collection: "messages", Result: "id"})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 7, handle.Handle)
+		assert.JSONEq(t, `{"id":"m1"}`, string(<-events))
+		assert.JSONEq(t, `{"id":"m2"}`, string(<-events))
+
+		clientConn.Destroy()
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+
+	t.Run("TestSubscribeCollectionContext", func(t *testing.T) {
+		clientConn := clientmock.NewClientGateway()
+		defer clientConn.Destroy()
+		netUC := NewNet(config, clientConn)
+		clientConn.On("net.subscribe_collection",
+			clientmock.Result(&domain.ResultOfSubscribeCollection{Handle: 10}),
+			clientmock.Event(`{"result":{"id":"m5"}}`),
+			clientmock.Event(`{"result":{"id":"m6"}}`),
+		).KeepOpen()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		events, errs, handle, err := netUC.SubscribeCollectionContext(ctx, &domain.ParamsOfSubscribeCollection{Collection: "messages", Result: "id"})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 10, handle.Handle)
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+
+		for range events {
+		}
+		assert.True(t, errors.Is(<-errs, context.Canceled))
+	})
+
+	t.Run("TestMalformedEvent", func(t *testing.T) {
+		clientConn := clientmock.NewClientGateway()
+		netUC := NewNet(config, clientConn)
+		clientConn.On("net.subscribe",
+			clientmock.Result(&domain.ResultOfSubscribeCollection{Handle: 8}),
+			clientmock.Event(`{"result":`),
+			clientmock.Event(`{"result":{"id":"m3"}}`),
+		)
+
+		events, errs, _, err := netUC.Subscribe(&domain.ParamsOfSubscribe{Subscription: "messages { id }"})
+		assert.Equal(t, nil, err)
+		assert.JSONEq(t, `{"id":"m3"}`, string(<-events))
+		_, ok := <-events
+		assert.False(t, ok)
+		assert.NotEqual(t, nil, <-errs)
+		_, ok = <-errs
+		assert.False(t, ok)
+	})
+
+	t.Run("TestEventWithoutResult", func(t *testing.T) {
+		clientConn := clientmock.NewClientGateway()
+		netUC := NewNet(config, clientConn)
+		clientConn.On("net.subscribe_collection",
+			clientmock.Result(&domain.ResultOfSubscribeCollection{Handle: 9}),
+			clientmock.Event(`{"result":{"id":"m4"}}`),
+			clientmock.Event(`{}`),
+		)
+
+		events, _, _, err := netUC.SubscribeCollection(&domain.ParamsOfSubscribeCollection{Collection: "messages", Result: "id"})
+		assert.Equal(t, nil, err)
+		assert.JSONEq(t, `{"id":"m4"}`, string(<-events))
+		assert.Nil(t, <-events, "result of the previous event is passed again")
+	})
+}
@@ -0,0 +1,23 @@
+package processing
+
+import "github.com/markgenuine/ever-client-go/domain"
+
+// Errors of processing module returned by SDK, use errors.Is to check them.
+var (
+	ErrMessageAlreadyExpired           = domain.NewClientError(domain.ProcessingErrorCode, "MessageAlreadyExpired")
+	ErrMessageHasNotDestinationAddress = domain.NewClientError(domain.ProcessingErrorCode, "MessageHasNotDestinationAddress")
+	ErrCanNotBuildMessageCell          = domain.NewClientError(domain.ProcessingErrorCode, "CanNotBuildMessageCell")
+	ErrFetchBlockFailed                = domain.NewClientError(domain.ProcessingErrorCode, "FetchBlockFailed")
+	ErrSendMessageFailed               = domain.NewClientError(domain.ProcessingErrorCode, "SendMessageFailed")
+	ErrInvalidMessageBoc               = domain.NewClientError(domain.ProcessingErrorCode, "InvalidMessageBoc")
+	ErrMessageExpired                  = domain.NewClientError(domain.ProcessingErrorCode, "MessageExpired")
+	ErrTransactionWaitTimeout          = domain.NewClientError(domain.ProcessingErrorCode, "TransactionWaitTimeout")
+	ErrInvalidBlockReceived            = domain.NewClientError(domain.ProcessingErrorCode, "InvalidBlockReceived")
+	ErrCanNotCheckBlockShard           = domain.NewClientError(domain.ProcessingErrorCode, "CanNotCheckBlockShard")
+	ErrBlockNotFound                   = domain.NewClientError(domain.ProcessingErrorCode, "BlockNotFound")
+	ErrInvalidData                     = domain.NewClientError(domain.ProcessingErrorCode, "InvalidData")
+	ErrExternalSignerMustNotBeUsed     = domain.NewClientError(domain.ProcessingErrorCode, "ExternalSignerMustNotBeUsed")
+	ErrMessageRejected                 = domain.NewClientError(domain.ProcessingErrorCode, "MessageRejected")
+	ErrInvalidRempStatus               = domain.NewClientError(domain.ProcessingErrorCode, "InvalidRempStatus")
+	ErrNextRempStatusTimeout           = domain.NewClientError(domain.ProcessingErrorCode, "NextRempStatusTimeout")
+)
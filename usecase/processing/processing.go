@@ -1,6 +1,7 @@
 package processing
 
 import (
+	"context"
 	"errors"
 
 	"github.com/markgenuine/ever-client-go/domain"
@@ -43,35 +44,60 @@ func NewProcessing(
 //
 // If monitoring queue with specified name does not exist then monitoring queue will be created with specified unresolved messages.
 func (p *processing) MonitorMessages(messages *domain.ParamsOfMonitorMessages) error {
-	_, err := p.client.GetResponse("processing.monitor_messages", messages)
+	return p.MonitorMessagesContext(context.Background(), messages)
+}
+
+// MonitorMessagesContext - MonitorMessages with ctx to cancel waiting for the result.
+func (p *processing) MonitorMessagesContext(ctx context.Context, messages *domain.ParamsOfMonitorMessages) error {
+	_, err := p.client.GetResponseContext(ctx, "processing.monitor_messages", messages)
 	return err
 }
 
 // GetMonitorInfo - Returns summary information about current state of the specified monitoring queue.
 func (p *processing) GetMonitorInfo(info *domain.ParamsOfGetMonitorInfo) (*domain.MonitoringQueueInfo, error) {
+	return p.GetMonitorInfoContext(context.Background(), info)
+}
+
+// GetMonitorInfoContext - GetMonitorInfo with ctx to cancel waiting for the result.
+func (p *processing) GetMonitorInfoContext(ctx context.Context, info *domain.ParamsOfGetMonitorInfo) (*domain.MonitoringQueueInfo, error) {
 	result := new(domain.MonitoringQueueInfo)
-	err := p.client.GetResult("processing.get_monitor_info", info, result)
+	err := p.client.GetResultContext(ctx, "processing.get_monitor_info", info, result)
 	return result, err
 }
 
 // FetchNextMonitorResults - Fetches next resolved results from the specified monitoring queue.
 // Results and waiting options are depends on the wait parameter. All returned results will be removed from the queue's resolved list.
 func (p *processing) FetchNextMonitorResults(results *domain.ParamsOfFetchNextMonitorResults) (*domain.ResultOfFetchNextMonitorResults, error) {
+	return p.FetchNextMonitorResultsContext(context.Background(), results)
+}
+
+// FetchNextMonitorResultsContext - FetchNextMonitorResults with ctx to cancel waiting for the result.
+func (p *processing) FetchNextMonitorResultsContext(ctx context.Context, results *domain.ParamsOfFetchNextMonitorResults) (*domain.ResultOfFetchNextMonitorResults, error) {
 	result := new(domain.ResultOfFetchNextMonitorResults)
-	err := p.client.GetResult("processing.fetch_next_monitor_results", results, result)
+	err := p.client.GetResultContext(ctx, "processing.fetch_next_monitor_results", results, result)
 	return result, err
 }
 
 // CancelMonitor - Cancels all background activity and releases all allocated system resources for the specified monitoring queue.
 func (p *processing) CancelMonitor(monitor *domain.ParamsOfCancelMonitor) error {
-	_, err := p.client.GetResponse("processing.cancel_monitor", monitor)
+	return p.CancelMonitorContext(context.Background(), monitor)
+}
+
+// CancelMonitorContext - CancelMonitor with ctx to cancel waiting for the result.
+func (p *processing) CancelMonitorContext(ctx context.Context, monitor *domain.ParamsOfCancelMonitor) error {
+	_, err := p.client.GetResponseContext(ctx, "processing.cancel_monitor", monitor)
 	return err
 }
 
 // SendMessages - Sends specified messages to the blockchain.
 func (p *processing) SendMessages(messages *domain.ParamsOfSendMessages) (*domain.ResultOfSendMessages, error) {
+	return p.SendMessagesContext(context.Background(), messages)
+}
+
+// SendMessagesContext - SendMessages with ctx to cancel waiting for the result.
+func (p *processing) SendMessagesContext(ctx context.Context, messages *domain.ParamsOfSendMessages) (*domain.ResultOfSendMessages, error) {
 	result := new(domain.ResultOfSendMessages)
-	err := p.client.GetResult("processing.send_messages", messages, result)
+	err := p.client.GetResultContext(ctx, "processing.send_messages", messages, result)
 	return result, err
 }
 
@@ -80,21 +106,26 @@ func (p *processing) SendMessages(messages *domain.ParamsOfSendMessages) (*domai
 // generated shard block of the destination account before
 // the message was sent. It will be required later for message processing.
 func (p *processing) SendMessage(pOSM *domain.ParamsOfSendMessage, callback domain.EventCallback) (*domain.ResultOfSendMessage, error) {
+	return p.SendMessageContext(context.Background(), pOSM, callback)
+}
+
+// SendMessageContext - SendMessage with ctx to stop waiting for events and the result.
+func (p *processing) SendMessageContext(ctx context.Context, pOSM *domain.ParamsOfSendMessage, callback domain.EventCallback) (*domain.ResultOfSendMessage, error) {
 	if pOSM.SendEvents && callback == nil {
 		return nil, errors.New("Don't find callback")
 	}
 
-	responses, err := p.client.Request("processing.send_message", pOSM)
+	stream, err := p.client.RequestContext(ctx, "processing.send_message", pOSM)
 	if err != nil {
 		return nil, err
 	}
 
 	if pOSM.SendEvents {
-		responses = domain.DynBufferForResponses(responses)
+		stream = stream.Buffer()
 	}
 
 	result := &domain.ResultOfSendMessage{}
-	return result, domain.HandleEvents(responses, callback, result)
+	return result, domain.HandleEventsContext(ctx, stream, callback, result)
 }
 
 // WaitForTransaction - Performs monitoring of the network for the result transaction of the external inbound message processing.
@@ -103,21 +134,26 @@ func (p *processing) SendMessage(pOSM *domain.ParamsOfSendMessage, callback doma
 // Note, that presence of the abi parameter is critical for ABI compliant contracts.
 // Message processing uses drastically different strategy for processing message for contracts which ABI includes "expire" header.
 func (p *processing) WaitForTransaction(pOWFT *domain.ParamsOfWaitForTransaction, callback domain.EventCallback) (*domain.ResultOfProcessMessage, error) {
+	return p.WaitForTransactionContext(context.Background(), pOWFT, callback)
+}
+
+// WaitForTransactionContext - WaitForTransaction with ctx to stop waiting for events and the result.
+func (p *processing) WaitForTransactionContext(ctx context.Context, pOWFT *domain.ParamsOfWaitForTransaction, callback domain.EventCallback) (*domain.ResultOfProcessMessage, error) {
 	if pOWFT.SendEvents && callback == nil {
 		return nil, errors.New("Don't find callback")
 	}
 
-	responses, err := p.client.Request("processing.wait_for_transaction", pOWFT)
+	stream, err := p.client.RequestContext(ctx, "processing.wait_for_transaction", pOWFT)
 	if err != nil {
 		return nil, err
 	}
 
 	if pOWFT.SendEvents {
-		responses = domain.DynBufferForResponses(responses)
+		stream = stream.Buffer()
 	}
 
 	result := &domain.ResultOfProcessMessage{}
-	return result, domain.HandleEvents(responses, callback, result)
+	return result, domain.HandleEventsContext(ctx, stream, callback, result)
 }
 
 // ProcessMessage - Creates message, sends it to the network and monitors its processing.
@@ -131,19 +167,24 @@ func (p *processing) WaitForTransaction(pOWFT *domain.ParamsOfWaitForTransaction
 // If contract's ABI does not include "expire" header then, if no transaction is found within
 // the network timeout (see config parameter ), exits with error.
 func (p *processing) ProcessMessage(pOPM *domain.ParamsOfProcessMessage, callback domain.EventCallback) (*domain.ResultOfProcessMessage, error) {
+	return p.ProcessMessageContext(context.Background(), pOPM, callback)
+}
+
+// ProcessMessageContext - ProcessMessage with ctx to stop waiting for events and the result.
+func (p *processing) ProcessMessageContext(ctx context.Context, pOPM *domain.ParamsOfProcessMessage, callback domain.EventCallback) (*domain.ResultOfProcessMessage, error) {
 	if pOPM.SendEvents && callback == nil {
 		return nil, errors.New("Don't find callback")
 	}
 
-	responses, err := p.client.Request("processing.process_message", pOPM)
+	stream, err := p.client.RequestContext(ctx, "processing.process_message", pOPM)
 	if err != nil {
 		return nil, err
 	}
 
 	if pOPM.SendEvents {
-		responses = domain.DynBufferForResponses(responses)
+		stream = stream.Buffer()
 	}
 
 	result := &domain.ResultOfProcessMessage{}
-	return result, domain.HandleEvents(responses, callback, result)
+	return result, domain.HandleEventsContext(ctx, stream, callback, result)
 }
@@ -1,7 +1,13 @@
 package processing
 
 import (
+	"errors"
 	"testing"
+
+	"github.com/markgenuine/ever-client-go/domain"
+	"github.com/markgenuine/ever-client-go/gateway/cassette"
+	"github.com/markgenuine/ever-client-go/gateway/clientmock"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestProcessing(t *testing.T) {
@@ -284,3 +290,86 @@ func TestProcessing(t *testing.T) {
 //		}
 //	}
 //}
+
+func TestProcessingReplay(t *testing.T) {
+	config := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), "")
+	clientConn, err := cassette.NewReplayGateway(config, "../samples/cassettes/processing.json")
+	assert.Equal(t, nil, err)
+	defer clientConn.Destroy()
+
+	processingUC := NewProcessing(config, clientConn)
+
+	t.Run("TestProcessMessageEvents", func(t *testing.T) {
+		handle := domain.AbiHandle(1)
+		var events []interface{}
+		result, err := processingUC.ProcessMessage(&domain.ParamsOfProcessMessage{
+			MessageEncodeParams: &domain.ParamsOfEncodeMessage{
+				Abi:     domain.NewAbiHandle(&handle),
+				Address: "0:1111111111111111111111111111111111111111111111111111111111111111",
+				CallSet: &domain.CallSet{FunctionName: "touch"},
+				Signer:  domain.NewSigner(domain.SignerNone{}),
+			},
+			SendEvents: true,
+		}, func(event *domain.ProcessingEvent) {
+			events = append(events, event.ValueEnumType)
+		})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 3, len(events))
+		assert.IsType(t, domain.ProcessingEventWillFetchFirstBlock{}, events[0])
+		assert.IsType(t, domain.ProcessingEventDidSend{}, events[2])
+		assert.Equal(t, int64(3), result.Fees.AccountFees.Int64())
+	})
+
+	t.Run("TestSendMessageExpired", func(t *testing.T) {
+		_, err := processingUC.SendMessage(&domain.ParamsOfSendMessage{Message: "te6c"}, nil)
+		assert.True(t, errors.Is(err, ErrMessageExpired))
+	})
+}
+
+func TestProcessingFake(t *testing.T) {
+	config := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), "")
+	clientConn := clientmock.NewClientGateway()
+	defer clientConn.Destroy()
+	processingUC := NewProcessing(config, clientConn)
+
+	t.Run("TestSendMessageEvents", func(t *testing.T) {
+		clientConn.On("processing.send_message",
+			clientmock.Event(&domain.ProcessingEvent{ValueEnumType: domain.ProcessingEventWillSend{MessageID: "m1"}}),
+			clientmock.Event(&domain.ProcessingEvent{ValueEnumType: domain.ProcessingEventDidSend{MessageID: "m1"}}),
+			clientmock.Result(&domain.ResultOfSendMessage{ShardBlockID: "sb1"}),
+		)
+
+		var messageIDs []string
+		result, err := processingUC.SendMessage(&domain.ParamsOfSendMessage{Message: "te6c", SendEvents: true}, func(event *domain.ProcessingEvent) {
+			switch value := event.ValueEnumType.(type) {
+			case domain.ProcessingEventWillSend:
+				messageIDs = append(messageIDs, value.MessageID)
+			case domain.ProcessingEventDidSend:
+				messageIDs = append(messageIDs, value.MessageID)
+			}
+		})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "sb1", result.ShardBlockID)
+		assert.Equal(t, []string{"m1", "m1"}, messageIDs)
+	})
+
+	t.Run("TestWaitForTransactionError", func(t *testing.T) {
+		clientConn.On("processing.wait_for_transaction",
+			clientmock.Event(&domain.ProcessingEvent{ValueEnumType: domain.ProcessingEventWillFetchNextBlock{MessageID: "m1"}}),
+			clientmock.Error(ErrTransactionWaitTimeout),
+		)
+
+		_, err := processingUC.WaitForTransaction(&domain.ParamsOfWaitForTransaction{Message: "te6c", SendEvents: true}, func(*domain.ProcessingEvent) {})
+		assert.True(t, errors.Is(err, ErrTransactionWaitTimeout))
+	})
+
+	t.Run("TestMalformedEvent", func(t *testing.T) {
+		clientConn.On("processing.process_message",
+			clientmock.Event(`{"type":"Unknown"}`),
+			clientmock.Result(&domain.ResultOfProcessMessage{}),
+		)
+
+		_, err := processingUC.ProcessMessage(&domain.ParamsOfProcessMessage{SendEvents: true}, func(*domain.ProcessingEvent) {})
+		assert.NotEqual(t, nil, err)
+	})
+}
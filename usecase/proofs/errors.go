@@ -0,0 +1,11 @@
+package proofs
+
+import "github.com/markgenuine/ever-client-go/domain"
+
+// Errors of proofs module returned by SDK, use errors.Is to check them.
+var (
+	ErrInvalidData           = domain.NewClientError(domain.ProofsErrorCode, "InvalidData")
+	ErrProofCheckFailed      = domain.NewClientError(domain.ProofsErrorCode, "ProofCheckFailed")
+	ErrInternalError         = domain.NewClientError(domain.ProofsErrorCode, "InternalError")
+	ErrDataDiffersFromProven = domain.NewClientError(domain.ProofsErrorCode, "DataDiffersFromProven")
+)
@@ -1,6 +1,8 @@
 package proofs
 
 import (
+	"context"
+
 	"github.com/markgenuine/ever-client-go/domain"
 )
 
@@ -33,7 +35,12 @@ func NewProofs(
 // the persistent local storage (e.g. file system for native environments or browser's IndexedDB for the web); otherwise
 // all the data is cached only in memory in current client's context and will be lost after destruction of the client.
 func (pr *proofs) ProofBlockData(data *domain.ParamsOfProofBlockData) error {
-	_, err := pr.client.GetResponse("proofs.proof_block_data", data)
+	return pr.ProofBlockDataContext(context.Background(), data)
+}
+
+// ProofBlockDataContext - ProofBlockData with ctx to cancel waiting for the result.
+func (pr *proofs) ProofBlockDataContext(ctx context.Context, data *domain.ParamsOfProofBlockData) error {
+	_, err := pr.client.GetResponseContext(ctx, "proofs.proof_block_data", data)
 	return err
 }
 
@@ -54,7 +61,12 @@ func (pr *proofs) ProofBlockData(data *domain.ParamsOfProofBlockData) error {
 // the persistent local storage (e.g. file system for native environments or browser's IndexedDB for the web); otherwise
 // all the data is cached only in memory in current client's context and will be lost after destruction of the client.
 func (pr *proofs) ProofTransactionData(data *domain.ParamsOfProofTransactionData) error {
-	_, err := pr.client.GetResponse("proofs.proof_transaction_data", data)
+	return pr.ProofTransactionDataContext(context.Background(), data)
+}
+
+// ProofTransactionDataContext - ProofTransactionData with ctx to cancel waiting for the result.
+func (pr *proofs) ProofTransactionDataContext(ctx context.Context, data *domain.ParamsOfProofTransactionData) error {
+	_, err := pr.client.GetResponseContext(ctx, "proofs.proof_transaction_data", data)
 	return err
 }
 
@@ -73,6 +85,11 @@ func (pr *proofs) ProofTransactionData(data *domain.ParamsOfProofTransactionData
 //
 // For more information about proofs checking, see description of proof_block_data function.
 func (pr *proofs) ParamsMessageData(data *domain.ParamsOfProofMessageData) error {
-	_, err := pr.client.GetResponse("proofs.proof_message_data", data)
+	return pr.ParamsMessageDataContext(context.Background(), data)
+}
+
+// ParamsMessageDataContext - ParamsMessageData with ctx to cancel waiting for the result.
+func (pr *proofs) ParamsMessageDataContext(ctx context.Context, data *domain.ParamsOfProofMessageData) error {
+	_, err := pr.client.GetResponseContext(ctx, "proofs.proof_message_data", data)
 	return err
 }
@@ -0,0 +1,22 @@
+package tvm
+
+import "github.com/markgenuine/ever-client-go/domain"
+
+// Errors of tvm module returned by SDK, use errors.Is to check them.
+var (
+	ErrCanNotReadTransaction      = domain.NewClientError(domain.TVMErrorCode, "CanNotReadTransaction")
+	ErrCanNotReadBlockchainConfig = domain.NewClientError(domain.TVMErrorCode, "CanNotReadBlockchainConfig")
+	ErrTransactionAborted         = domain.NewClientError(domain.TVMErrorCode, "TransactionAborted")
+	ErrInternalError              = domain.NewClientError(domain.TVMErrorCode, "InternalError")
+	ErrActionPhaseFailed          = domain.NewClientError(domain.TVMErrorCode, "ActionPhaseFailed")
+	ErrAccountCodeMissing         = domain.NewClientError(domain.TVMErrorCode, "AccountCodeMissing")
+	ErrLowBalance                 = domain.NewClientError(domain.TVMErrorCode, "LowBalance")
+	ErrAccountFrozenOrDeleted     = domain.NewClientError(domain.TVMErrorCode, "AccountFrozenOrDeleted")
+	ErrAccountMissing             = domain.NewClientError(domain.TVMErrorCode, "AccountMissing")
+	ErrUnknownExecutionError      = domain.NewClientError(domain.TVMErrorCode, "UnknownExecutionError")
+	ErrInvalidInputStack          = domain.NewClientError(domain.TVMErrorCode, "InvalidInputStack")
+	ErrInvalidAccountBoc          = domain.NewClientError(domain.TVMErrorCode, "InvalidAccountBoc")
+	ErrInvalidMessageType         = domain.NewClientError(domain.TVMErrorCode, "InvalidMessageType")
+	ErrContractExecutionError     = domain.NewClientError(domain.TVMErrorCode, "ContractExecutionError")
+	ErrAccountIsSuspended         = domain.NewClientError(domain.TVMErrorCode, "AccountIsSuspended")
+)
@@ -1,6 +1,8 @@
 package tvm
 
 import (
+	"context"
+
 	"github.com/markgenuine/ever-client-go/domain"
 )
 
@@ -23,21 +25,36 @@ func NewTvm(
 // Performs all the phases of contract execution on Transaction Executor
 // - the same component that is used on Validator Nodes.
 func (t *tvm) RunExecutor(pORE *domain.ParamsOfRunExecutor) (*domain.ResultOfRunExecuteMessage, error) {
+	return t.RunExecutorContext(context.Background(), pORE)
+}
+
+// RunExecutorContext - RunExecutor with ctx to cancel waiting for the result.
+func (t *tvm) RunExecutorContext(ctx context.Context, pORE *domain.ParamsOfRunExecutor) (*domain.ResultOfRunExecuteMessage, error) {
 	result := new(domain.ResultOfRunExecuteMessage)
-	err := t.client.GetResult("tvm.run_executor", pORE, result)
+	err := t.client.GetResultContext(ctx, "tvm.run_executor", pORE, result)
 	return result, err
 }
 
 // RunTvm - Executes get-methods of ABI-compatible contracts.
 func (t *tvm) RunTvm(pORT *domain.ParamsOfRunTvm) (*domain.ResultOfRunTvm, error) {
+	return t.RunTvmContext(context.Background(), pORT)
+}
+
+// RunTvmContext - RunTvm with ctx to cancel waiting for the result.
+func (t *tvm) RunTvmContext(ctx context.Context, pORT *domain.ParamsOfRunTvm) (*domain.ResultOfRunTvm, error) {
 	result := new(domain.ResultOfRunTvm)
-	err := t.client.GetResult("tvm.run_tvm", pORT, result)
+	err := t.client.GetResultContext(ctx, "tvm.run_tvm", pORT, result)
 	return result, err
 }
 
 // RunGet - Executes a get-method of FIFT contract.
 func (t *tvm) RunGet(pORG *domain.ParamsOfRunGet) (*domain.ResultOfRunGet, error) {
+	return t.RunGetContext(context.Background(), pORG)
+}
+
+// RunGetContext - RunGet with ctx to cancel waiting for the result.
+func (t *tvm) RunGetContext(ctx context.Context, pORG *domain.ParamsOfRunGet) (*domain.ResultOfRunGet, error) {
 	result := new(domain.ResultOfRunGet)
-	err := t.client.GetResult("tvm.run_get", pORG, result)
+	err := t.client.GetResultContext(ctx, "tvm.run_get", pORG, result)
 	return result, err
 }
@@ -0,0 +1,19 @@
+//go:build cgo && !nocgo
+// +build cgo,!nocgo
+
+package util
+
+import "C"
+
+type (
+	// ExportedCChar ...
+	ExportedCChar C.char
+
+	// ExportedCInt ...
+	ExportedCInt C.int
+)
+
+// CToString ...
+func CToString(valueString *ExportedCChar, valueLen ExportedCInt) string {
+	return C.GoStringN((*C.char)(valueString), (C.int)(valueLen))
+}